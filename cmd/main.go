@@ -10,11 +10,13 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 
+	"github.com/NKTKLN/todo-api/models"
 	"github.com/NKTKLN/todo-api/pkg/common"
 	"github.com/NKTKLN/todo-api/pkg/db/minio"
 	"github.com/NKTKLN/todo-api/pkg/db/postgres"
 	"github.com/NKTKLN/todo-api/pkg/db/redis"
 	"github.com/NKTKLN/todo-api/pkg/handlers"
+	"github.com/NKTKLN/todo-api/pkg/scheduler"
 	"github.com/NKTKLN/todo-api/server"
 )
 
@@ -30,6 +32,11 @@ import (
 // @in                          header
 // @name                        token
 
+// @securityDefinitions.apikey  bearer
+// @in                          header
+// @name                        Authorization
+// @description                 Access token with the "Bearer " prefix
+
 // @license.name  MIT
 // @license.url   https://github.com/NKTKLN/todo-api/blob/main/LICENSE
 
@@ -50,12 +57,26 @@ func main() {
 		logrus.Fatalf("error when connecting to the postgres database: %s", err.Error())
 	}
 
+	// Asymmetric keys for signing jwt tokens
+	var jwtKeyFiles []common.JWTKeyFile
+	if err = viper.UnmarshalKey("api.jwt.keys", &jwtKeyFiles); err != nil {
+		logrus.Fatalf("error when reading jwt keys config: %s", err.Error())
+	}
+	jwtKeys, err := common.LoadJWTKeys(jwtKeyFiles)
+	if err != nil {
+		logrus.Fatalf("error when loading jwt keys: %s", err.Error())
+	}
+
 	redisClient, err := redis.Connect(
 		fmt.Sprintf("%s:%d", viper.GetString("databases.redis.host"), viper.GetInt("databases.redis.port")),
 		viper.GetString("databases.redis.password"),
 		viper.GetInt("databases.redis.email-db"),
 		viper.GetInt("databases.redis.access-token-db"),
 		viper.GetInt("databases.redis.refresh-token-db"),
+		viper.GetInt("databases.redis.session-db"),
+		viper.GetInt("databases.redis.limit-db"),
+		viper.GetInt("databases.redis.reminder-db"),
+		jwtKeys,
 	)
 	if err != nil {
 		logrus.Fatalf("error when connecting to the redis database: %s", err.Error())
@@ -74,6 +95,13 @@ func main() {
 		logrus.Fatalf("error when creting bucket in MinIO database: %s", err.Error())
 	}
 
+	// Tokens of disabled users are checked in the redis
+	for _, userId := range postgresDB.GetDisabledUserIds() {
+		if err = redisClient.AddDisabledUser(context.Background(), userId); err != nil {
+			logrus.Fatalf("error when loading disabled users to Redis database: %s", err.Error())
+		}
+	}
+
 	emailAuthData := common.NewEmailProvider(
 		viper.GetString("smtp.email"), 
 		viper.GetString("smtp.password"), 
@@ -86,7 +114,27 @@ func main() {
 		RedisClient:   redisClient,
 		MinIOClient:   minioClient,
 		EmailAuthData: emailAuthData,
+		JWTKeys:       jwtKeys,
+		Limits: models.LimitSettings{
+			SignIn:   limitConfig("limits.sign-in"),
+			SignInIP: limitConfig("limits.sign-in-ip"),
+			Email:    limitConfig("limits.email"),
+			EmailIP:  limitConfig("limits.email-ip"),
+		},
+	}
+
+	// Every replica runs the scheduler, the reminders are claimed in the redis
+	reminderScheduler := scheduler.Scheduler{
+		PostgresDB:  postgresDB,
+		RedisClient: redisClient,
+		Notifier:    scheduler.NewEmailNotifier(emailAuthData),
+		Interval:    viper.GetDuration("reminders.interval"),
+	}
+	if err = reminderScheduler.LoadReminders(context.Background()); err != nil {
+		logrus.Fatalf("error when loading reminders to Redis database: %s", err.Error())
 	}
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	go reminderScheduler.Run(schedulerCtx)
 
 	srv := new(server.Server)
 	go func() {
@@ -103,6 +151,8 @@ func main() {
 
 	logrus.Print("TodoApi Shutting Down")
 
+	stopScheduler()
+
 	if err := srv.Shutdown(context.Background()); err != nil {
 		logrus.Errorf("error occured on server shutting down: %s", err.Error())
 	}
@@ -113,3 +163,12 @@ func initConfig() error {
 	viper.SetConfigName("config")
 	return viper.ReadInConfig()
 }
+
+func limitConfig(key string) models.Limit {
+	return models.Limit{
+		MaxAttempts: viper.GetInt(key + ".max-attempts"),
+		Window:      viper.GetDuration(key + ".window"),
+		Lockout:     viper.GetDuration(key + ".lockout"),
+		MaxLockout:  viper.GetDuration(key + ".max-lockout"),
+	}
+}
@@ -24,8 +24,8 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/sign-in": {
-            "post": {
+        "/.well-known/jwks.json": {
+            "get": {
                 "consumes": [
                     "application/json"
                 ],
@@ -35,42 +35,27 @@ const docTemplate = `{
                 "tags": [
                     "Authorization"
                 ],
-                "summary": "Sign in to your account",
-                "parameters": [
-                    {
-                        "description": "User data",
-                        "name": "LoginData",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UserLoginData"
-                        }
-                    }
-                ],
+                "summary": "Public keys for verifying tokens",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.UserTokens"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.ApiError"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.ApiError"
+                            "$ref": "#/definitions/models.JWKS"
                         }
                     }
                 }
             }
         },
-        "/auth/sign-up": {
-            "post": {
+        "/admin/users": {
+            "get": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -78,25 +63,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authorization"
+                    "Admin"
                 ],
-                "summary": "Register a user",
+                "summary": "Shows users with search and pagination",
                 "parameters": [
                     {
-                        "description": "User data",
-                        "name": "UserData",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UserData"
-                        }
+                        "type": "string",
+                        "description": "Part of the email, username or name",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starts from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of users on the page, all users without the limit and the cursor",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ApiMessage"
+                            "$ref": "#/definitions/models.ApiShowUsers"
                         }
                     },
                     "400": {
@@ -105,8 +99,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ApiError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.ApiError"
                         }
@@ -120,8 +120,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/verify": {
-            "get": {
+        "/admin/users/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -129,15 +137,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authorization"
+                    "Admin"
                 ],
-                "summary": "Confirm the new user's email",
+                "summary": "Delete user account",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Verification key",
-                        "name": "key",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -145,7 +153,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.UserTokens"
+                            "$ref": "#/definitions/models.ApiMessage"
                         }
                     },
                     "400": {
@@ -154,6 +162,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ApiError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -163,11 +189,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/todo/list/add": {
+        "/admin/users/{id}/disable": {
             "post": {
                 "security": [
                     {
                         "token": []
+                    },
+                    {
+                        "bearer": []
                     }
                 ],
                 "consumes": [
@@ -177,18 +206,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Working with lists"
+                    "Admin"
                 ],
-                "summary": "Create list",
+                "summary": "Disable user account",
                 "parameters": [
                     {
-                        "description": "List data",
-                        "name": "ListData",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ApiListData"
-                        }
+                        "type": "integer",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -204,6 +231,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ApiError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -219,11 +258,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/todo/list/delete": {
-            "delete": {
+        "/admin/users/{id}/enable": {
+            "post": {
                 "security": [
                     {
                         "token": []
+                    },
+                    {
+                        "bearer": []
                     }
                 ],
                 "consumes": [
@@ -233,15 +275,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Working with lists"
+                    "Admin"
                 ],
-                "summary": "Delete list",
+                "summary": "Enable user account",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "The id of the list to be deleted",
-                        "name": "list_id",
-                        "in": "query",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -252,6 +294,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ApiMessage"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -267,11 +327,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/todo/list/edit": {
-            "put": {
+        "/admin/users/{id}/reset-password": {
+            "post": {
                 "security": [
                     {
                         "token": []
+                    },
+                    {
+                        "bearer": []
                     }
                 ],
                 "consumes": [
@@ -281,18 +344,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Working with lists"
+                    "Admin"
                 ],
-                "summary": "Edit list",
+                "summary": "Force user password reset",
                 "parameters": [
                     {
-                        "description": "List data",
-                        "name": "ListData",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ListEditData"
-                        }
+                        "type": "integer",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -308,6 +369,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ApiError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -323,11 +396,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/todo/list/show": {
-            "get": {
+        "/admin/users/{id}/sessions": {
+            "delete": {
                 "security": [
                     {
                         "token": []
+                    },
+                    {
+                        "bearer": []
                     }
                 ],
                 "consumes": [
@@ -337,14 +413,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Working with lists"
+                    "Admin"
+                ],
+                "summary": "Revoke all user sessions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Shows all lists created by the user",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ApiShowLists"
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
                         }
                     },
                     "404": {
@@ -352,17 +455,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.ApiError"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
                     }
                 }
             }
         },
-        "/todo/subtask/add": {
+        "/auth/sign-in": {
             "post": {
-                "security": [
-                    {
-                        "token": []
-                    }
-                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -370,17 +474,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Working with subtasks"
+                    "Authorization"
                 ],
-                "summary": "Create subtask",
+                "summary": "Sign in to your account",
                 "parameters": [
                     {
-                        "description": "Subtask data",
-                        "name": "SubtaskData",
+                        "description": "User data",
+                        "name": "LoginData",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ApiSubtaskData"
+                            "$ref": "#/definitions/models.UserLoginData"
                         }
                     }
                 ],
@@ -388,7 +492,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ApiMessage"
+                            "$ref": "#/definitions/models.UserTokens"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiTwoFactorChallenge"
                         }
                     },
                     "400": {
@@ -397,8 +507,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ApiError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/models.ApiError"
                         }
@@ -412,13 +528,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/todo/subtask/delete": {
-            "delete": {
-                "security": [
-                    {
-                        "token": []
-                    }
-                ],
+        "/auth/sign-in/2fa": {
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -426,32 +537,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Working with subtasks"
+                    "Authorization"
                 ],
-                "summary": "Delete subtask",
+                "summary": "Complete sign in with two-factor authentication",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "The id of the subtask to be deleted",
-                        "name": "subtask_id",
-                        "in": "query",
-                        "required": true
+                        "description": "Challenge and TOTP or recovery code",
+                        "name": "SignInData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorSignIn"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ApiMessage"
+                            "$ref": "#/definitions/models.UserTokens"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.ApiError"
                         }
                     },
-                    "500": {
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.ApiError"
@@ -460,11 +585,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/todo/subtask/edit": {
-            "put": {
+        "/auth/sign-out": {
+            "post": {
                 "security": [
                     {
                         "token": []
+                    },
+                    {
+                        "bearer": []
                     }
                 ],
                 "consumes": [
@@ -474,20 +602,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Working with subtasks"
-                ],
-                "summary": "Edit subtask",
-                "parameters": [
-                    {
-                        "description": "Subtask data",
-                        "name": "SubtaskData",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.SubtaskEditData"
-                        }
-                    }
+                    "Authorization"
                 ],
+                "summary": "Sign out of the current session",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -495,14 +612,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ApiMessage"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.ApiError"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.ApiError"
                         }
@@ -516,11 +627,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/todo/subtask/show": {
-            "get": {
+        "/auth/sign-out-all": {
+            "post": {
                 "security": [
                     {
                         "token": []
+                    },
+                    {
+                        "bearer": []
                     }
                 ],
                 "consumes": [
@@ -530,27 +644,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Working with subtasks"
-                ],
-                "summary": "Shows all subtasks in the task",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Task id with subtasks",
-                        "name": "task_id",
-                        "in": "query",
-                        "required": true
-                    }
+                    "Authorization"
                 ],
+                "summary": "Sign out of all sessions",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ApiShowSubtasks"
+                            "$ref": "#/definitions/models.ApiMessage"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.ApiError"
                         }
@@ -564,13 +669,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/todo/task/add": {
+        "/auth/sign-up": {
             "post": {
-                "security": [
-                    {
-                        "token": []
-                    }
-                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -578,17 +678,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Working with tasks"
+                    "Authorization"
                 ],
-                "summary": "Create task",
+                "summary": "Register a user",
                 "parameters": [
                     {
-                        "description": "Task data",
-                        "name": "TaskData",
+                        "description": "User data",
+                        "name": "UserData",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ApiTaskData"
+                            "$ref": "#/definitions/models.UserData"
                         }
                     }
                 ],
@@ -611,6 +711,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ApiError"
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -620,13 +726,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/todo/task/delete": {
-            "delete": {
-                "security": [
-                    {
-                        "token": []
-                    }
-                ],
+        "/auth/verify": {
+            "get": {
                 "consumes": [
                     "application/json"
                 ],
@@ -634,27 +735,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Working with tasks"
+                    "Authorization"
                 ],
-                "summary": "Delete task",
+                "summary": "Confirm the new user's email",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "The id of the task to be deleted",
-                        "name": "task_id",
+                        "type": "string",
+                        "description": "Verification key",
+                        "name": "key",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Device name",
+                        "name": "device",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ApiMessage"
+                            "$ref": "#/definitions/models.UserTokens"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.ApiError"
                         }
@@ -668,11 +775,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/todo/task/edit": {
-            "put": {
+        "/todo/assignee/add": {
+            "post": {
                 "security": [
                     {
                         "token": []
+                    },
+                    {
+                        "bearer": []
                     }
                 ],
                 "consumes": [
@@ -682,17 +792,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Working with tasks"
+                    "Working with assignees"
                 ],
-                "summary": "Edit task",
+                "summary": "Assign the task or subtask to a member of the list, the assignee is notified by email",
                 "parameters": [
                     {
-                        "description": "Task data",
-                        "name": "TaskData",
+                        "description": "Assignee data",
+                        "name": "AssigneeData",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.TaskEditData"
+                            "$ref": "#/definitions/models.ApiAssigneeData"
                         }
                     }
                 ],
@@ -709,6 +819,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ApiError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -724,11 +846,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/todo/task/show": {
-            "get": {
+        "/todo/assignee/delete": {
+            "delete": {
                 "security": [
                     {
                         "token": []
+                    },
+                    {
+                        "bearer": []
                     }
                 ],
                 "consumes": [
@@ -738,14 +863,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Working with tasks"
+                    "Working with assignees"
                 ],
-                "summary": "Shows all tasks in the list",
+                "summary": "Remove the assignee from the task or subtask, the assignees can remove themselves",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "List id with tasks",
-                        "name": "list_id",
+                        "description": "Id of the task or subtask",
+                        "name": "task_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of the assignee",
+                        "name": "user_id",
                         "in": "query",
                         "required": true
                     }
@@ -754,7 +886,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ApiShowTasks"
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
                         }
                     },
                     "404": {
@@ -772,11 +916,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/delete/account": {
-            "delete": {
+        "/todo/comment/add": {
+            "post": {
                 "security": [
                     {
                         "token": []
+                    },
+                    {
+                        "bearer": []
                     }
                 ],
                 "consumes": [
@@ -786,16 +933,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User settings"
+                    "Working with comments"
                 ],
-                "summary": "Delete user account",
+                "summary": "Comment the task or subtask, the mentioned members of the list are notified by email",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User password",
-                        "name": "password",
-                        "in": "query",
-                        "required": true
+                        "description": "Comment data, members are mentioned as @username",
+                        "name": "CommentData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiCommentData"
+                        }
                     }
                 ],
                 "responses": {
@@ -811,6 +960,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ApiError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -826,11 +987,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/delete/icon": {
+        "/todo/comment/delete": {
             "delete": {
                 "security": [
                     {
                         "token": []
+                    },
+                    {
+                        "bearer": []
                     }
                 ],
                 "consumes": [
@@ -840,9 +1004,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User settings"
+                    "Working with comments"
+                ],
+                "summary": "Delete the comment, the author and the admins of the list can delete it",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "The id of the comment to be deleted",
+                        "name": "comment_id",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "Delete user icon",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -850,8 +1023,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ApiMessage"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.ApiError"
                         }
@@ -871,11 +1050,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/settings/reset/email": {
-            "post": {
+        "/todo/comment/edit": {
+            "put": {
                 "security": [
                     {
                         "token": []
+                    },
+                    {
+                        "bearer": []
                     }
                 ],
                 "consumes": [
@@ -885,17 +1067,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User settings"
+                    "Working with comments"
                 ],
-                "summary": "Reset user email",
+                "summary": "Edit the comment, only the author can edit it and only the newly mentioned members are notified",
                 "parameters": [
                     {
-                        "description": "New user email",
-                        "name": "NewUserEmail",
+                        "description": "New comment data",
+                        "name": "CommentData",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UserEmail"
+                            "$ref": "#/definitions/models.CommentEditData"
                         }
                     }
                 ],
@@ -912,6 +1094,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ApiError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -921,8 +1121,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/settings/reset/password": {
-            "post": {
+        "/todo/comment/show": {
+            "get": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -930,25 +1138,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User settings"
+                    "Working with comments"
                 ],
-                "summary": "Reset user password",
+                "summary": "Shows the comments of the task or subtask from the oldest one",
                 "parameters": [
                     {
-                        "description": "User data",
-                        "name": "UserEmail",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UserEmail"
-                        }
+                        "type": "integer",
+                        "description": "Id of the task or subtask",
+                        "name": "task_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page from the next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.UserTokens"
+                            "$ref": "#/definitions/models.ApiShowComments"
                         }
                     },
                     "400": {
@@ -957,6 +1175,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ApiError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -966,8 +1196,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/settings/update/email": {
-            "patch": {
+        "/todo/filter/add": {
+            "post": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -975,16 +1213,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User settings"
+                    "Working with saved filters"
                 ],
-                "summary": "Update user email",
+                "summary": "Create saved filter",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Veryfication key",
-                        "name": "key",
-                        "in": "query",
-                        "required": true
+                        "description": "Filter data",
+                        "name": "FilterData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiSavedFilterData"
+                        }
                     }
                 ],
                 "responses": {
@@ -1000,6 +1240,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ApiError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1009,11 +1255,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/settings/update/icon": {
-            "put": {
+        "/todo/filter/delete": {
+            "delete": {
                 "security": [
                     {
                         "token": []
+                    },
+                    {
+                        "bearer": []
                     }
                 ],
                 "consumes": [
@@ -1023,15 +1272,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User settings"
+                    "Working with saved filters"
                 ],
-                "summary": "Update user icon",
+                "summary": "Delete saved filter",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "User icon",
-                        "name": "icon",
-                        "in": "formData",
+                        "type": "integer",
+                        "description": "The id of the filter to be deleted",
+                        "name": "filter_id",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -1042,8 +1291,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ApiMessage"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.ApiError"
                         }
@@ -1057,11 +1312,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/settings/update/name": {
-            "patch": {
+        "/todo/filter/edit": {
+            "put": {
                 "security": [
                     {
                         "token": []
+                    },
+                    {
+                        "bearer": []
                     }
                 ],
                 "consumes": [
@@ -1071,17 +1329,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User settings"
+                    "Working with saved filters"
                 ],
-                "summary": "Change user name",
+                "summary": "Edit saved filter",
                 "parameters": [
                     {
-                        "description": "User name",
-                        "name": "NewUserName",
+                        "description": "Filter data",
+                        "name": "FilterData",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UserName"
+                            "$ref": "#/definitions/models.SavedFilterEditData"
                         }
                     }
                 ],
@@ -1092,6 +1350,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ApiMessage"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1107,8 +1377,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/settings/update/password": {
-            "patch": {
+        "/todo/filter/show": {
+            "get": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -1116,32 +1394,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User settings"
+                    "Working with saved filters"
                 ],
-                "summary": "Update user password",
+                "summary": "Shows tasks from all lists matching the saved filter",
                 "parameters": [
                     {
-                        "description": "User password",
-                        "name": "NewUserPassword",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UserPassword"
-                        }
+                        "type": "integer",
+                        "description": "Filter id",
+                        "name": "filter_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of tasks on the page, all tasks without the limit and the cursor",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Veryfication key",
-                        "name": "key",
-                        "in": "query",
-                        "required": true
+                        "description": "Cursor of the page from next_cursor",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.UserTokens"
+                            "$ref": "#/definitions/models.ApiShowViewTasks"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
                         }
                     },
                     "404": {
@@ -1159,42 +1452,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/settings/update/token": {
-            "put": {
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "User settings"
-                ],
-                "summary": "Update user token",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Refresh token",
-                        "name": "refresh_token",
-                        "in": "header",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/models.UserTokens"
-                        }
-                    }
-                }
-            }
-        },
-        "/user/settings/update/username": {
-            "patch": {
+        "/todo/list/add": {
+            "post": {
                 "security": [
                     {
                         "token": []
+                    },
+                    {
+                        "bearer": []
                     }
                 ],
                 "consumes": [
@@ -1204,17 +1469,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User settings"
+                    "Working with lists"
                 ],
-                "summary": "Change user username",
+                "summary": "Create list, editors of the workspace can create lists in it",
                 "parameters": [
                     {
-                        "description": "Username",
-                        "name": "NewUsername",
+                        "description": "List data",
+                        "name": "ListData",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UserUsername"
+                            "$ref": "#/definitions/models.ApiListData"
                         }
                     }
                 ],
@@ -1225,6 +1490,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ApiMessage"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1240,8 +1523,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/show/data-by-id": {
-            "get": {
+        "/todo/list/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -1249,14 +1540,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Show user data"
+                    "Working with lists"
                 ],
-                "summary": "Get basic user data",
+                "summary": "Delete list, only the owner can delete the list. Admins of the workspace own its lists.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User id",
-                        "name": "user_id",
+                        "description": "The id of the list to be deleted",
+                        "name": "list_id",
                         "in": "query",
                         "required": true
                     }
@@ -1265,7 +1556,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ShowUserData"
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
                         }
                     },
                     "404": {
@@ -1283,11 +1586,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/show/data-by-token": {
-            "get": {
+        "/todo/list/edit": {
+            "put": {
                 "security": [
                     {
                         "token": []
+                    },
+                    {
+                        "bearer": []
                     }
                 ],
                 "consumes": [
@@ -1297,14 +1603,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Show user data"
+                    "Working with lists"
+                ],
+                "summary": "Edit list, only admins of the list can edit it. The index is the place among the lists of the owner or workspace.",
+                "parameters": [
+                    {
+                        "description": "List data",
+                        "name": "ListData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ListEditData"
+                        }
+                    }
                 ],
-                "summary": "Get basic user data",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ShowUserData"
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
                         }
                     },
                     "404": {
@@ -1322,8 +1657,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/show/icon": {
+        "/todo/list/members": {
             "get": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -1331,21 +1674,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Show user data"
+                    "Working with list members"
                 ],
-                "summary": "Get user icon",
+                "summary": "Shows the owner and members of the list",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User id",
-                        "name": "user_id",
+                        "description": "List id",
+                        "name": "list_id",
                         "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiShowListMembers"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.ApiError"
                         }
@@ -1363,112 +1712,4982 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "models.ApiError": {
-            "type": "object",
+            },
+            "post": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with list members"
+                ],
+                "summary": "Add a member to the list by the username or email, only admins of the list can add members",
+                "parameters": [
+                    {
+                        "description": "Member data",
+                        "name": "MemberData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ListMemberAddData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with list members"
+                ],
+                "summary": "Remove the member from the list, only admins of the list can remove members",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "List id",
+                        "name": "list_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of the member to be removed",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with list members"
+                ],
+                "summary": "Change the role of the list member, only admins of the list can change roles",
+                "parameters": [
+                    {
+                        "description": "Member data",
+                        "name": "MemberData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ListMemberEditData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/list/members/accept": {
+            "post": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with list members"
+                ],
+                "summary": "Accept the invitation to the list sent to the user's email, new users pass the invitation key to the sign up instead",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invitation key",
+                        "name": "key",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/list/members/invite": {
+            "post": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with list members"
+                ],
+                "summary": "Invite a person without an account to the list by email, only admins of the list can send invitations",
+                "parameters": [
+                    {
+                        "description": "Invitation data",
+                        "name": "InviteData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ListInviteData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/list/members/leave": {
+            "delete": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with list members"
+                ],
+                "summary": "Leave the shared list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "List id",
+                        "name": "list_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/list/show": {
+            "get": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with lists"
+                ],
+                "summary": "Shows saved filters, lists created by the user, lists shared with the user and lists of the user workspaces grouped by the workspace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Part of the name or comment",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of lists on the page, all lists without the limit and the cursor",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page from next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiShowLists"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/reminder/add": {
+            "post": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with reminders"
+                ],
+                "summary": "Create task reminder",
+                "parameters": [
+                    {
+                        "description": "Reminder data, either before in minutes or remind_at",
+                        "name": "ReminderData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiReminderData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/reminder/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with reminders"
+                ],
+                "summary": "Delete task reminder",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "The id of the reminder to be deleted",
+                        "name": "reminder_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/reminder/show": {
+            "get": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with reminders"
+                ],
+                "summary": "Shows all reminders of the task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task id with reminders",
+                        "name": "task_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiShowReminders"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/search": {
+            "get": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Full-text search in the names and comments of lists, tasks and subtasks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Searched words, every word is matched as a prefix",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of results on the page, all results without the limit and the cursor",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page from next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiSearchResults"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/subtask/add": {
+            "post": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with subtasks"
+                ],
+                "summary": "Create subtask",
+                "parameters": [
+                    {
+                        "description": "Subtask data",
+                        "name": "SubtaskData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiSubtaskData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/subtask/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with subtasks"
+                ],
+                "summary": "Delete subtask",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "The id of the subtask to be deleted",
+                        "name": "subtask_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/subtask/edit": {
+            "put": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with subtasks"
+                ],
+                "summary": "Edit subtask",
+                "parameters": [
+                    {
+                        "description": "Subtask data",
+                        "name": "SubtaskData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SubtaskEditData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/subtask/promote": {
+            "put": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with subtasks"
+                ],
+                "summary": "Turn subtask into the task of the list",
+                "parameters": [
+                    {
+                        "description": "Subtask data",
+                        "name": "SubtaskData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SubtaskPromoteData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/subtask/show": {
+            "get": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with subtasks"
+                ],
+                "summary": "Shows all subtasks in the task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task id with subtasks",
+                        "name": "task_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only done or not done subtasks",
+                        "name": "done",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only special or not special subtasks",
+                        "name": "special",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only subtasks with the category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only subtasks with the end time before, in the 2006-01-02 15:04 format in the user timezone",
+                        "name": "due_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only subtasks with the end time after or at, in the 2006-01-02 15:04 format in the user timezone",
+                        "name": "due_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the name or comment",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of subtasks on the page, all subtasks without the limit and the cursor",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page from next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiShowSubtasks"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/tags": {
+            "get": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with tags"
+                ],
+                "summary": "Shows all user tags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiShowTags"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with tags"
+                ],
+                "summary": "Create tag",
+                "parameters": [
+                    {
+                        "description": "Tag data",
+                        "name": "TagData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiTagData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with tags"
+                ],
+                "summary": "Delete tag, the tag is removed from the categories of all tasks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "The id of the tag to be deleted",
+                        "name": "tag_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with tags"
+                ],
+                "summary": "Edit tag, the renamed tag is renamed in the categories of all tasks",
+                "parameters": [
+                    {
+                        "description": "Tag data, empty fields are not changed",
+                        "name": "TagData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TagEditData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/tags/autocomplete": {
+            "get": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with tags"
+                ],
+                "summary": "Autocomplete tags, the most used tags go first",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Beginning of the tag name",
+                        "name": "q",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiTagsAutocomplete"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/task/add": {
+            "post": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with tasks"
+                ],
+                "summary": "Create task",
+                "parameters": [
+                    {
+                        "description": "Task data",
+                        "name": "TaskData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiTaskData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/task/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with tasks"
+                ],
+                "summary": "Delete task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "The id of the task to be deleted",
+                        "name": "task_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/task/demote": {
+            "put": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with tasks"
+                ],
+                "summary": "Turn task with its subtasks into the subtask of another task or subtask",
+                "parameters": [
+                    {
+                        "description": "Task data",
+                        "name": "TaskData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TaskDemoteData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/task/edit": {
+            "put": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with tasks"
+                ],
+                "summary": "Edit task",
+                "parameters": [
+                    {
+                        "description": "Task data",
+                        "name": "TaskData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TaskEditData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/task/end-series": {
+            "put": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with tasks"
+                ],
+                "summary": "End the series of the recurring task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "The id of the recurring task",
+                        "name": "task_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/task/move": {
+            "put": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with tasks"
+                ],
+                "summary": "Move task with its subtasks to the list",
+                "parameters": [
+                    {
+                        "description": "Task data",
+                        "name": "TaskData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TaskMoveData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/task/show": {
+            "get": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with tasks"
+                ],
+                "summary": "Shows all tasks in the list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "List id with tasks",
+                        "name": "list_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "manual",
+                            "priority",
+                            "due",
+                            "created",
+                            "name"
+                        ],
+                        "type": "string",
+                        "description": "Sort mode, manual by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Order direction, desc by default for the priority and asc for the rest",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only done or not done tasks",
+                        "name": "done",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only special or not special tasks",
+                        "name": "special",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks with the category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks with the end time before, in the 2006-01-02 15:04 format in the user timezone",
+                        "name": "due_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks with the end time after or at, in the 2006-01-02 15:04 format in the user timezone",
+                        "name": "due_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the name or comment",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of tasks on the page, all tasks without the limit and the cursor",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page from next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiShowTasks"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/task/skip": {
+            "put": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with tasks"
+                ],
+                "summary": "Skip the occurrence of the recurring task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "The id of the recurring task",
+                        "name": "task_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/task/tree": {
+            "get": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with tasks"
+                ],
+                "summary": "Shows all tasks in the list with all levels of their subtasks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "List id with tasks",
+                        "name": "list_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiShowTaskTree"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/views/assigned-to-me": {
+            "get": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Views"
+                ],
+                "summary": "Shows not done tasks and subtasks from all lists assigned to the user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of tasks on the page, all tasks without the limit and the cursor",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page from next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiShowViewTasks"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/views/overdue": {
+            "get": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Views"
+                ],
+                "summary": "Shows not done tasks from all lists whose end time has passed",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of tasks on the page, all tasks without the limit and the cursor",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page from next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiShowViewTasks"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/views/starred": {
+            "get": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Views"
+                ],
+                "summary": "Shows not done special tasks from all lists",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of tasks on the page, all tasks without the limit and the cursor",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page from next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiShowViewTasks"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/views/today": {
+            "get": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Views"
+                ],
+                "summary": "Shows not done tasks from all lists due today in the user timezone",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of tasks on the page, all tasks without the limit and the cursor",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page from next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiShowViewTasks"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/views/upcoming": {
+            "get": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Views"
+                ],
+                "summary": "Shows not done tasks from all lists due in the next days after today in the user timezone",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of tasks on the page, all tasks without the limit and the cursor",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page from next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiShowViewTasks"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/workspace/add": {
+            "post": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with workspaces"
+                ],
+                "summary": "Create workspace, the user who created it becomes its admin",
+                "parameters": [
+                    {
+                        "description": "Workspace data",
+                        "name": "WorkspaceData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiWorkspaceData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/workspace/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with workspaces"
+                ],
+                "summary": "Delete workspace together with its lists, only admins of the workspace can delete it",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "The id of the workspace to be deleted",
+                        "name": "workspace_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/workspace/edit": {
+            "put": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with workspaces"
+                ],
+                "summary": "Rename workspace, only admins of the workspace can rename it",
+                "parameters": [
+                    {
+                        "description": "Workspace data",
+                        "name": "WorkspaceData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WorkspaceEditData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/workspace/members": {
+            "get": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with workspace members"
+                ],
+                "summary": "Shows the members of the workspace",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workspace id",
+                        "name": "workspace_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiShowWorkspaceMembers"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with workspace members"
+                ],
+                "summary": "Add a member to the workspace by the username or email, only admins of the workspace can add members",
+                "parameters": [
+                    {
+                        "description": "Member data",
+                        "name": "MemberData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WorkspaceMemberAddData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with workspace members"
+                ],
+                "summary": "Remove the member from the workspace, only admins of the workspace can remove members and any member can leave",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workspace id",
+                        "name": "workspace_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of the member to be removed",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with workspace members"
+                ],
+                "summary": "Change the role of the workspace member, only admins of the workspace can change roles",
+                "parameters": [
+                    {
+                        "description": "Member data",
+                        "name": "MemberData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WorkspaceMemberEditData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/workspace/show": {
+            "get": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Working with workspaces"
+                ],
+                "summary": "Shows the workspaces of the user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiShowWorkspaces"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/2fa/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Two-factor authentication"
+                ],
+                "summary": "Confirm enabling two-factor authentication",
+                "parameters": [
+                    {
+                        "description": "TOTP code",
+                        "name": "Code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorCode"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiRecoveryCodes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/2fa/disable": {
+            "post": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Two-factor authentication"
+                ],
+                "summary": "Disable two-factor authentication",
+                "parameters": [
+                    {
+                        "description": "User password and TOTP or recovery code",
+                        "name": "DisableData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorDisable"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/2fa/enable": {
+            "post": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Two-factor authentication"
+                ],
+                "summary": "Start enabling two-factor authentication",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiTwoFactorSetup"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/delete/account": {
+            "delete": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User settings"
+                ],
+                "summary": "Delete user account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User password",
+                        "name": "password",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/delete/icon": {
+            "delete": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User settings"
+                ],
+                "summary": "Delete user icon",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User sessions"
+                ],
+                "summary": "Shows all active sessions of the user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiShowSessions"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User sessions"
+                ],
+                "summary": "Delete session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The id of the session to be deleted",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/reset/email": {
+            "post": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User settings"
+                ],
+                "summary": "Reset user email",
+                "parameters": [
+                    {
+                        "description": "New user email",
+                        "name": "NewUserEmail",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserEmail"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/reset/password": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User settings"
+                ],
+                "summary": "Reset user password",
+                "parameters": [
+                    {
+                        "description": "User data",
+                        "name": "UserEmail",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserEmail"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserTokens"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/update/email": {
+            "patch": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User settings"
+                ],
+                "summary": "Update user email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Veryfication key",
+                        "name": "key",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/update/icon": {
+            "put": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User settings"
+                ],
+                "summary": "Update user icon",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "User icon",
+                        "name": "icon",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/update/name": {
+            "patch": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User settings"
+                ],
+                "summary": "Change user name",
+                "parameters": [
+                    {
+                        "description": "User name",
+                        "name": "NewUserName",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserName"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/update/password": {
+            "patch": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User settings"
+                ],
+                "summary": "Update user password",
+                "parameters": [
+                    {
+                        "description": "User password",
+                        "name": "NewUserPassword",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserPassword"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Veryfication key",
+                        "name": "key",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserTokens"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/update/rollup": {
+            "patch": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User settings"
+                ],
+                "summary": "Change completion rollup, with it the tasks are completed when all their subtasks are done",
+                "parameters": [
+                    {
+                        "description": "Is the rollup enabled",
+                        "name": "CompletionRollup",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserCompletionRollup"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/update/timezone": {
+            "patch": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User settings"
+                ],
+                "summary": "Change user timezone",
+                "parameters": [
+                    {
+                        "description": "IANA time zone name",
+                        "name": "NewUserTimezone",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserTimezone"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/update/token": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User settings"
+                ],
+                "summary": "Update user token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Refresh token",
+                        "name": "refresh_token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserTokens"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/update/username": {
+            "patch": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User settings"
+                ],
+                "summary": "Change user username",
+                "parameters": [
+                    {
+                        "description": "Username",
+                        "name": "NewUsername",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserUsername"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/show/data-by-id": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Show user data"
+                ],
+                "summary": "Get basic user data",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User id",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ShowUserData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/show/data-by-token": {
+            "get": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Show user data"
+                ],
+                "summary": "Get basic user data",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ShowUserData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/show/icon": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Show user data"
+                ],
+                "summary": "Get user icon",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User id",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/tokens": {
+            "get": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Personal access tokens"
+                ],
+                "summary": "Shows all personal access tokens of the user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiShowPersonalTokens"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Personal access tokens"
+                ],
+                "summary": "Create personal access token",
+                "parameters": [
+                    {
+                        "description": "Token data",
+                        "name": "TokenData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PersonalTokenData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiPersonalToken"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/tokens/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "token": []
+                    },
+                    {
+                        "bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Personal access tokens"
+                ],
+                "summary": "Revoke personal access token",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "The id of the token to be revoked",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApiError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.AdminUserData": {
+            "type": "object",
+            "properties": {
+                "disabled": {
+                    "type": "boolean"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "nktkln@example.com"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "name": {
+                    "type": "string",
+                    "example": "NKTKLN"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "user"
+                },
+                "totp_enabled": {
+                    "type": "boolean"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "nktkln"
+                }
+            }
+        },
+        "models.ApiAssigneeData": {
+            "type": "object",
+            "properties": {
+                "task_id": {
+                    "description": "Id of the task or subtask",
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1023456789
+                }
+            }
+        },
+        "models.ApiCommentData": {
+            "type": "object",
+            "properties": {
+                "task_id": {
+                    "description": "Id of the task or subtask",
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "text": {
+                    "type": "string",
+                    "example": "@nktkln the design is ready"
+                }
+            }
+        },
+        "models.ApiError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ApiListData": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "example": "Products needed for the party"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "List of products"
+                },
+                "workspace_id": {
+                    "description": "The list is created in the workspace instead of the user lists",
+                    "type": "integer",
+                    "example": 1023456789
+                }
+            }
+        },
+        "models.ApiMessage": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ApiPersonalToken": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2077-12-10 13:13"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2077-12-10 13:13"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "name": {
+                    "type": "string",
+                    "example": "CI"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "lists:read",
+                        "tasks:write"
+                    ]
+                },
+                "token": {
+                    "type": "string",
+                    "example": "pat_3f9a10c7b28d2e4b61f03f9a10c7b28d2e4b61f03f9a10c7b28d2e4b61f0aa"
+                }
+            }
+        },
+        "models.ApiRecoveryCodes": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "3f9a1-0c7b2",
+                        "8d2e4-b61f0"
+                    ]
+                }
+            }
+        },
+        "models.ApiReminderData": {
+            "type": "object",
+            "properties": {
+                "before": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "remind_at": {
+                    "type": "string",
+                    "example": "2077-12-10 12:00"
+                },
+                "task_id": {
+                    "type": "integer",
+                    "example": 1023456789
+                }
+            }
+        },
+        "models.ApiSavedFilterData": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Work this week"
+                },
+                "query": {
+                    "type": "string",
+                    "example": "category contains Work AND not done AND due within 7 days"
+                }
+            }
+        },
+        "models.ApiSearchResults": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "type": "string",
+                    "example": "WzAsMTAyMzQ1Njc4OV0"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SearchResultData"
+                    }
+                }
+            }
+        },
+        "models.ApiShowComments": {
+            "type": "object",
+            "properties": {
+                "comments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CommentData"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "WzAsMTAyMzQ1Njc4OV0"
+                }
+            }
+        },
+        "models.ApiShowListMembers": {
+            "type": "object",
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ListMemberData"
+                    }
+                }
+            }
+        },
+        "models.ApiShowLists": {
+            "type": "object",
+            "properties": {
+                "lists": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ListsData"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "WzAsMTAyMzQ1Njc4OV0"
+                }
+            }
+        },
+        "models.ApiShowPersonalTokens": {
+            "type": "object",
+            "properties": {
+                "tokens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PersonalTokenInfo"
+                    }
+                }
+            }
+        },
+        "models.ApiShowReminders": {
+            "type": "object",
+            "properties": {
+                "reminders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ReminderData"
+                    }
+                }
+            }
+        },
+        "models.ApiShowSessions": {
+            "type": "object",
+            "properties": {
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SessionData"
+                    }
+                }
+            }
+        },
+        "models.ApiShowSubtasks": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "type": "string",
+                    "example": "WzAsMTAyMzQ1Njc4OV0"
+                },
+                "subtasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SubtasksData"
+                    }
+                }
+            }
+        },
+        "models.ApiShowTags": {
+            "type": "object",
+            "properties": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TagsData"
+                    }
+                }
+            }
+        },
+        "models.ApiShowTaskTree": {
+            "type": "object",
+            "properties": {
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TaskTreeData"
+                    }
+                }
+            }
+        },
+        "models.ApiShowTasks": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "type": "string",
+                    "example": "WzAsMTAyMzQ1Njc4OV0"
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TasksData"
+                    }
+                }
+            }
+        },
+        "models.ApiShowUsers": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AdminUserData"
+                    }
+                }
+            }
+        },
+        "models.ApiShowViewTasks": {
+            "type": "object",
             "properties": {
-                "error": {
+                "next_cursor": {
+                    "type": "string",
+                    "example": "WzAsMTAyMzQ1Njc4OV0"
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ViewTasksData"
+                    }
+                }
+            }
+        },
+        "models.ApiShowWorkspaceMembers": {
+            "type": "object",
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WorkspaceMemberData"
+                    }
+                }
+            }
+        },
+        "models.ApiShowWorkspaces": {
+            "type": "object",
+            "properties": {
+                "workspaces": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WorkspacesData"
+                    }
+                }
+            }
+        },
+        "models.ApiSubtaskData": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "example": "Sugar-free"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Coca-Cola"
+                },
+                "task_id": {
+                    "type": "integer",
+                    "example": 1023456789
+                }
+            }
+        },
+        "models.ApiTagData": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "example": "#ff8800"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Shopping"
+                }
+            }
+        },
+        "models.ApiTagsAutocomplete": {
+            "type": "object",
+            "properties": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TagUsageData"
+                    }
+                }
+            }
+        },
+        "models.ApiTaskData": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "example": "Go to the supermarket on the way home"
+                },
+                "list_id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Buy drinks"
+                }
+            }
+        },
+        "models.ApiTwoFactorChallenge": {
+            "type": "object",
+            "properties": {
+                "challenge": {
+                    "type": "string",
+                    "example": "4b0a6bd2-4c5e-4d1f-9a0e-6f7e2b4c1d3a"
+                }
+            }
+        },
+        "models.ApiTwoFactorSetup": {
+            "type": "object",
+            "properties": {
+                "secret": {
+                    "type": "string",
+                    "example": "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+                },
+                "uri": {
+                    "type": "string",
+                    "example": "otpauth://totp/ToDo%20API:nktkln@example.com?algorithm=SHA1\u0026digits=6\u0026issuer=ToDo+API\u0026period=30\u0026secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+                }
+            }
+        },
+        "models.ApiWorkspaceData": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Company"
+                }
+            }
+        },
+        "models.AssigneeData": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "username": {
+                    "type": "string",
+                    "example": "NKTKLN"
+                }
+            }
+        },
+        "models.CommentData": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2077-12-10 13:13"
+                },
+                "edited_at": {
+                    "type": "string",
+                    "example": "2077-12-10 13:43"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "text": {
+                    "type": "string",
+                    "example": "@nktkln the design is ready"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "username": {
+                    "type": "string",
+                    "example": "NKTKLN"
+                }
+            }
+        },
+        "models.CommentEditData": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "text": {
+                    "type": "string",
+                    "example": "@nktkln the design is ready, take a look"
+                }
+            }
+        },
+        "models.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string",
+                    "example": "EdDSA"
+                },
+                "crv": {
+                    "type": "string",
+                    "example": "Ed25519"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string",
+                    "example": "2022-07"
+                },
+                "kty": {
+                    "type": "string",
+                    "example": "OKP"
+                },
+                "n": {
                     "type": "string"
+                },
+                "use": {
+                    "type": "string",
+                    "example": "sig"
+                },
+                "x": {
+                    "type": "string",
+                    "example": "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"
+                }
+            }
+        },
+        "models.JWKS": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.JWK"
+                    }
+                }
+            }
+        },
+        "models.ListEditData": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "example": "Products needed for the party"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "index": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "New list of products"
+                }
+            }
+        },
+        "models.ListInviteData": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "nktkln@example.com"
+                },
+                "list_id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "editor",
+                        "viewer"
+                    ],
+                    "example": "editor"
+                }
+            }
+        },
+        "models.ListMemberAddData": {
+            "type": "object",
+            "properties": {
+                "list_id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "editor",
+                        "viewer"
+                    ],
+                    "example": "editor"
+                },
+                "user": {
+                    "description": "Username or email of the user",
+                    "type": "string",
+                    "example": "NKTKLN"
+                }
+            }
+        },
+        "models.ListMemberData": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Nikita"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "admin",
+                        "editor",
+                        "viewer"
+                    ],
+                    "example": "editor"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "NKTKLN"
+                }
+            }
+        },
+        "models.ListMemberEditData": {
+            "type": "object",
+            "properties": {
+                "list_id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "editor",
+                        "viewer"
+                    ],
+                    "example": "viewer"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1023456789
                 }
             }
         },
-        "models.ApiListData": {
+        "models.ListsData": {
             "type": "object",
             "properties": {
                 "comment": {
                     "type": "string",
                     "example": "Products needed for the party"
                 },
+                "id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "index": {
+                    "type": "integer",
+                    "example": 0
+                },
                 "name": {
                     "type": "string",
                     "example": "List of products"
+                },
+                "query": {
+                    "type": "string",
+                    "example": "category contains Party AND not done"
+                },
+                "role": {
+                    "description": "Role of the user in the list",
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "admin",
+                        "editor",
+                        "viewer"
+                    ],
+                    "example": "owner"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "list",
+                        "filter"
+                    ],
+                    "example": "list"
+                },
+                "workspace": {
+                    "description": "Name of the workspace",
+                    "type": "string",
+                    "example": "Company"
+                },
+                "workspace_id": {
+                    "type": "integer",
+                    "example": 1023456789
                 }
             }
         },
-        "models.ApiMessage": {
+        "models.PersonalTokenData": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string"
+                "expires_at": {
+                    "type": "string",
+                    "example": "2077-12-10 13:13"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "CI"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "lists:read",
+                        "tasks:write"
+                    ]
                 }
             }
         },
-        "models.ApiShowLists": {
+        "models.PersonalTokenInfo": {
             "type": "object",
             "properties": {
-                "lists": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2077-12-10 13:13"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2077-12-10 13:13"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "name": {
+                    "type": "string",
+                    "example": "CI"
+                },
+                "scopes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.ListsData"
-                    }
+                        "type": "string"
+                    },
+                    "example": [
+                        "lists:read",
+                        "tasks:write"
+                    ]
                 }
             }
         },
-        "models.ApiShowSubtasks": {
+        "models.ReminderData": {
             "type": "object",
             "properties": {
-                "subtasks": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.SubtasksData"
-                    }
+                "before": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "remind_at": {
+                    "type": "string",
+                    "example": "2077-12-10 12:43"
+                },
+                "sent": {
+                    "type": "boolean"
                 }
             }
         },
-        "models.ApiShowTasks": {
+        "models.SavedFilterEditData": {
             "type": "object",
             "properties": {
-                "tasks": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.TasksData"
-                    }
+                "id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Work this month"
+                },
+                "query": {
+                    "type": "string",
+                    "example": "category contains Work AND not done AND due within 30 days"
                 }
             }
         },
-        "models.ApiSubtaskData": {
+        "models.SearchResultData": {
             "type": "object",
             "properties": {
-                "comment": {
-                    "type": "string",
-                    "example": "Sugar-free"
+                "id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "list_id": {
+                    "type": "integer",
+                    "example": 1023456789
                 },
                 "name": {
                     "type": "string",
-                    "example": "Coca-Cola"
+                    "example": "Buy drinks"
+                },
+                "rank": {
+                    "type": "number",
+                    "example": 0.0607927
+                },
+                "snippet": {
+                    "type": "string",
+                    "example": "\u003cmark\u003eBuy\u003c/mark\u003e drinks Go to the supermarket"
                 },
                 "task_id": {
+                    "description": "Task of the subtask",
                     "type": "integer",
-                    "example": 1023456789
+                    "example": 0
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "list",
+                        "task",
+                        "subtask"
+                    ],
+                    "example": "task"
                 }
             }
         },
-        "models.ApiTaskData": {
+        "models.SessionData": {
             "type": "object",
             "properties": {
-                "comment": {
+                "created_at": {
                     "type": "string",
-                    "example": "Go to the supermarket on the way home"
+                    "example": "2077-12-10 13:13"
                 },
-                "list_id": {
+                "current": {
+                    "type": "boolean"
+                },
+                "device": {
+                    "type": "string",
+                    "example": "iPhone 13"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "4b0a6bd2-4c5e-4d1f-9a0e-6f7e2b4c1d3a"
+                },
+                "last_active": {
+                    "type": "string",
+                    "example": "2077-12-10 13:13"
+                },
+                "user_agent": {
+                    "type": "string",
+                    "example": "Mozilla/5.0 (iPhone; CPU iPhone OS 15_5 like Mac OS X)"
+                }
+            }
+        },
+        "models.ShowUserData": {
+            "type": "object",
+            "properties": {
+                "id": {
                     "type": "integer",
                     "example": 1023456789
                 },
                 "name": {
                     "type": "string",
-                    "example": "Buy drinks"
+                    "example": "NKTKLN"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "nktkln"
                 }
             }
         },
-        "models.ListEditData": {
+        "models.SubtaskEditData": {
             "type": "object",
             "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Party",
+                        "Shoping",
+                        "Today"
+                    ]
+                },
                 "comment": {
                     "type": "string",
-                    "example": "Products needed for the party"
+                    "example": "Sugar-free"
+                },
+                "done": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "2077-12-10 13:13"
                 },
                 "id": {
                     "type": "integer",
@@ -1480,16 +6699,72 @@ const docTemplate = `{
                 },
                 "name": {
                     "type": "string",
-                    "example": "New list of products"
+                    "example": "Pepsi"
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "low",
+                        "medium",
+                        "high",
+                        "urgent"
+                    ],
+                    "example": "medium"
+                },
+                "special": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "models.ListsData": {
+        "models.SubtaskPromoteData": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "index": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "list_id": {
+                    "description": "List where the subtask becomes a task",
+                    "type": "integer",
+                    "example": 1023456789
+                }
+            }
+        },
+        "models.SubtasksData": {
             "type": "object",
             "properties": {
+                "assignees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AssigneeData"
+                    }
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Party",
+                        "Shoping"
+                    ]
+                },
                 "comment": {
                     "type": "string",
-                    "example": "Products needed for the party"
+                    "example": "Sugar-free"
+                },
+                "done": {
+                    "type": "boolean"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "2077-12-10 13:13"
                 },
                 "id": {
                     "type": "integer",
@@ -1497,32 +6772,103 @@ const docTemplate = `{
                 },
                 "index": {
                     "type": "integer",
-                    "example": 0
+                    "example": 0
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Coca-Cola"
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "low",
+                        "medium",
+                        "high",
+                        "urgent"
+                    ],
+                    "example": "low"
+                },
+                "special": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.TagEditData": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "example": "#00aa55"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Groceries"
+                }
+            }
+        },
+        "models.TagUsageData": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "example": "#ff8800"
+                },
+                "count": {
+                    "description": "Number of tasks and subtasks with the tag",
+                    "type": "integer",
+                    "example": 12
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Shopping"
+                }
+            }
+        },
+        "models.TagsData": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "example": "#ff8800"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1023456789
                 },
                 "name": {
                     "type": "string",
-                    "example": "List of products"
+                    "example": "Shopping"
                 }
             }
         },
-        "models.ShowUserData": {
+        "models.TaskDemoteData": {
             "type": "object",
             "properties": {
                 "id": {
                     "type": "integer",
                     "example": 1023456789
                 },
-                "name": {
-                    "type": "string",
-                    "example": "NKTKLN"
+                "index": {
+                    "type": "integer",
+                    "example": 0
                 },
-                "username": {
-                    "type": "string",
-                    "example": "nktkln"
+                "task_id": {
+                    "description": "Task whose subtask the task becomes",
+                    "type": "integer",
+                    "example": 1023456789
                 }
             }
         },
-        "models.SubtaskEditData": {
+        "models.TaskEditData": {
             "type": "object",
             "properties": {
                 "categories": {
@@ -1538,7 +6884,7 @@ const docTemplate = `{
                 },
                 "comment": {
                     "type": "string",
-                    "example": "Sugar-free"
+                    "example": "Go to the supermarket on the way home"
                 },
                 "done": {
                     "type": "boolean",
@@ -1558,7 +6904,22 @@ const docTemplate = `{
                 },
                 "name": {
                     "type": "string",
-                    "example": "Pepsi"
+                    "example": "Buy new drinks"
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "low",
+                        "medium",
+                        "high",
+                        "urgent"
+                    ],
+                    "example": "urgent"
+                },
+                "recurrence": {
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=MO,TH;COUNT=10"
                 },
                 "special": {
                     "type": "boolean",
@@ -1566,30 +6927,9 @@ const docTemplate = `{
                 }
             }
         },
-        "models.SubtasksData": {
+        "models.TaskMoveData": {
             "type": "object",
             "properties": {
-                "categories": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "Party",
-                        "Shoping"
-                    ]
-                },
-                "comment": {
-                    "type": "string",
-                    "example": "Sugar-free"
-                },
-                "done": {
-                    "type": "boolean"
-                },
-                "end_time": {
-                    "type": "string",
-                    "example": "2077-12-10 13:13"
-                },
                 "id": {
                     "type": "integer",
                     "example": 1023456789
@@ -1598,18 +6938,22 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 0
                 },
-                "name": {
-                    "type": "string",
-                    "example": "Coca-Cola"
-                },
-                "special": {
-                    "type": "boolean"
+                "list_id": {
+                    "description": "List where the task is moved",
+                    "type": "integer",
+                    "example": 1023456789
                 }
             }
         },
-        "models.TaskEditData": {
+        "models.TaskTreeData": {
             "type": "object",
             "properties": {
+                "assignees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AssigneeData"
+                    }
+                },
                 "categories": {
                     "type": "array",
                     "items": {
@@ -1617,8 +6961,7 @@ const docTemplate = `{
                     },
                     "example": [
                         "Party",
-                        "Shoping",
-                        "Today"
+                        "Shoping"
                     ]
                 },
                 "comment": {
@@ -1626,8 +6969,7 @@ const docTemplate = `{
                     "example": "Go to the supermarket on the way home"
                 },
                 "done": {
-                    "type": "boolean",
-                    "example": true
+                    "type": "boolean"
                 },
                 "end_time": {
                     "type": "string",
@@ -1639,21 +6981,47 @@ const docTemplate = `{
                 },
                 "index": {
                     "type": "integer",
-                    "example": 1
+                    "example": 0
                 },
                 "name": {
                     "type": "string",
-                    "example": "Buy new drinks"
+                    "example": "Buy drinks"
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "low",
+                        "medium",
+                        "high",
+                        "urgent"
+                    ],
+                    "example": "high"
+                },
+                "recurrence": {
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=MO,TH"
                 },
                 "special": {
-                    "type": "boolean",
-                    "example": true
+                    "type": "boolean"
+                },
+                "subtasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TaskTreeData"
+                    }
                 }
             }
         },
         "models.TasksData": {
             "type": "object",
             "properties": {
+                "assignees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AssigneeData"
+                    }
+                },
                 "categories": {
                     "type": "array",
                     "items": {
@@ -1687,11 +7055,70 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Buy drinks"
                 },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "low",
+                        "medium",
+                        "high",
+                        "urgent"
+                    ],
+                    "example": "high"
+                },
+                "recurrence": {
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=MO,TH"
+                },
                 "special": {
                     "type": "boolean"
                 }
             }
         },
+        "models.TwoFactorCode": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "models.TwoFactorDisable": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "StRon9Pa$$w0rd"
+                }
+            }
+        },
+        "models.TwoFactorSignIn": {
+            "type": "object",
+            "properties": {
+                "challenge": {
+                    "type": "string",
+                    "example": "4b0a6bd2-4c5e-4d1f-9a0e-6f7e2b4c1d3a"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "models.UserCompletionRollup": {
+            "type": "object",
+            "properties": {
+                "rollup": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
         "models.UserData": {
             "type": "object",
             "properties": {
@@ -1699,6 +7126,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "nktkln@example.com"
                 },
+                "invite": {
+                    "description": "Key of the invitation to a shared list",
+                    "type": "string",
+                    "example": "1023456789"
+                },
                 "name": {
                     "type": "string",
                     "example": "NKTKLN"
@@ -1725,6 +7157,10 @@ const docTemplate = `{
         "models.UserLoginData": {
             "type": "object",
             "properties": {
+                "device": {
+                    "type": "string",
+                    "example": "iPhone 13"
+                },
                 "email": {
                     "type": "string",
                     "example": "nktkln@example.com"
@@ -1753,6 +7189,15 @@ const docTemplate = `{
                 }
             }
         },
+        "models.UserTimezone": {
+            "type": "object",
+            "properties": {
+                "timezone": {
+                    "type": "string",
+                    "example": "Europe/Moscow"
+                }
+            }
+        },
         "models.UserTokens": {
             "type": "object",
             "properties": {
@@ -1774,9 +7219,191 @@ const docTemplate = `{
                     "example": "nktkln"
                 }
             }
+        },
+        "models.ViewTasksData": {
+            "type": "object",
+            "properties": {
+                "assignees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AssigneeData"
+                    }
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Party",
+                        "Shoping"
+                    ]
+                },
+                "comment": {
+                    "type": "string",
+                    "example": "Go to the supermarket on the way home"
+                },
+                "done": {
+                    "type": "boolean"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "2077-12-10 13:13"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "index": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "list_id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "list_name": {
+                    "type": "string",
+                    "example": "List of products"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Buy drinks"
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "low",
+                        "medium",
+                        "high",
+                        "urgent"
+                    ],
+                    "example": "high"
+                },
+                "recurrence": {
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=MO,TH"
+                },
+                "special": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.WorkspaceEditData": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "name": {
+                    "type": "string",
+                    "example": "New company"
+                }
+            }
+        },
+        "models.WorkspaceMemberAddData": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "editor",
+                        "viewer"
+                    ],
+                    "example": "editor"
+                },
+                "user": {
+                    "description": "Username or email of the user",
+                    "type": "string",
+                    "example": "NKTKLN"
+                },
+                "workspace_id": {
+                    "type": "integer",
+                    "example": 1023456789
+                }
+            }
+        },
+        "models.WorkspaceMemberData": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Nikita"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "editor",
+                        "viewer"
+                    ],
+                    "example": "editor"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "NKTKLN"
+                }
+            }
+        },
+        "models.WorkspaceMemberEditData": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "editor",
+                        "viewer"
+                    ],
+                    "example": "viewer"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "workspace_id": {
+                    "type": "integer",
+                    "example": 1023456789
+                }
+            }
+        },
+        "models.WorkspacesData": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1023456789
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Company"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "editor",
+                        "viewer"
+                    ],
+                    "example": "admin"
+                }
+            }
         }
     },
     "securityDefinitions": {
+        "bearer": {
+            "description": "Access token with the \"Bearer \" prefix",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        },
         "token": {
             "type": "apiKey",
             "name": "token",
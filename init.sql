@@ -4,14 +4,58 @@ CREATE TABLE users (
     password text,
     name text,
     username text UNIQUE,
-    icon text
+    icon text,
+    totp_secret text DEFAULT '',
+    totp_enabled boolean DEFAULT false,
+    totp_last_step bigint DEFAULT 0,
+    recovery_codes text [],
+    role text DEFAULT 'user',
+    disabled boolean DEFAULT false,
+    timezone text DEFAULT 'UTC',
+    completion_rollup boolean DEFAULT false
+);
+CREATE TABLE workspaces (
+    id bigint UNIQUE,
+    name text
+);
+CREATE TABLE workspace_members (
+    workspace_id bigint,
+    user_id bigint,
+    role text,
+    UNIQUE (workspace_id, user_id)
 );
 CREATE TABLE lists (
     id bigint UNIQUE,
     user_id bigint,
+    workspace_id bigint DEFAULT 0,
     name text,
     comment text DEFAULT '',
-    index integer
+    index integer,
+    search tsvector GENERATED ALWAYS AS (to_tsvector('simple', coalesce(name, '') || ' ' || coalesce(comment, ''))) STORED
+);
+CREATE INDEX lists_search_index ON lists USING GIN (search);
+CREATE TABLE list_members (
+    list_id bigint,
+    user_id bigint,
+    role text,
+    UNIQUE (list_id, user_id)
+);
+CREATE VIEW list_access AS
+    SELECT list_id, user_id, role FROM list_members
+    UNION ALL
+    SELECT lists.id, workspace_members.user_id, CASE WHEN workspace_members.role = 'admin' THEN 'owner' ELSE workspace_members.role END
+    FROM lists INNER JOIN workspace_members ON workspace_members.workspace_id = lists.workspace_id;
+CREATE TABLE saved_filters (
+    id bigint UNIQUE,
+    user_id bigint,
+    name text,
+    query text
+);
+CREATE TABLE tags (
+    id bigint UNIQUE,
+    user_id bigint,
+    name text,
+    color text
 );
 CREATE TABLE tasks (
     id bigint UNIQUE,
@@ -23,5 +67,41 @@ CREATE TABLE tasks (
     categories text [],
     end_time timestamptz DEFAULT null,
     done boolean DEFAULT false,
-    special boolean DEFAULT false
+    special boolean DEFAULT false,
+    priority text DEFAULT 'none',
+    recurrence text DEFAULT '',
+    occurrence integer DEFAULT 0,
+    created_at timestamptz DEFAULT now(),
+    search tsvector GENERATED ALWAYS AS (to_tsvector('simple', coalesce(name, '') || ' ' || coalesce(comment, ''))) STORED
+);
+CREATE INDEX tasks_search_index ON tasks USING GIN (search);
+CREATE TABLE reminders (
+    id bigint UNIQUE,
+    task_id bigint,
+    user_id bigint,
+    before integer DEFAULT null,
+    remind_at timestamptz,
+    sent boolean DEFAULT false
+);
+CREATE TABLE task_assignees (
+    task_id bigint,
+    user_id bigint,
+    UNIQUE (task_id, user_id)
+);
+CREATE TABLE task_comments (
+    id bigint UNIQUE,
+    task_id bigint,
+    user_id bigint,
+    text text,
+    created_at timestamptz,
+    edited_at timestamptz DEFAULT null
+);
+CREATE TABLE personal_tokens (
+    id bigint UNIQUE,
+    user_id bigint,
+    name text,
+    token_hash text UNIQUE,
+    scopes text [],
+    expires_at timestamptz DEFAULT null,
+    created_at timestamptz
 );
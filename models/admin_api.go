@@ -0,0 +1,18 @@
+package models
+
+type ApiShowUsers struct {
+	Users []AdminUserData `json:"users"`
+	Page  int             `json:"page" example:"1"`
+	Limit int             `json:"limit" example:"20"`
+	Total int64           `json:"total" example:"42"`
+}
+
+type AdminUserData struct {
+	Id          int    `json:"id" example:"1023456789"`
+	Email       string `json:"email" example:"nktkln@example.com"`
+	Name        string `json:"name" example:"NKTKLN"`
+	Username    string `json:"username" example:"nktkln"`
+	Role        string `json:"role" example:"user"`
+	Disabled    bool   `json:"disabled"`
+	TotpEnabled bool   `json:"totp_enabled"`
+}
@@ -0,0 +1,11 @@
+package models
+
+type ApiAssigneeData struct {
+	TaskId int `json:"task_id" example:"1023456789"` // Id of the task or subtask
+	UserId int `json:"user_id" example:"1023456789"`
+}
+
+type AssigneeData struct {
+	Id       int    `json:"id" example:"1023456789"`
+	Username string `json:"username" example:"NKTKLN"`
+}
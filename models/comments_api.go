@@ -0,0 +1,25 @@
+package models
+
+type ApiShowComments struct {
+	Comments   []CommentData `json:"comments"`
+	NextCursor string        `json:"next_cursor,omitempty" example:"WzAsMTAyMzQ1Njc4OV0"`
+}
+
+type ApiCommentData struct {
+	TaskId int    `json:"task_id" example:"1023456789"` // Id of the task or subtask
+	Text   string `json:"text" example:"@nktkln the design is ready"`
+}
+
+type CommentEditData struct {
+	Id   int    `json:"id" example:"1023456789"`
+	Text string `json:"text" example:"@nktkln the design is ready, take a look"`
+}
+
+type CommentData struct {
+	Id        int    `json:"id" example:"1023456789"`
+	UserId    int    `json:"user_id" example:"1023456789"`
+	Username  string `json:"username" example:"NKTKLN"`
+	Text      string `json:"text" example:"@nktkln the design is ready"`
+	CreatedAt string `json:"created_at" example:"2077-12-10 13:13"`
+	EditedAt  string `json:"edited_at,omitempty" example:"2077-12-10 13:43"`
+}
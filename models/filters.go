@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// TasksFilter limits the shown tasks and subtasks, empty fields are not used
+type TasksFilter struct {
+	Done      *bool
+	Special   *bool
+	Category  string
+	DueBefore time.Time
+	DueAfter  time.Time
+	Search    string
+}
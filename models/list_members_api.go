@@ -0,0 +1,30 @@
+package models
+
+type ApiShowListMembers struct {
+	Members []ListMemberData `json:"members"`
+}
+
+type ListMemberData struct {
+	Id       int    `json:"id" example:"1023456789"`
+	Username string `json:"username" example:"NKTKLN"`
+	Name     string `json:"name" example:"Nikita"`
+	Role     string `json:"role" example:"editor" enums:"owner,admin,editor,viewer"`
+}
+
+type ListMemberAddData struct {
+	ListId int    `json:"list_id" example:"1023456789"`
+	User   string `json:"user" example:"NKTKLN"` // Username or email of the user
+	Role   string `json:"role" example:"editor" enums:"admin,editor,viewer"`
+}
+
+type ListMemberEditData struct {
+	ListId int    `json:"list_id" example:"1023456789"`
+	UserId int    `json:"user_id" example:"1023456789"`
+	Role   string `json:"role" example:"viewer" enums:"admin,editor,viewer"`
+}
+
+type ListInviteData struct {
+	ListId int    `json:"list_id" example:"1023456789"`
+	Email  string `json:"email" example:"nktkln@example.com"`
+	Role   string `json:"role" example:"editor" enums:"admin,editor,viewer"`
+}
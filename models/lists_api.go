@@ -1,19 +1,26 @@
 package models
 
 type ApiShowLists struct {
-	Lists []ListsData `json:"lists"`
+	Lists      []ListsData `json:"lists"`
+	NextCursor string      `json:"next_cursor,omitempty" example:"WzAsMTAyMzQ1Njc4OV0"`
 }
 
 type ApiListData struct {
-	Name    string `json:"name" example:"List of products"`
-	Comment string `json:"comment" example:"Products needed for the party"`
+	Name        string `json:"name" example:"List of products"`
+	Comment     string `json:"comment" example:"Products needed for the party"`
+	WorkspaceId int    `json:"workspace_id,omitempty" example:"1023456789"` // The list is created in the workspace instead of the user lists
 }
 
 type ListsData struct {
-	Id      int    `json:"id" example:"1023456789"`
-	Name    string `json:"name" example:"List of products"`
-	Comment string `json:"comment" example:"Products needed for the party"`
-	Index   int    `json:"index" example:"0"`
+	Id          int    `json:"id" example:"1023456789"`
+	Type        string `json:"type" example:"list" enums:"list,filter"`
+	Name        string `json:"name" example:"List of products"`
+	Comment     string `json:"comment" example:"Products needed for the party"`
+	Query       string `json:"query,omitempty" example:"category contains Party AND not done"`
+	Role        string `json:"role,omitempty" example:"owner" enums:"owner,admin,editor,viewer"` // Role of the user in the list
+	WorkspaceId int    `json:"workspace_id,omitempty" example:"1023456789"`
+	Workspace   string `json:"workspace,omitempty" example:"Company"` // Name of the workspace
+	Index       int    `json:"index" example:"0"`
 }
 
 type ListEditData struct {
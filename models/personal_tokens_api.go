@@ -0,0 +1,24 @@
+package models
+
+type PersonalTokenData struct {
+	Name      string   `json:"name" example:"CI"`
+	Scopes    []string `json:"scopes" example:"lists:read,tasks:write"`
+	ExpiresAt string   `json:"expires_at" example:"2077-12-10 13:13"`
+}
+
+type ApiPersonalToken struct {
+	Token string `json:"token" example:"pat_3f9a10c7b28d2e4b61f03f9a10c7b28d2e4b61f03f9a10c7b28d2e4b61f0aa"`
+	PersonalTokenInfo
+}
+
+type ApiShowPersonalTokens struct {
+	Tokens []PersonalTokenInfo `json:"tokens"`
+}
+
+type PersonalTokenInfo struct {
+	Id        int      `json:"id" example:"1023456789"`
+	Name      string   `json:"name" example:"CI"`
+	Scopes    []string `json:"scopes" example:"lists:read,tasks:write"`
+	ExpiresAt string   `json:"expires_at" example:"2077-12-10 13:13"`
+	CreatedAt string   `json:"created_at" example:"2077-12-10 13:13"`
+}
@@ -7,20 +7,63 @@ import (
 )
 
 type Users struct {
-	Id       int
-	Email    string
-	Password string
-	Name     string
-	Username string
-	Icon     string
+	Id               int
+	Email            string
+	Password         string
+	Name             string
+	Username         string
+	Icon             string
+	TotpSecret       string
+	TotpEnabled      bool
+	TotpLastStep     int64 // Time step of the last accepted TOTP code, codes up to it can't be used again
+	RecoveryCodes    pq.StringArray `gorm:"type:text[]"`
+	Role             string
+	Disabled         bool
+	Timezone         string // IANA time zone name
+	CompletionRollup bool   // Tasks are completed when all their subtasks are done
+}
+
+type Workspaces struct {
+	Id   int
+	Name string
+}
+
+// WorkspaceMembers have the list roles in all lists of the workspace, admins of the workspace own its lists
+type WorkspaceMembers struct {
+	WorkspaceId int
+	UserId      int
+	Role        string
 }
 
 type Lists struct {
-	Id      int
-	UserId  int
-	Name    string
-	Comment string
-	Index   int
+	Id          int
+	UserId      int // Zero for the workspace lists
+	WorkspaceId int // Zero for the personal lists
+	Name        string
+	Comment     string
+	Index       int
+}
+
+// ListMembers are the collaborators of the shared list, the list owner is not a member
+type ListMembers struct {
+	ListId int
+	UserId int
+	Role   string
+}
+
+type SavedFilters struct {
+	Id     int
+	UserId int
+	Name   string
+	Query  string // Filter expression, see common.ParseFilter
+}
+
+// Tags are matched with the task categories by the name
+type Tags struct {
+	Id     int
+	UserId int
+	Name   string
+	Color  string
 }
 
 type Tasks struct {
@@ -34,4 +77,42 @@ type Tasks struct {
 	EndTime    time.Time
 	Done       bool
 	Special    bool
+	Priority   string
+	Recurrence string // RFC 5545 RRULE
+	Occurrence int    // Number of the occurrence in the recurrence series
+	CreatedAt  time.Time
+}
+
+// TaskAssignees are the list members the task or subtask is assigned to
+type TaskAssignees struct {
+	TaskId int
+	UserId int
+}
+
+type TaskComments struct {
+	Id        int
+	TaskId    int
+	UserId    int
+	Text      string
+	CreatedAt time.Time
+	EditedAt  *time.Time // nil until the comment is edited
+}
+
+type Reminders struct {
+	Id       int
+	TaskId   int
+	UserId   int
+	Before   *int // Minutes before the task end time, nil for reminders at an absolute time
+	RemindAt time.Time
+	Sent     bool
+}
+
+type PersonalTokens struct {
+	Id        int
+	UserId    int
+	Name      string
+	TokenHash string
+	Scopes    pq.StringArray `gorm:"type:text[]"`
+	ExpiresAt *time.Time
+	CreatedAt time.Time
 }
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+type Sessions struct {
+	Id         string    `json:"id"`
+	UserId     int       `json:"user_id"`
+	Device     string    `json:"device"`
+	UserAgent  string    `json:"user_agent"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastActive time.Time `json:"last_active"`
+}
+
+// ListInvites are the invitations to the shared lists sent by email
+type ListInvites struct {
+	ListId int    `json:"list_id"`
+	Email  string `json:"email"`
+	Role   string `json:"role"`
+}
+
+type Challenges struct {
+	Id        string `json:"id"`
+	UserId    int    `json:"user_id"`
+	Device    string `json:"device"`
+	UserAgent string `json:"user_agent"`
+}
+
+// Limit allows MaxAttempts attempts during the Window, after that the key is locked.
+// Every next lockout is twice as long as the previous one, but not longer than MaxLockout.
+type Limit struct {
+	MaxAttempts int
+	Window      time.Duration
+	Lockout     time.Duration
+	MaxLockout  time.Duration
+}
+
+type LimitSettings struct {
+	SignIn   Limit
+	SignInIP Limit
+	Email    Limit
+	EmailIP  Limit
+}
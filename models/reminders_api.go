@@ -0,0 +1,18 @@
+package models
+
+type ApiShowReminders struct {
+	Reminders []ReminderData `json:"reminders"`
+}
+
+type ApiReminderData struct {
+	TaskId   int    `json:"task_id" example:"1023456789"`
+	Before   *int   `json:"before" example:"30"`
+	RemindAt string `json:"remind_at" example:"2077-12-10 12:00"`
+}
+
+type ReminderData struct {
+	Id       int    `json:"id" example:"1023456789"`
+	Before   *int   `json:"before,omitempty" example:"30"`
+	RemindAt string `json:"remind_at" example:"2077-12-10 12:43"`
+	Sent     bool   `json:"sent"`
+}
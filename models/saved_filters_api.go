@@ -0,0 +1,12 @@
+package models
+
+type ApiSavedFilterData struct {
+	Name  string `json:"name" example:"Work this week"`
+	Query string `json:"query" example:"category contains Work AND not done AND due within 7 days"`
+}
+
+type SavedFilterEditData struct {
+	Id    int    `json:"id" example:"1023456789"`
+	Name  string `json:"name" example:"Work this month"`
+	Query string `json:"query" example:"category contains Work AND not done AND due within 30 days"`
+}
@@ -0,0 +1,16 @@
+package models
+
+type ApiSearchResults struct {
+	Results    []SearchResultData `json:"results"`
+	NextCursor string             `json:"next_cursor,omitempty" example:"WzAsMTAyMzQ1Njc4OV0"`
+}
+
+type SearchResultData struct {
+	Type    string  `json:"type" example:"task" enums:"list,task,subtask"`
+	Id      int     `json:"id" example:"1023456789"`
+	ListId  int     `json:"list_id" example:"1023456789"`
+	TaskId  int     `json:"task_id" example:"0"` // Task of the subtask
+	Name    string  `json:"name" example:"Buy drinks"`
+	Snippet string  `json:"snippet" example:"<mark>Buy</mark> drinks Go to the supermarket"`
+	Rank    float64 `json:"rank" example:"0.0607927"`
+}
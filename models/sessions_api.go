@@ -0,0 +1,14 @@
+package models
+
+type ApiShowSessions struct {
+	Sessions []SessionData `json:"sessions"`
+}
+
+type SessionData struct {
+	Id         string `json:"id" example:"4b0a6bd2-4c5e-4d1f-9a0e-6f7e2b4c1d3a"`
+	Device     string `json:"device" example:"iPhone 13"`
+	UserAgent  string `json:"user_agent" example:"Mozilla/5.0 (iPhone; CPU iPhone OS 15_5 like Mac OS X)"`
+	CreatedAt  string `json:"created_at" example:"2077-12-10 13:13"`
+	LastActive string `json:"last_active" example:"2077-12-10 13:13"`
+	Current    bool   `json:"current"`
+}
@@ -6,13 +6,106 @@ const (
 	MAX_ICON_UPLOAD_SIZE = 5 << 20             // 5MB
 	ACCESS_TOKEN_LIVE    = 15 * time.Minute    // 15 minutes
 	REFRESH_TOKEN_LIVE   = 30 * 24 * time.Hour // 30 days
+	CHALLENGE_LIVE       = 5 * time.Minute     // 5 minutes
+	EMAIL_KEY_LIVE       = 15 * time.Minute    // 15 minutes
+	INVITE_KEY_LIVE      = 7 * 24 * time.Hour  // 7 days
+	REMINDER_LEASE       = time.Minute         // 1 minute, after it a claimed reminder can be claimed again
+
+	MAX_CHALLENGE_ATTEMPTS = 5
+	RECOVERY_CODES_COUNT   = 10
+	TOTP_ISSUER            = "ToDo API"
+	PERSONAL_TOKEN_PREFIX  = "pat_"
+	DEFAULT_PAGE_LIMIT     = 20
+	MAX_PAGE_LIMIT         = 100
+	MAX_TASK_REMINDERS     = 10
+	MAX_SAVED_FILTERS      = 20
+	REMINDERS_BATCH        = 100
+	DEFAULT_TIMEZONE       = "UTC"
+	UPCOMING_DAYS          = 7
+	MAX_TAGS               = 100
+	TAGS_AUTOCOMPLETE      = 10
+	DEFAULT_TAG_COLOR      = "#808080"
+	MAX_LIST_MEMBERS       = 50
+	MAX_TASK_ASSIGNEES     = 20
+	MAX_WORKSPACE_MEMBERS  = 100
+	MAX_COMMENT_LENGTH     = 2000
+
+	// Highlighting of the found words in the search results
+	SEARCH_HEADLINE_OPTIONS = "StartSel=<mark>, StopSel=</mark>, MaxWords=20, MinWords=5"
+)
+
+// Token uses
+const (
+	ACCESS_TOKEN  = "access"
+	REFRESH_TOKEN = "refresh"
+)
+
+// Task sort modes
+const (
+	MANUAL_SORT   = "manual"
+	PRIORITY_SORT = "priority"
+	DUE_SORT      = "due"
+	CREATED_SORT  = "created"
+	NAME_SORT     = "name"
+)
+
+// Types of the shown lists
+const (
+	LIST_TYPE   = "list"
+	FILTER_TYPE = "filter"
+)
+
+// User roles
+const (
+	USER_ROLE  = "user"
+	ADMIN_ROLE = "admin"
+)
+
+// List member roles
+const (
+	LIST_VIEWER_ROLE = "viewer"
+	LIST_EDITOR_ROLE = "editor"
+	LIST_ADMIN_ROLE  = "admin"
+	LIST_OWNER_ROLE  = "owner"
+)
+
+// Email key flows
+const (
+	VERIFICATION_FLOW   = "verification"
+	EMAIL_CHANGE_FLOW   = "email-change"
+	PASSWORD_RESET_FLOW = "password-reset"
+	LIST_INVITE_FLOW    = "list-invite"
 )
 
 var (
+	// Task priorities from the lowest to the highest
+	TASK_PRIORITIES = []string{"none", "low", "medium", "high", "urgent"}
+
+	// List roles from the lowest to the highest, every role has the rights of the lower ones
+	LIST_ROLES = []string{LIST_VIEWER_ROLE, LIST_EDITOR_ROLE, LIST_ADMIN_ROLE, LIST_OWNER_ROLE}
+
+	// Task sort modes with their default order
+	TASK_SORTS = map[string]string{
+		MANUAL_SORT:   "asc",
+		PRIORITY_SORT: "desc",
+		DUE_SORT:      "asc",
+		CREATED_SORT:  "asc",
+		NAME_SORT:     "asc",
+	}
+
 	IMAGE_TYPES = map[string]interface{}{
 		"image/jpeg": ".jpeg",
 		"image/png":  ".png",
 	}
+
+	TOKEN_SCOPES = map[string]interface{}{
+		"lists:read":  nil,
+		"lists:write": nil,
+		"tasks:read":  nil,
+		"tasks:write": nil,
+		"user:read":   nil,
+		"user:write":  nil,
+	}
 )
 
 const BucketName = "user-icons"
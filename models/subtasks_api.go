@@ -3,7 +3,8 @@ package models
 import "github.com/lib/pq"
 
 type ApiShowSubtasks struct {
-	Subtasks []SubtasksData `json:"subtasks"`
+	Subtasks   []SubtasksData `json:"subtasks"`
+	NextCursor string         `json:"next_cursor,omitempty" example:"WzAsMTAyMzQ1Njc4OV0"`
 }
 
 type ApiSubtaskData struct {
@@ -21,6 +22,8 @@ type SubtasksData struct {
 	EndTime    string         `json:"end_time" example:"2077-12-10 13:13"`
 	Done       bool           `json:"done"`
 	Special    bool           `json:"special"`
+	Priority   string         `json:"priority" example:"low" enums:"none,low,medium,high,urgent"`
+	Assignees  []AssigneeData `json:"assignees,omitempty"`
 }
 
 type SubtaskEditData struct {
@@ -32,4 +35,11 @@ type SubtaskEditData struct {
 	EndTime    string         `json:"end_time" example:"2077-12-10 13:13"`
 	Done       bool           `json:"done" example:"true"`
 	Special    bool           `json:"special" example:"true"`
+	Priority   string         `json:"priority" example:"medium" enums:"none,low,medium,high,urgent"`
+}
+
+type SubtaskPromoteData struct {
+	Id     int `json:"id" example:"1023456789"`
+	ListId int `json:"list_id" example:"1023456789"` // List where the subtask becomes a task
+	Index  int `json:"index" example:"0"`
 }
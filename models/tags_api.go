@@ -0,0 +1,34 @@
+package models
+
+type ApiShowTags struct {
+	Tags []TagsData `json:"tags"`
+}
+
+type ApiTagData struct {
+	Name  string `json:"name" example:"Shopping"`
+	Color string `json:"color" example:"#ff8800"`
+}
+
+type TagsData struct {
+	Id    int    `json:"id" example:"1023456789"`
+	Name  string `json:"name" example:"Shopping"`
+	Color string `json:"color" example:"#ff8800"`
+}
+
+// TagEditData changes only the given fields of the tag
+type TagEditData struct {
+	Id    int    `json:"id" example:"1023456789"`
+	Name  string `json:"name" example:"Groceries"`
+	Color string `json:"color" example:"#00aa55"`
+}
+
+type ApiTagsAutocomplete struct {
+	Tags []TagUsageData `json:"tags"`
+}
+
+type TagUsageData struct {
+	Id    int    `json:"id" example:"1023456789"`
+	Name  string `json:"name" example:"Shopping"`
+	Color string `json:"color" example:"#ff8800"`
+	Count int    `json:"count" example:"12"` // Number of tasks and subtasks with the tag
+}
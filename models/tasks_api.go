@@ -3,7 +3,12 @@ package models
 import "github.com/lib/pq"
 
 type ApiShowTasks struct {
-	Tasks []TasksData `json:"tasks"`
+	Tasks      []TasksData `json:"tasks"`
+	NextCursor string      `json:"next_cursor,omitempty" example:"WzAsMTAyMzQ1Njc4OV0"`
+}
+
+type ApiShowTaskTree struct {
+	Tasks []TaskTreeData `json:"tasks"`
 }
 
 type ApiTaskData struct {
@@ -21,6 +26,15 @@ type TasksData struct {
 	EndTime    string         `json:"end_time" example:"2077-12-10 13:13"`
 	Done       bool           `json:"done"`
 	Special    bool           `json:"special"`
+	Priority   string         `json:"priority" example:"high" enums:"none,low,medium,high,urgent"`
+	Recurrence string         `json:"recurrence" example:"FREQ=WEEKLY;BYDAY=MO,TH"`
+	Assignees  []AssigneeData `json:"assignees,omitempty"`
+}
+
+// TaskTreeData is the task with all levels of its subtasks
+type TaskTreeData struct {
+	TasksData
+	Subtasks []TaskTreeData `json:"subtasks"`
 }
 
 type TaskEditData struct {
@@ -32,4 +46,18 @@ type TaskEditData struct {
 	EndTime    string         `json:"end_time" example:"2077-12-10 13:13"`
 	Done       bool           `json:"done" example:"true"`
 	Special    bool           `json:"special" example:"true"`
+	Priority   string         `json:"priority" example:"urgent" enums:"none,low,medium,high,urgent"`
+	Recurrence string         `json:"recurrence" example:"FREQ=WEEKLY;BYDAY=MO,TH;COUNT=10"`
+}
+
+type TaskMoveData struct {
+	Id     int `json:"id" example:"1023456789"`
+	ListId int `json:"list_id" example:"1023456789"` // List where the task is moved
+	Index  int `json:"index" example:"0"`
+}
+
+type TaskDemoteData struct {
+	Id     int `json:"id" example:"1023456789"`
+	TaskId int `json:"task_id" example:"1023456789"` // Task whose subtask the task becomes
+	Index  int `json:"index" example:"0"`
 }
@@ -4,59 +4,223 @@ const (
 	// Select
 	SqlSelectUserById           = `SELECT * FROM "users" WHERE id = $1 LIMIT 1`
 	SqlSelectUserByEmail        = `SELECT * FROM "users" WHERE email = $1 LIMIT 1`
+	SqlSelectUserByUsername     = `SELECT * FROM "users" WHERE username = $1 LIMIT 1`
 	SqlSelectAllUsersById       = `SELECT * FROM "users" WHERE id = $1 ORDER BY "users"."id"`
 	SqlSelectAllUsersByEmail    = `SELECT * FROM "users" WHERE email = $1 ORDER BY "users"."id"`
 	SqlSelectAllUsersByUsername = `SELECT * FROM "users" WHERE username = $1 ORDER BY "users"."id"`
+	SqlSelectUsersCountBySearch = `SELECT count(*) FROM "users" WHERE email ILIKE $1 OR username ILIKE $2 OR name ILIKE $3`
+	SqlSelectUsersBySearch      = `SELECT * FROM "users" WHERE email ILIKE $1 OR username ILIKE $2 OR name ILIKE $3 ORDER BY username`
+
+	SqlSelectWorkspaceById              = `SELECT * FROM "workspaces" WHERE id = $1 LIMIT 1`
+	SqlSelectUserWorkspaces             = `SELECT workspaces.id, workspaces.name, workspace_members.role FROM "workspaces" INNER JOIN workspace_members ON workspace_members.workspace_id = workspaces.id WHERE workspace_members.user_id = $1 ORDER BY workspaces.name, workspaces.id`
+	SqlSelectWorkspaceRole              = `SELECT role FROM "workspace_members" WHERE workspace_id = $1 AND user_id = $2 LIMIT 1`
+	SqlSelectWorkspaceMembers           = `SELECT users.id, users.username, users.name, workspace_members.role FROM "workspace_members" INNER JOIN users ON users.id = workspace_members.user_id WHERE workspace_members.workspace_id = $1 ORDER BY users.username`
+	SqlSelectUserAdminWorkspaceIds      = `SELECT "workspace_id" FROM "workspace_members" WHERE user_id = $1 AND role = $2`
+	SqlSelectWorkspaceMemberRoles       = `SELECT "role" FROM "workspace_members" WHERE workspace_id = $1`
+	SqlSelectAllListsByWorkspaceId      = `SELECT * FROM "lists" WHERE workspace_id = $1 ORDER BY index`
+	SqlSelectWorkspaceListsForDelete    = `SELECT * FROM "lists" WHERE workspace_id = $1`
+	SqlSelectWorkspaceListsForEditIndex = `SELECT * FROM "lists" WHERE workspace_id = $1 AND index > $2`
 
 	SqlSelectListById                   = `SELECT * FROM "lists" WHERE id = $1 LIMIT 1`
-	SqlSelectListByIdAndUserId          = `SELECT * FROM "lists" WHERE id = $1 AND user_id = $2 LIMIT 1`
+	SqlSelectListByIdAndUserId          = `SELECT * FROM "lists" WHERE id = $1 AND (lists.user_id = $2 OR lists.id IN (SELECT list_id FROM "list_access" WHERE user_id = $3)) LIMIT 1`
 	SqlSelectAllListsByUserId           = `SELECT * FROM "lists" WHERE user_id = $1 ORDER BY index`
+	SqlSelectUserLists                  = `SELECT lists.*, coalesce(list_access.role, $1) AS role, coalesce(workspaces.name, '') AS workspace, json_build_array(workspaces.name IS NOT NULL, coalesce(workspaces.name, ''), lists.workspace_id, list_access.user_id IS NOT NULL, lists.index, lists.id) AS page_key FROM "lists" LEFT JOIN list_access ON list_access.list_id = lists.id AND list_access.user_id = $2 LEFT JOIN workspaces ON workspaces.id = lists.workspace_id WHERE lists.user_id = $3 OR list_access.user_id IS NOT NULL ORDER BY workspaces.name IS NOT NULL,coalesce(workspaces.name, ''),lists.workspace_id,list_access.user_id IS NOT NULL,lists.index,lists.id`
+	SqlSelectListsBySearch              = `SELECT lists.*, coalesce(list_access.role, $1) AS role, coalesce(workspaces.name, '') AS workspace, json_build_array(workspaces.name IS NOT NULL, coalesce(workspaces.name, ''), lists.workspace_id, list_access.user_id IS NOT NULL, lists.index, lists.id) AS page_key FROM "lists" LEFT JOIN list_access ON list_access.list_id = lists.id AND list_access.user_id = $2 LEFT JOIN workspaces ON workspaces.id = lists.workspace_id WHERE (lists.user_id = $3 OR list_access.user_id IS NOT NULL) AND (lists.name ILIKE $4 OR lists.comment ILIKE $5) ORDER BY workspaces.name IS NOT NULL,coalesce(workspaces.name, ''),lists.workspace_id,list_access.user_id IS NOT NULL,lists.index,lists.id LIMIT 2`
 	SqlSelectAllListsForEditIndex       = `SELECT * FROM "lists" WHERE user_id = $1 AND index > $2`
 	SqlSelectMaxListIndex               = `SELECT max(index) FROM "lists" WHERE user_id = $1 LIMIT 1`
 	SqlSelectAllListsToIncreaseTheIndex = `SELECT * FROM "lists" WHERE user_id = $1 AND index <= $2 AND index > $3`
 	SqlSelectAllListsForIndexReduction  = `SELECT * FROM "lists" WHERE user_id = $1 AND index >= $2 AND index < $3`
 
+	SqlSelectSavedFiltersByUserId     = `SELECT saved_filters.*, json_build_array(name, id) AS page_key FROM "saved_filters" WHERE user_id = $1 ORDER BY name,id`
+	SqlSelectSavedFiltersBySearch     = `SELECT saved_filters.*, json_build_array(name, id) AS page_key FROM "saved_filters" WHERE user_id = $1 AND name ILIKE $2 ORDER BY name,id`
+	SqlSelectSavedFiltersAfterKey     = `SELECT saved_filters.*, json_build_array(name, id) AS page_key FROM "saved_filters" WHERE user_id = $1 AND (name, id) > ($2,$3) ORDER BY name,id LIMIT 2`
+	SqlSelectSavedFilterByIdAndUserId = `SELECT * FROM "saved_filters" WHERE id = $1 AND user_id = $2 LIMIT 1`
+	SqlSelectSavedFilterById          = `SELECT * FROM "saved_filters" WHERE id = $1 LIMIT 1`
+
+	SqlSelectTagsByUserId       = `SELECT * FROM "tags" WHERE user_id = $1 ORDER BY name`
+	SqlSelectTagByIdAndUserId   = `SELECT * FROM "tags" WHERE id = $1 AND user_id = $2 LIMIT 1`
+	SqlSelectTagByNameAndUserId = `SELECT * FROM "tags" WHERE name = $1 AND user_id = $2 LIMIT 1`
+	SqlSelectTagById            = `SELECT * FROM "tags" WHERE id = $1 LIMIT 1`
+	SqlSelectTagsUsageByUserId  = `SELECT tags.id, tags.name, tags.color, count(tasks.id) AS count FROM "tags" LEFT JOIN tasks ON tags.name = ANY(tasks.categories) AND tasks.id IN (WITH RECURSIVE tree AS (SELECT id FROM tasks WHERE list_id IN (SELECT id FROM "lists" WHERE lists.user_id = $1 OR lists.id IN (SELECT list_id FROM "list_access" WHERE user_id = $2)) UNION SELECT tasks.id FROM tasks INNER JOIN tree ON tasks.task_id = tree.id) SELECT id FROM tree) WHERE tags.user_id = $3 AND tags.name ILIKE $4 GROUP BY "tags"."id" ORDER BY count DESC, tags.name LIMIT 10`
+
 	SqlSelectTaskById                   = `SELECT * FROM "tasks" WHERE id = $1 LIMIT 1`
-	SqlSelectAllTasksByListId           = `SELECT * FROM "tasks" WHERE list_id = $1 ORDER BY index`
+	SqlSelectAllTasksByListId           = `SELECT tasks.*, json_build_array(index, id) AS page_key FROM "tasks" WHERE list_id = $1 ORDER BY index,id`
+	SqlSelectAllTasksByPriority         = `SELECT tasks.*, json_build_array(coalesce(array_position(ARRAY['none','low','medium','high','urgent'], priority), 0), -index, -id) AS page_key FROM "tasks" WHERE list_id = $1 ORDER BY coalesce(array_position(ARRAY['none','low','medium','high','urgent'], priority), 0) DESC,-index DESC,-id DESC`
+	SqlSelectAllTasksByDueTime          = `SELECT tasks.*, json_build_array(end_time, -index, -id) AS page_key FROM "tasks" WHERE list_id = $1 ORDER BY end_time DESC,-index DESC,-id DESC`
+	SqlSelectFilteredTasks              = `SELECT tasks.*, json_build_array(index, id) AS page_key FROM "tasks" WHERE list_id = $1 AND done = $2 AND $3 = ANY(categories) AND (name ILIKE $4 OR comment ILIKE $5) ORDER BY index,id LIMIT 2`
+	SqlSelectTasksByDueRange            = `SELECT tasks.*, json_build_array(index, id) AS page_key FROM "tasks" WHERE list_id = $1 AND (end_time < $2 AND end_time > $3) AND end_time >= $4 AND (index, id) > ($5,$6) ORDER BY index,id LIMIT 21`
+	SqlSelectTodayUserTasks             = `SELECT tasks.*, json_build_array(CASE WHEN end_time = '0001-01-01 00:00:00+00' THEN 'infinity' ELSE end_time END, list_id, index, id) AS page_key FROM "tasks" WHERE list_id IN (SELECT id FROM "lists" WHERE lists.user_id = $1 OR lists.id IN (SELECT list_id FROM "list_access" WHERE user_id = $2)) AND done = $3 AND (end_time < $4 AND end_time > $5) AND end_time >= $6 ORDER BY CASE WHEN end_time = '0001-01-01 00:00:00+00' THEN 'infinity' ELSE end_time END,list_id,index,id`
+	SqlSelectStarredUserTasks           = `SELECT tasks.*, json_build_array(CASE WHEN end_time = '0001-01-01 00:00:00+00' THEN 'infinity' ELSE end_time END, list_id, index, id) AS page_key FROM "tasks" WHERE list_id IN (SELECT id FROM "lists" WHERE lists.user_id = $1 OR lists.id IN (SELECT list_id FROM "list_access" WHERE user_id = $2)) AND done = $3 AND special = $4 ORDER BY CASE WHEN end_time = '0001-01-01 00:00:00+00' THEN 'infinity' ELSE end_time END,list_id,index,id`
 	SqlSelectAllTasksForEditIndex       = `SELECT * FROM "tasks" WHERE list_id = $1 AND index > $2`
 	SqlSelectMaxTaskIndex               = `SELECT max(index) FROM "tasks" WHERE list_id = $1 LIMIT 1`
 	SqlSelectAllTasksToIncreaseTheIndex = `SELECT * FROM "tasks" WHERE list_id = $1 AND index <= $2 AND index > $3`
 	SqlSelectAllTasksForIndexReduction  = `SELECT * FROM "tasks" WHERE list_id = $1 AND index >= $2 AND index < $3`
 
-	SqlSelectAllSubtasksByTaskId           = `SELECT * FROM "tasks" WHERE task_id = $1 ORDER BY index`
+	// Only the ending of the search query, the arguments are checked in full
+	SqlSearch = `WHERE tasks.search @@ search.query) AS results ORDER BY rank DESC,type DESC,id DESC`
+
+	SqlSelectAllSubtasksByTaskId           = `SELECT tasks.*, json_build_array(index, id) AS page_key FROM "tasks" WHERE task_id = $1 ORDER BY index,id`
+	SqlSelectSubtreeByTaskId               = `SELECT * FROM "tasks" WHERE id IN (WITH RECURSIVE subtree AS (SELECT id FROM tasks WHERE id = $1 UNION SELECT tasks.id FROM tasks INNER JOIN subtree ON tasks.task_id = subtree.id) SELECT id FROM subtree) AND id <> $2 ORDER BY index`
+	SqlSelectSpecialSubtasks               = `SELECT tasks.*, json_build_array(index, id) AS page_key FROM "tasks" WHERE task_id = $1 AND special = $2 ORDER BY index,id`
 	SqlSelectTaskIdBySubtaskId             = `SELECT task_id FROM "tasks" WHERE id = $1 LIMIT 1`
 	SqlSelectMaxSubtaskIndex               = `SELECT max(index) FROM "tasks" WHERE task_id = $1 LIMIT 1`
 	SqlSelectAllSubtasksForEditIndex       = `SELECT * FROM "tasks" WHERE task_id = $1 AND index > $2`
 	SqlSelectAllSubtasksToIncreaseTheIndex = `SELECT * FROM "tasks" WHERE task_id = $1 AND index <= $2 AND index > $3`
 	SqlSelectAllSubtasksForIndexReduction  = `SELECT * FROM "tasks" WHERE task_id = $1 AND index >= $2 AND index < $3`
+	SqlSelectUndoneSubtasksCount           = `SELECT count(*) FROM "tasks" WHERE task_id = $1 AND done = $2`
+
+	// Select with recursion
+	SqlSelectRootTaskId = `WITH RECURSIVE ancestors AS (SELECT id, task_id FROM tasks WHERE id = $1 UNION SELECT tasks.id, tasks.task_id FROM tasks INNER JOIN ancestors ON tasks.id = ancestors.task_id) SELECT id FROM ancestors WHERE task_id = 0 LIMIT 1`
+	SqlSelectTaskTree   = `WITH RECURSIVE tree AS (SELECT * FROM tasks WHERE list_id = $1 UNION ALL SELECT tasks.* FROM tasks INNER JOIN tree ON tasks.task_id = tree.id) SELECT * FROM tree ORDER BY index`
+
+	SqlSelectPersonalTokenById          = `SELECT * FROM "personal_tokens" WHERE id = $1 LIMIT 1`
+	SqlSelectPersonalTokenByHash        = `SELECT * FROM "personal_tokens" WHERE token_hash = $1 LIMIT 1`
+	SqlSelectPersonalTokenByIdAndUserId = `SELECT * FROM "personal_tokens" WHERE id = $1 AND user_id = $2 LIMIT 1`
+	SqlSelectAllPersonalTokensByUserId  = `SELECT * FROM "personal_tokens" WHERE user_id = $1 ORDER BY created_at`
+
+	SqlSelectReminderById          = `SELECT * FROM "reminders" WHERE id = $1 LIMIT 1`
+	SqlSelectReminderByIdAndUserId = `SELECT * FROM "reminders" WHERE id = $1 AND user_id = $2 LIMIT 1`
+	SqlSelectAllRemindersByTaskId  = `SELECT * FROM "reminders" WHERE task_id = $1 ORDER BY remind_at`
+	SqlSelectUnsentReminders       = `SELECT * FROM "reminders" WHERE sent = $1`
+	SqlSelectTaskTreeReminders     = `SELECT * FROM "reminders" WHERE task_id IN (WITH RECURSIVE subtree AS (SELECT id FROM tasks WHERE id = $1 UNION SELECT tasks.id FROM tasks INNER JOIN subtree ON tasks.task_id = subtree.id) SELECT id FROM subtree)`
+
+	SqlSelectListMember = `SELECT * FROM "list_members" WHERE list_id = $1 AND user_id = $2 LIMIT 1`
+
+	// Only the beginning of the list members query, the arguments are checked in full
+	SqlSelectListMembers = `SELECT users.id, users.username, users.name, $1 AS role, 0 AS position FROM lists INNER JOIN users ON users.id = lists.user_id WHERE lists.id = $2`
+
+	SqlSelectListMembersCount = `SELECT count(*) FROM "list_members" WHERE list_id = $1`
+
+	SqlSelectTaskAssignee = `SELECT * FROM "task_assignees" WHERE task_id = $1 AND user_id = $2 LIMIT 1`
+
+	// Only the beginning of the assignees query, the number of task ids varies
+	SqlSelectTaskAssignees = `SELECT task_assignees.task_id, users.id, users.username FROM "task_assignees" INNER JOIN users ON users.id = task_assignees.user_id WHERE task_assignees.task_id IN (`
+
+	SqlSelectTaskCommentById = `SELECT * FROM "task_comments" WHERE id = $1 LIMIT 1`
+	SqlSelectTaskComments    = `SELECT task_comments.*, users.username, json_build_array(task_comments.created_at, task_comments.id) AS page_key FROM "task_comments" LEFT JOIN users ON users.id = task_comments.user_id WHERE task_comments.task_id = $1 ORDER BY task_comments.created_at,task_comments.id`
+
+	SqlSelectAssignedTasks = `SELECT tasks.*, roots.list_id AS root_list_id, json_build_array(CASE WHEN end_time = '0001-01-01 00:00:00+00' THEN 'infinity' ELSE end_time END, roots.list_id, index, tasks.id) AS page_key FROM "tasks" INNER JOIN (WITH RECURSIVE ancestors AS (SELECT id AS assigned_id, task_id, list_id FROM tasks WHERE id IN (SELECT task_id FROM task_assignees WHERE user_id = $1) UNION SELECT ancestors.assigned_id, tasks.task_id, tasks.list_id FROM tasks INNER JOIN ancestors ON tasks.id = ancestors.task_id) SELECT assigned_id AS id, list_id FROM ancestors WHERE task_id = 0) AS roots ON roots.id = tasks.id WHERE roots.list_id IN (SELECT id FROM "lists" WHERE lists.user_id = $2 OR lists.id IN (SELECT list_id FROM "list_access" WHERE user_id = $3)) AND done = $4 ORDER BY CASE WHEN end_time = '0001-01-01 00:00:00+00' THEN 'infinity' ELSE end_time END,roots.list_id,index,tasks.id`
 
 	// Select with join
-	SqlSelectListIdWhereTask = `SELECT lists.id FROM "lists" INNER JOIN tasks ON lists.id=tasks.list_id WHERE user_id = $1 AND tasks.id = $2 LIMIT 1`
+	SqlSelectListIdWhereTask = `SELECT lists.id FROM "lists" INNER JOIN tasks ON lists.id=tasks.list_id WHERE (lists.user_id = $1 OR lists.id IN (SELECT list_id FROM "list_access" WHERE user_id = $2)) AND tasks.id = $3 LIMIT 1`
+	SqlSelectListRole        = `SELECT CASE WHEN lists.user_id = $1 THEN $2 ELSE coalesce(list_access.role, '') END FROM "lists" LEFT JOIN list_access ON list_access.list_id = lists.id AND list_access.user_id = $3 WHERE lists.id = $4 ORDER BY array_position(ARRAY['viewer','editor','admin','owner'], list_access.role) DESC NULLS LAST LIMIT 1`
 
 	// Insert
-	SqlInsertUserData = `INSERT INTO "users" ("email","password","name","username","icon","id") VALUES ($1,$2,$3,$4,$5,$6) RETURNING "id"`
+	SqlInsertUserData = `INSERT INTO "users" ("email","password","name","username","icon","totp_secret","totp_enabled","totp_last_step","recovery_codes","role","disabled","timezone","completion_rollup","id") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14) RETURNING "id"`
+
+	SqlInsertListData = `INSERT INTO "lists" ("user_id","workspace_id","name","comment","index","id") VALUES ($1,$2,$3,$4,$5,$6) RETURNING "id"`
+
+	SqlInsertListMember = `INSERT INTO "list_members" ("list_id","user_id","role") VALUES ($1,$2,$3)`
+
+	SqlInsertWorkspaceData   = `INSERT INTO "workspaces" ("name","id") VALUES ($1,$2) RETURNING "id"`
+	SqlInsertWorkspaceMember = `INSERT INTO "workspace_members" ("workspace_id","user_id","role") VALUES ($1,$2,$3)`
+
+	SqlInsertSavedFilterData = `INSERT INTO "saved_filters" ("user_id","name","query","id") VALUES ($1,$2,$3,$4) RETURNING "id"`
+
+	SqlInsertTagData = `INSERT INTO "tags" ("user_id","name","color","id") VALUES ($1,$2,$3,$4) RETURNING "id"`
 
-	SqlInsertListData = `INSERT INTO "lists" ("user_id","name","comment","index","id") VALUES ($1,$2,$3,$4,$5) RETURNING "id"`
+	SqlInsertPersonalTokenData = `INSERT INTO "personal_tokens" ("user_id","name","token_hash","scopes","expires_at","created_at","id") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "id"`
 
-	SqlInsertTaskData = `INSERT INTO "tasks" ("list_id","task_id","name","comment","index","categories","end_time","done","special","id") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) RETURNING "id"`
+	SqlInsertTaskData = `INSERT INTO "tasks" ("list_id","task_id","name","comment","index","categories","end_time","done","special","priority","recurrence","occurrence","created_at","id") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14) RETURNING "id"`
+
+	SqlInsertTaskAssignee = `INSERT INTO "task_assignees" ("task_id","user_id") VALUES ($1,$2)`
+
+	SqlInsertReminderData = `INSERT INTO "reminders" ("task_id","user_id","before","remind_at","sent","id") VALUES ($1,$2,$3,$4,$5,$6) RETURNING "id"`
+
+	SqlInsertTaskCommentData = `INSERT INTO "task_comments" ("task_id","user_id","text","created_at","edited_at","id") VALUES ($1,$2,$3,$4,$5,$6) RETURNING "id"`
 
 	// Delete
 	SqlDeleteUser = `DELETE FROM "users" WHERE "users"."id" = $1`
 
 	SqlDeleteList = `DELETE FROM "lists" WHERE "lists"."id" = $1`
 
-	SqlDeleteTask = `DELETE FROM "tasks" WHERE "tasks"."id" = $1`
+	SqlDeleteListMember          = `DELETE FROM "list_members" WHERE list_id = $1 AND user_id = $2`
+	SqlDeleteListMembers         = `DELETE FROM "list_members" WHERE list_id = $1`
+	SqlDeleteAllUserListMembers  = `DELETE FROM "list_members" WHERE user_id = $1`
+	SqlDeleteListMemberReminders = `DELETE FROM "reminders" WHERE user_id = $1 AND task_id IN (WITH RECURSIVE tree AS (SELECT id FROM tasks WHERE list_id = $2 UNION SELECT tasks.id FROM tasks INNER JOIN tree ON tasks.task_id = tree.id) SELECT id FROM tree)`
+	SqlDeleteAllUserReminders    = `DELETE FROM "reminders" WHERE user_id = $1`
+
+	SqlDeleteWorkspace                = `DELETE FROM "workspaces" WHERE "workspaces"."id" = $1`
+	SqlDeleteWorkspaceMember          = `DELETE FROM "workspace_members" WHERE workspace_id = $1 AND user_id = $2`
+	SqlDeleteWorkspaceMembers         = `DELETE FROM "workspace_members" WHERE workspace_id = $1`
+	SqlDeleteAllUserWorkspaceMembers  = `DELETE FROM "workspace_members" WHERE user_id = $1`
+	SqlDeleteWorkspaceListMembers     = `DELETE FROM "list_members" WHERE user_id = $1 AND list_id IN (SELECT id FROM "lists" WHERE workspace_id = $2)`
+	SqlDeleteWorkspaceMemberReminders = `DELETE FROM "reminders" WHERE user_id = $1 AND task_id IN (WITH RECURSIVE tree AS (SELECT tasks.id FROM tasks INNER JOIN lists ON lists.id = tasks.list_id WHERE lists.workspace_id = $2 UNION SELECT tasks.id FROM tasks INNER JOIN tree ON tasks.task_id = tree.id) SELECT id FROM tree)`
+	SqlDeleteWorkspaceMemberAssignees = `DELETE FROM "task_assignees" WHERE user_id = $1 AND task_id IN (WITH RECURSIVE tree AS (SELECT tasks.id FROM tasks INNER JOIN lists ON lists.id = tasks.list_id WHERE lists.workspace_id = $2 UNION SELECT tasks.id FROM tasks INNER JOIN tree ON tasks.task_id = tree.id) SELECT id FROM tree)`
+
+	SqlDeleteTask     = `DELETE FROM "tasks" WHERE "tasks"."id" = $1`
+	SqlDeleteTaskTree = `DELETE FROM "tasks" WHERE id IN (WITH RECURSIVE subtree AS (SELECT id FROM tasks WHERE id = $1 UNION SELECT tasks.id FROM tasks INNER JOIN subtree ON tasks.task_id = subtree.id) SELECT id FROM subtree)`
+
+	SqlDeleteTaskAssignee        = `DELETE FROM "task_assignees" WHERE task_id = $1 AND user_id = $2`
+	SqlDeleteTaskAssignees       = `DELETE FROM "task_assignees" WHERE task_id = $1`
+	SqlDeleteTaskTreeAssignees   = `DELETE FROM "task_assignees" WHERE task_id IN (WITH RECURSIVE subtree AS (SELECT id FROM tasks WHERE id = $1 UNION SELECT tasks.id FROM tasks INNER JOIN subtree ON tasks.task_id = subtree.id) SELECT id FROM subtree)`
+	SqlDeleteListMemberAssignees = `DELETE FROM "task_assignees" WHERE user_id = $1 AND task_id IN (WITH RECURSIVE tree AS (SELECT id FROM tasks WHERE list_id = $2 UNION SELECT tasks.id FROM tasks INNER JOIN tree ON tasks.task_id = tree.id) SELECT id FROM tree)`
+	SqlDeleteMovedTaskAssignees  = `DELETE FROM "task_assignees" WHERE task_id IN (WITH RECURSIVE subtree AS (SELECT id FROM tasks WHERE id = $1 UNION SELECT tasks.id FROM tasks INNER JOIN subtree ON tasks.task_id = subtree.id) SELECT id FROM subtree) AND user_id NOT IN (WITH RECURSIVE ancestors AS (SELECT id, task_id, list_id FROM tasks WHERE id = $2 UNION SELECT tasks.id, tasks.task_id, tasks.list_id FROM tasks INNER JOIN ancestors ON tasks.id = ancestors.task_id), root AS (SELECT list_id FROM ancestors WHERE task_id = 0 LIMIT 1) SELECT lists.user_id FROM lists INNER JOIN root ON lists.id = root.list_id UNION SELECT list_access.user_id FROM list_access INNER JOIN root ON list_access.list_id = root.list_id)`
+	SqlDeleteAllUserAssignees    = `DELETE FROM "task_assignees" WHERE user_id = $1`
+	SqlDeleteTaskComments        = `DELETE FROM "task_comments" WHERE task_id = $1`
+	SqlDeleteTaskTreeComments    = `DELETE FROM "task_comments" WHERE task_id IN (WITH RECURSIVE subtree AS (SELECT id FROM tasks WHERE id = $1 UNION SELECT tasks.id FROM tasks INNER JOIN subtree ON tasks.task_id = subtree.id) SELECT id FROM subtree)`
+
+	SqlDeleteMovedTaskReminders = `DELETE FROM "reminders" WHERE task_id IN (WITH RECURSIVE subtree AS (SELECT id FROM tasks WHERE id = $1 UNION SELECT tasks.id FROM tasks INNER JOIN subtree ON tasks.task_id = subtree.id) SELECT id FROM subtree) AND user_id NOT IN (WITH RECURSIVE ancestors AS (SELECT id, task_id, list_id FROM tasks WHERE id = $2 UNION SELECT tasks.id, tasks.task_id, tasks.list_id FROM tasks INNER JOIN ancestors ON tasks.id = ancestors.task_id), root AS (SELECT list_id FROM ancestors WHERE task_id = 0 LIMIT 1) SELECT lists.user_id FROM lists INNER JOIN root ON lists.id = root.list_id UNION SELECT list_access.user_id FROM list_access INNER JOIN root ON list_access.list_id = root.list_id)`
+
+	SqlDeleteReminder          = `DELETE FROM "reminders" WHERE "reminders"."id" = $1`
+	SqlDeleteTaskComment       = `DELETE FROM "task_comments" WHERE "task_comments"."id" = $1`
+	SqlDeleteTaskReminders     = `DELETE FROM "reminders" WHERE task_id = $1`
+	SqlDeleteTaskTreeReminders = `DELETE FROM "reminders" WHERE task_id IN (WITH RECURSIVE subtree AS (SELECT id FROM tasks WHERE id = $1 UNION SELECT tasks.id FROM tasks INNER JOIN subtree ON tasks.task_id = subtree.id) SELECT id FROM subtree)`
+
+	SqlDeletePersonalToken         = `DELETE FROM "personal_tokens" WHERE "personal_tokens"."id" = $1`
+	SqlDeleteAllUserPersonalTokens = `DELETE FROM "personal_tokens" WHERE user_id = $1`
+	SqlDeleteSavedFilter           = `DELETE FROM "saved_filters" WHERE "saved_filters"."id" = $1`
+	SqlDeleteAllUserSavedFilters   = `DELETE FROM "saved_filters" WHERE user_id = $1`
+	SqlDeleteTag                   = `DELETE FROM "tags" WHERE "tags"."id" = $1`
+	SqlDeleteAllUserTags           = `DELETE FROM "tags" WHERE user_id = $1`
 
 	// Edit
-	SqlEditUserName     = `UPDATE "users" SET "name"=$1 WHERE "users"."id" = $2`
-	SqlEditUserIcon     = `UPDATE "users" SET "icon"=$1 WHERE id = $2`
-	SqlEditUserEmail    = `UPDATE "users" SET "email"=$1 WHERE "users"."id" = $2`
-	SqlEditUserUsername = `UPDATE "users" SET "username"=$1 WHERE "users"."id" = $2`
-	SqlEditUserPassword = `UPDATE "users" SET "password"=$1 WHERE email = $2`
+	SqlEditUserName       = `UPDATE "users" SET "name"=$1 WHERE "users"."id" = $2`
+	SqlEditUserIcon       = `UPDATE "users" SET "icon"=$1 WHERE id = $2`
+	SqlEditUserEmail      = `UPDATE "users" SET "email"=$1 WHERE "users"."id" = $2`
+	SqlEditUserUsername   = `UPDATE "users" SET "username"=$1 WHERE "users"."id" = $2`
+	SqlEditUserTimezone   = `UPDATE "users" SET "timezone"=$1 WHERE "users"."id" = $2`
+	SqlEditUserPassword   = `UPDATE "users" SET "password"=$1 WHERE email = $2`
+	SqlEditUserTOTPSecret = `UPDATE "users" SET "totp_secret"=$1 WHERE id = $2`
+	SqlEditUserDisabled   = `UPDATE "users" SET "disabled"=$1 WHERE id = $2`
+	SqlEnableUserTOTP     = `UPDATE "users" SET "recovery_codes"=$1,"totp_enabled"=$2 WHERE id = $3`
+	SqlDisableUserTOTP    = `UPDATE "users" SET "recovery_codes"=$1,"totp_enabled"=$2,"totp_secret"=$3 WHERE id = $4`
+	SqlUseTOTPStep        = `UPDATE "users" SET "totp_last_step"=$1 WHERE id = $2 AND totp_last_step < $3`
+	SqlUseRecoveryCode    = `UPDATE "users" SET "recovery_codes"=array_remove(recovery_codes, $1) WHERE id = $2 AND $3 = ANY(recovery_codes)`
+
+	SqlEditUserCompletionRollup = `UPDATE "users" SET "completion_rollup"=$1 WHERE id = $2`
 
 	SqlEditList      = `UPDATE "lists" SET "name"=$1,"comment"=$2 WHERE "id" = $3`
 	SqlEditListIndex = `UPDATE "lists" SET "index"=$1 WHERE id = $2`
 
-	SqlEditTask      = `UPDATE "tasks" SET "name"=$1,"comment"=$2,"categories"=$3,"end_time"=$4,"done"=$5,"special"=$6 WHERE "id" = $7`
+	SqlEditListMemberRole = `UPDATE "list_members" SET "role"=$1 WHERE list_id = $2 AND user_id = $3`
+
+	SqlEditWorkspace           = `UPDATE "workspaces" SET "name"=$1 WHERE id = $2`
+	SqlEditWorkspaceMemberRole = `UPDATE "workspace_members" SET "role"=$1 WHERE workspace_id = $2 AND user_id = $3`
+	SqlPromoteWorkspaceMembers = `UPDATE "workspace_members" SET "role"=$1 WHERE workspace_id = $2 AND role = $3`
+
+	SqlEditSavedFilter = `UPDATE "saved_filters" SET "name"=$1,"query"=$2 WHERE "id" = $3`
+
+	SqlEditTag = `UPDATE "tags" SET "name"=$1,"color"=$2 WHERE "id" = $3`
+
+	SqlRenameTaskCategory = `UPDATE "tasks" SET "categories"=array_replace(array_remove(categories, $1), $2, $3) WHERE $4 = ANY(categories) AND id IN (WITH RECURSIVE tree AS (SELECT id FROM tasks WHERE list_id IN (SELECT id FROM "lists" WHERE lists.user_id = $5 OR lists.id IN (SELECT list_id FROM "list_access" WHERE user_id = $6 AND role IN ($7,$8,$9))) UNION SELECT tasks.id FROM tasks INNER JOIN tree ON tasks.task_id = tree.id) SELECT id FROM tree)`
+	SqlRemoveTaskCategory = `UPDATE "tasks" SET "categories"=array_remove(categories, $1) WHERE $2 = ANY(categories) AND id IN (WITH RECURSIVE tree AS (SELECT id FROM tasks WHERE list_id IN (SELECT id FROM "lists" WHERE lists.user_id = $3 OR lists.id IN (SELECT list_id FROM "list_access" WHERE user_id = $4 AND role IN ($5,$6,$7))) UNION SELECT tasks.id FROM tasks INNER JOIN tree ON tasks.task_id = tree.id) SELECT id FROM tree)`
+
+	SqlEditTask      = `UPDATE "tasks" SET "name"=$1,"comment"=$2,"categories"=$3,"end_time"=$4,"done"=$5,"special"=$6,"priority"=$7 WHERE "id" = $8`
 	SqlEditTaskIndex = `UPDATE "tasks" SET "index"=$1 WHERE id = $2`
+	SqlCompleteTask  = `UPDATE "tasks" SET "done"=$1 WHERE id = $2 AND recurrence = $3`
+
+	SqlEditTaskRecurrence = `UPDATE "tasks" SET "end_time"=$1,"recurrence"=$2,"occurrence"=$3 WHERE "id" = $4`
+
+	SqlCloseTaskGap       = `UPDATE "tasks" SET "index"=index - 1 WHERE list_id = $1 AND index > $2`
+	SqlOpenTaskPlace      = `UPDATE "tasks" SET "index"=index + 1 WHERE list_id = $1 AND index >= $2`
+	SqlCloseSubtaskGap    = `UPDATE "tasks" SET "index"=index - 1 WHERE task_id = $1 AND index > $2`
+	SqlOpenSubtaskPlace   = `UPDATE "tasks" SET "index"=index + 1 WHERE task_id = $1 AND index >= $2`
+	SqlMoveTask           = `UPDATE "tasks" SET "index"=$1,"list_id"=$2,"task_id"=$3 WHERE id = $4`
+	SqlMoveTaskToSubtasks = `UPDATE "tasks" SET "index"=$1,"list_id"=$2,"occurrence"=$3,"recurrence"=$4,"task_id"=$5 WHERE id = $6`
+
+	SqlEditReminderTime = `UPDATE "reminders" SET "remind_at"=$1,"sent"=$2 WHERE id = $3`
+	SqlMarkReminderSent = `UPDATE "reminders" SET "sent"=$1 WHERE id = $2 AND sent = $3`
+
+	SqlEditTaskComment = `UPDATE "task_comments" SET "edited_at"=$1,"text"=$2 WHERE id = $3`
 )
@@ -0,0 +1,28 @@
+package models
+
+type ApiTwoFactorSetup struct {
+	Secret string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	URI    string `json:"uri" example:"otpauth://totp/ToDo%20API:nktkln@example.com?algorithm=SHA1&digits=6&issuer=ToDo+API&period=30&secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+}
+
+type ApiRecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"3f9a1-0c7b2,8d2e4-b61f0"`
+}
+
+type ApiTwoFactorChallenge struct {
+	Challenge string `json:"challenge" example:"4b0a6bd2-4c5e-4d1f-9a0e-6f7e2b4c1d3a"`
+}
+
+type TwoFactorCode struct {
+	Code string `json:"code" example:"123456"`
+}
+
+type TwoFactorSignIn struct {
+	Challenge string `json:"challenge" example:"4b0a6bd2-4c5e-4d1f-9a0e-6f7e2b4c1d3a"`
+	Code      string `json:"code" example:"123456"`
+}
+
+type TwoFactorDisable struct {
+	Password string `json:"password" example:"StRon9Pa$$w0rd"`
+	Code     string `json:"code" example:"123456"`
+}
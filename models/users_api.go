@@ -8,6 +8,14 @@ type UserUsername struct {
 	Username string `json:"username" example:"nktkln"`
 }
 
+type UserTimezone struct {
+	Timezone string `json:"timezone" example:"Europe/Moscow"`
+}
+
+type UserCompletionRollup struct {
+	Rollup bool `json:"rollup" example:"true"`
+}
+
 type UserEmail struct {
 	Email string `json:"email" example:"nktkln@example.com"`
 }
@@ -24,6 +32,7 @@ type UserTokens struct {
 type UserLoginData struct {
 	Email    string `json:"email" example:"nktkln@example.com"`
 	Password string `json:"password" example:"StRon9Pa$$w0rd"`
+	Device   string `json:"device" example:"iPhone 13"`
 }
 
 type UserData struct {
@@ -31,6 +40,7 @@ type UserData struct {
 	Password string `json:"password" example:"StRon9Pa$$w0rd"`
 	Name     string `json:"name" example:"NKTKLN"`
 	Username string `json:"username" example:"nktkln"`
+	Invite   string `json:"invite,omitempty" example:"1023456789"` // Key of the invitation to a shared list
 }
 
 type ShowUserData struct {
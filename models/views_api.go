@@ -0,0 +1,12 @@
+package models
+
+type ApiShowViewTasks struct {
+	Tasks      []ViewTasksData `json:"tasks"`
+	NextCursor string          `json:"next_cursor,omitempty" example:"WzAsMTAyMzQ1Njc4OV0"`
+}
+
+type ViewTasksData struct {
+	ListId   int    `json:"list_id" example:"1023456789"`
+	ListName string `json:"list_name" example:"List of products"`
+	TasksData
+}
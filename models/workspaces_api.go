@@ -0,0 +1,43 @@
+package models
+
+type ApiShowWorkspaces struct {
+	Workspaces []WorkspacesData `json:"workspaces"`
+}
+
+type ApiWorkspaceData struct {
+	Name string `json:"name" example:"Company"`
+}
+
+type WorkspacesData struct {
+	Id   int    `json:"id" example:"1023456789"`
+	Name string `json:"name" example:"Company"`
+	Role string `json:"role" example:"admin" enums:"admin,editor,viewer"`
+}
+
+type WorkspaceEditData struct {
+	Id   int    `json:"id" example:"1023456789"`
+	Name string `json:"name" example:"New company"`
+}
+
+type ApiShowWorkspaceMembers struct {
+	Members []WorkspaceMemberData `json:"members"`
+}
+
+type WorkspaceMemberData struct {
+	Id       int    `json:"id" example:"1023456789"`
+	Username string `json:"username" example:"NKTKLN"`
+	Name     string `json:"name" example:"Nikita"`
+	Role     string `json:"role" example:"editor" enums:"admin,editor,viewer"`
+}
+
+type WorkspaceMemberAddData struct {
+	WorkspaceId int    `json:"workspace_id" example:"1023456789"`
+	User        string `json:"user" example:"NKTKLN"` // Username or email of the user
+	Role        string `json:"role" example:"editor" enums:"admin,editor,viewer"`
+}
+
+type WorkspaceMemberEditData struct {
+	WorkspaceId int    `json:"workspace_id" example:"1023456789"`
+	UserId      int    `json:"user_id" example:"1023456789"`
+	Role        string `json:"role" example:"viewer" enums:"admin,editor,viewer"`
+}
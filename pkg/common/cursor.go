@@ -0,0 +1,50 @@
+package common
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// EncodeCursor hides the sort key of the last row of the page from the client, an empty key means there is no next
+// page
+func EncodeCursor(key []interface{}) string {
+	if len(key) == 0 {
+		return ""
+	}
+
+	value, err := json.Marshal(key)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(value)
+}
+
+// DecodeCursor returns the sort key of the last row of the previous page, an empty cursor is the first page. Numbers
+// are kept as json.Number to not lose the precision of the ids.
+func DecodeCursor(cursor string) (key []interface{}, err error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	value, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+	if err := decoder.Decode(&key); err != nil {
+		return nil, err
+	}
+
+	for _, part := range key {
+		switch part.(type) {
+		case json.Number, string, bool:
+		default:
+			return nil, errors.New("incorrect cursor")
+		}
+	}
+	return key, nil
+}
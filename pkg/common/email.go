@@ -8,6 +8,7 @@ import (
 	"html/template"
 	"net/mail"
 	"net/smtp"
+	"time"
 
 	"github.com/NKTKLN/todo-api/models"
 	"github.com/NKTKLN/todo-api/pkg/db"
@@ -25,6 +26,11 @@ type EmailProvider interface {
 	UserEmailVerification(context.Context, db.RedisClient, models.UserData) error
 	UserPasswordReset(context.Context, db.RedisClient, string) error
 	UserEmailReset(context.Context, db.RedisClient, string, int) error
+	AccountLocked(string, time.Duration) error
+	TaskReminder(string, string, time.Time) error
+	ListInvite(context.Context, db.RedisClient, models.ListInvites, string, string) error
+	TaskAssigned(string, string, string, string) error
+	TaskMentioned(string, string, string, string) error
 }
 
 // Creating new service for email
@@ -45,7 +51,7 @@ func (d *EmailAuthData) UserEmailVerification(ctx context.Context, client db.Red
 	}
 
 	// Create a temporary access key
-	key, err := client.AddEmailData(ctx, jsonData)
+	key, err := client.AddEmailData(ctx, models.VERIFICATION_FLOW, jsonData)
 	if err != nil {
 		return
 	}
@@ -63,13 +69,13 @@ func (d *EmailAuthData) UserEmailVerification(ctx context.Context, client db.Red
 
 func (d *EmailAuthData) UserPasswordReset(ctx context.Context, client db.RedisClient, userEmail string) (err error) {
 	// Create a temporary access key
-	key, err := client.AddEmailData(ctx, userEmail)
+	key, err := client.AddEmailData(ctx, models.PASSWORD_RESET_FLOW, userEmail)
 	if err != nil {
 		return
 	}
 
 	// Generate message from template
-	htmlTemplate := template.Must(template.ParseFiles("templates/passwors_reset.html"))
+	htmlTemplate := template.Must(template.ParseFiles("templates/password_reset.html"))
 	buf := new(bytes.Buffer)
 	if err = htmlTemplate.Execute(buf, map[string]string{"verificationCode": key}); err != nil {
 		return
@@ -87,7 +93,7 @@ func (d *EmailAuthData) UserEmailReset(ctx context.Context, client db.RedisClien
 	}
 
 	// Create a temporary access key
-	key, err := client.AddEmailData(ctx, jsonData)
+	key, err := client.AddEmailData(ctx, models.EMAIL_CHANGE_FLOW, jsonData)
 	if err != nil {
 		return
 	}
@@ -103,6 +109,77 @@ func (d *EmailAuthData) UserEmailReset(ctx context.Context, client db.RedisClien
 	return d.sendEmail(userEmail, "Changing email", buf.String())
 }
 
+func (d *EmailAuthData) AccountLocked(userEmail string, lockout time.Duration) (err error) {
+	// Generate message from template
+	htmlTemplate := template.Must(template.ParseFiles("templates/account_locked.html"))
+	buf := new(bytes.Buffer)
+	if err = htmlTemplate.Execute(buf, map[string]string{"lockout": fmt.Sprint(lockout.Round(time.Minute).Minutes())}); err != nil {
+		return
+	}
+
+	// Sending an email about the account lockout to a user
+	return d.sendEmail(userEmail, "Account locked", buf.String())
+}
+
+func (d *EmailAuthData) TaskReminder(userEmail, taskName string, endTime time.Time) (err error) {
+	var taskEndTime string
+	if !endTime.IsZero() {
+		taskEndTime = endTime.Format("2006-01-02 15:04")
+	}
+
+	// Generate message from template
+	htmlTemplate := template.Must(template.ParseFiles("templates/task_reminder.html"))
+	buf := new(bytes.Buffer)
+	if err = htmlTemplate.Execute(buf, map[string]string{"name": taskName, "endTime": taskEndTime}); err != nil {
+		return
+	}
+
+	// Sending a task reminder to a user
+	return d.sendEmail(userEmail, "Reminder: "+taskName, buf.String())
+}
+
+func (d *EmailAuthData) ListInvite(ctx context.Context, client db.RedisClient, invite models.ListInvites, inviterName, listName string) (err error) {
+	// Create an invitation key
+	key, err := client.AddListInvite(ctx, invite)
+	if err != nil {
+		return
+	}
+
+	// Generate message from template
+	htmlTemplate := template.Must(template.ParseFiles("templates/list_invite.html"))
+	buf := new(bytes.Buffer)
+	if err = htmlTemplate.Execute(buf, map[string]string{"inviter": inviterName, "list": listName, "inviteCode": key}); err != nil {
+		return
+	}
+
+	// Sending an invitation to the shared list
+	return d.sendEmail(invite.Email, "Invitation to the list "+listName, buf.String())
+}
+
+func (d *EmailAuthData) TaskAssigned(userEmail, taskName, listName, assignerName string) (err error) {
+	// Generate message from template
+	htmlTemplate := template.Must(template.ParseFiles("templates/task_assigned.html"))
+	buf := new(bytes.Buffer)
+	if err = htmlTemplate.Execute(buf, map[string]string{"name": taskName, "list": listName, "assigner": assignerName}); err != nil {
+		return
+	}
+
+	// Sending a notification about the assigned task
+	return d.sendEmail(userEmail, "New task: "+taskName, buf.String())
+}
+
+func (d *EmailAuthData) TaskMentioned(userEmail, taskName, authorName, text string) (err error) {
+	// Generate message from template
+	htmlTemplate := template.Must(template.ParseFiles("templates/task_mentioned.html"))
+	buf := new(bytes.Buffer)
+	if err = htmlTemplate.Execute(buf, map[string]string{"name": taskName, "author": authorName, "text": text}); err != nil {
+		return
+	}
+
+	// Sending a notification about the mention
+	return d.sendEmail(userEmail, "New mention: "+taskName, buf.String())
+}
+
 func (d *EmailAuthData) sendEmail(userEmail, subject, body string) error {
 	message := email.NewHTMLMessage(subject, body)
 	message.From = mail.Address{
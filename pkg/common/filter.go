@@ -0,0 +1,258 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NKTKLN/todo-api/models"
+)
+
+// Filter is a saved tasks filter. It consists of terms joined with AND:
+//
+//	category contains Work AND not done AND due within 7 days
+//
+// The terms are [not] done, [not] special, category contains <value>, text contains <value>, overdue,
+// due within <n> days, due before <date> and due after <date>. Values with spaces are written in double quotes,
+// dates are written in the 2006-01-02 format.
+type Filter struct {
+	done      *bool
+	special   *bool
+	category  string
+	text      string
+	overdue   bool
+	dueWithin *int // Days after today
+	dueBefore time.Time
+	dueAfter  time.Time
+}
+
+type filterParser struct {
+	tokens   []string
+	position int
+	filter   *Filter
+}
+
+func ParseFilter(query string) (*Filter, error) {
+	tokens, err := filterTokens(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, errors.New("empty filter")
+	}
+
+	p := &filterParser{tokens: tokens, filter: &Filter{}}
+	for {
+		if err := p.term(); err != nil {
+			return nil, err
+		}
+
+		switch joiner := p.next(); {
+		case joiner == "":
+			return p.filter, nil
+		case strings.EqualFold(joiner, "or"):
+			return nil, errors.New("OR is not supported, terms can only be joined with AND")
+		case !strings.EqualFold(joiner, "and"):
+			return nil, fmt.Errorf("expected AND before %q", joiner)
+		}
+	}
+}
+
+// filterTokens splits the query by spaces, text in double quotes is kept as one token
+func filterTokens(query string) (tokens []string, err error) {
+	var token strings.Builder
+	quoted, hasToken := false, false
+	for _, char := range query {
+		switch {
+		case char == '"':
+			quoted, hasToken = !quoted, true
+		case !quoted && (char == ' ' || char == '\t' || char == '\n'):
+			if hasToken {
+				tokens = append(tokens, token.String())
+				token.Reset()
+				hasToken = false
+			}
+		default:
+			token.WriteRune(char)
+			hasToken = true
+		}
+	}
+
+	if quoted {
+		return nil, errors.New("unclosed double quote")
+	}
+	if hasToken {
+		tokens = append(tokens, token.String())
+	}
+	return
+}
+
+func (p *filterParser) next() string {
+	if p.position >= len(p.tokens) {
+		return ""
+	}
+	p.position++
+	return p.tokens[p.position-1]
+}
+
+// expect checks that the next token is the keyword that follows the previous one
+func (p *filterParser) expect(previous, keyword string) error {
+	if token := p.next(); !strings.EqualFold(token, keyword) {
+		return fmt.Errorf("expected %q after %q", keyword, previous)
+	}
+	return nil
+}
+
+func (p *filterParser) value(previous string) (string, error) {
+	value := p.next()
+	if value == "" {
+		return "", fmt.Errorf("missing a value after %q", previous)
+	}
+	return value, nil
+}
+
+func (p *filterParser) term() error {
+	word := p.next()
+	negated := strings.EqualFold(word, "not")
+	if negated {
+		word = p.next()
+	}
+
+	switch name := strings.ToLower(word); name {
+	case "":
+		if negated {
+			return errors.New(`missing a term after "not"`)
+		}
+		return errors.New(`missing a term after "and"`)
+	case "done", "special":
+		flag := &p.filter.done
+		if name == "special" {
+			flag = &p.filter.special
+		}
+		if *flag != nil {
+			return fmt.Errorf("duplicate %q condition", name)
+		}
+		value := !negated
+		*flag = &value
+		return nil
+	}
+
+	if negated {
+		return fmt.Errorf(`"not" can only be used with "done" and "special", not with %q`, word)
+	}
+
+	switch name := strings.ToLower(word); name {
+	case "category", "text":
+		if err := p.expect(word, "contains"); err != nil {
+			return err
+		}
+		value, err := p.value("contains")
+		if err != nil {
+			return err
+		}
+
+		field := &p.filter.category
+		if name == "text" {
+			field = &p.filter.text
+		}
+		if *field != "" {
+			return fmt.Errorf("duplicate %q condition", name)
+		}
+		*field = value
+	case "overdue":
+		if p.filter.hasDueEnd() {
+			return errors.New("conflicting due conditions")
+		}
+		p.filter.overdue = true
+	case "due":
+		return p.dueTerm()
+	default:
+		return fmt.Errorf("unknown term %q", word)
+	}
+	return nil
+}
+
+func (p *filterParser) dueTerm() error {
+	switch kind := strings.ToLower(p.next()); kind {
+	case "within":
+		value, err := p.value("within")
+		if err != nil {
+			return err
+		}
+		days, err := strconv.Atoi(value)
+		if err != nil || days < 0 {
+			return fmt.Errorf("incorrect number of days %q", value)
+		}
+		if unit := strings.ToLower(p.next()); unit != "day" && unit != "days" {
+			return fmt.Errorf(`expected "days" after %q`, value)
+		}
+
+		if p.filter.hasDueEnd() || !p.filter.dueAfter.IsZero() {
+			return errors.New("conflicting due conditions")
+		}
+		p.filter.dueWithin = &days
+	case "before", "after":
+		value, err := p.value(kind)
+		if err != nil {
+			return err
+		}
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return fmt.Errorf("incorrect date %q, expected the 2006-01-02 format", value)
+		}
+
+		if kind == "before" {
+			if p.filter.hasDueEnd() {
+				return errors.New("conflicting due conditions")
+			}
+			p.filter.dueBefore = date
+		} else {
+			if p.filter.dueWithin != nil || !p.filter.dueAfter.IsZero() {
+				return errors.New("conflicting due conditions")
+			}
+			p.filter.dueAfter = date
+		}
+	default:
+		return errors.New(`expected "within", "before" or "after" after "due"`)
+	}
+	return nil
+}
+
+// hasDueEnd checks whether the end of the due range is already set
+func (f *Filter) hasDueEnd() bool {
+	return f.overdue || f.dueWithin != nil || !f.dueBefore.IsZero()
+}
+
+// TasksFilter converts the filter into the tasks filter, relative terms are counted from now and dates are taken in
+// the location of now
+func (f *Filter) TasksFilter(now time.Time) models.TasksFilter {
+	filter := models.TasksFilter{
+		Done:     f.done,
+		Special:  f.special,
+		Category: f.category,
+		Search:   f.text,
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch {
+	case f.overdue:
+		filter.DueBefore = now
+	case f.dueWithin != nil:
+		// Today is included, so "due within 0 days" is today
+		filter.DueAfter = today
+		filter.DueBefore = today.AddDate(0, 0, *f.dueWithin+1)
+	case !f.dueBefore.IsZero():
+		filter.DueBefore = inLocation(f.dueBefore, now.Location())
+	}
+	if !f.dueAfter.IsZero() {
+		filter.DueAfter = inLocation(f.dueAfter, now.Location())
+	}
+
+	return filter
+}
+
+func inLocation(date time.Time, location *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, location)
+}
@@ -6,42 +6,64 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 )
 
-func NewJWT(userId int, ttl time.Duration, key string) (jwtToken string, err error) {
+type TokenClaims struct {
+	SessionId string `json:"sid"`
+	TokenUse  string `json:"token_use,omitempty"`
+	jwt.RegisteredClaims
+}
+
+func newTokenClaims(userId int, sessionId, tokenUse string, ttl time.Duration) TokenClaims {
+	return TokenClaims{
+		SessionId: sessionId,
+		TokenUse:  tokenUse,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: &jwt.NumericDate{Time: time.Now().Add(ttl)},
+			Subject:   strconv.Itoa(userId),
+			ID:        uuid.New().String(),
+		},
+	}
+}
+
+// NewJWT creates a HS256 token, it is used when there are no asymmetric keys
+func NewJWT(userId int, sessionId string, ttl time.Duration, key string) (jwtToken string, err error) {
 	// Creating a new token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
-		ExpiresAt: &jwt.NumericDate{Time: time.Now().Add(ttl)},
-		Subject:   strconv.Itoa(userId),
-	})
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, newTokenClaims(userId, sessionId, "", ttl))
 
 	// Token signing
 	return token.SignedString([]byte(key))
 }
 
-func VerifyToken(tokenString, key string) int {
+func VerifyToken(tokenString, key string) (userId int, claims TokenClaims) {
 	// Token decryption
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &TokenClaims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %s", token.Header["alg"])
 		}
 
 		return []byte(key), nil
 	})
+
+	return tokenData(token, err, "")
+}
+
+func tokenData(token *jwt.Token, err error, tokenUse string) (userId int, claims TokenClaims) {
 	if err != nil {
-		return 0
+		return
 	}
 
 	// Retrieving data from a token
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok || !token.Valid {
-		return 0
+	tokenClaims, ok := token.Claims.(*TokenClaims)
+	if !ok || !token.Valid || tokenClaims.TokenUse != tokenUse {
+		return
 	}
-	
-	userId, err := strconv.Atoi(claims["sub"].(string))
+
+	userId, err = strconv.Atoi(tokenClaims.Subject)
 	if err != nil {
-		return 0
+		return 0, TokenClaims{}
 	}
-	
-	return userId
-}
\ No newline at end of file
+
+	return userId, *tokenClaims
+}
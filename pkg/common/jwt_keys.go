@@ -0,0 +1,158 @@
+package common
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/NKTKLN/todo-api/models"
+)
+
+type JWTKeyFile struct {
+	Id   string `mapstructure:"id"`
+	File string `mapstructure:"file"`
+}
+
+type JWTKey struct {
+	Id         string
+	Method     jwt.SigningMethod
+	PrivateKey crypto.PrivateKey
+	PublicKey  crypto.PublicKey
+}
+
+// JWTKeys signs tokens with the first key, the rest of the keys are only used to verify the tokens issued before the rotation
+type JWTKeys struct {
+	signingKey *JWTKey
+	keys       []*JWTKey
+}
+
+// LoadJWTKeys loads keys from PEM files, without files the tokens are signed with HS256
+func LoadJWTKeys(files []JWTKeyFile) (*JWTKeys, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	keys := &JWTKeys{}
+	for _, file := range files {
+		if file.Id == "" {
+			return nil, fmt.Errorf("jwt key %s has no id", file.File)
+		}
+		if keys.key(file.Id) != nil {
+			return nil, fmt.Errorf("jwt key id %s is used twice", file.Id)
+		}
+
+		data, err := os.ReadFile(file.File)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := ParseJWTKey(file.Id, data)
+		if err != nil {
+			return nil, err
+		}
+		keys.keys = append(keys.keys, key)
+	}
+
+	// New tokens can only be signed with a private key
+	keys.signingKey = keys.keys[0]
+	if keys.signingKey.PrivateKey == nil {
+		return nil, fmt.Errorf("jwt key %s is used for signing, but it is not a private key", keys.signingKey.Id)
+	}
+
+	return keys, nil
+}
+
+// ParseJWTKey accepts RSA and Ed25519 keys, a public key can only verify tokens
+func ParseJWTKey(id string, data []byte) (*JWTKey, error) {
+	if privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(data); err == nil {
+		return &JWTKey{Id: id, Method: jwt.SigningMethodRS256, PrivateKey: privateKey, PublicKey: &privateKey.PublicKey}, nil
+	}
+	if privateKey, err := jwt.ParseEdPrivateKeyFromPEM(data); err == nil {
+		return &JWTKey{Id: id, Method: jwt.SigningMethodEdDSA, PrivateKey: privateKey, PublicKey: privateKey.(ed25519.PrivateKey).Public()}, nil
+	}
+	if publicKey, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+		return &JWTKey{Id: id, Method: jwt.SigningMethodRS256, PublicKey: publicKey}, nil
+	}
+	if publicKey, err := jwt.ParseEdPublicKeyFromPEM(data); err == nil {
+		return &JWTKey{Id: id, Method: jwt.SigningMethodEdDSA, PublicKey: publicKey}, nil
+	}
+
+	return nil, fmt.Errorf("jwt key %s is not a RSA or Ed25519 key", id)
+}
+
+func (k *JWTKeys) NewJWT(userId int, sessionId, tokenUse string, ttl time.Duration) (jwtToken string, err error) {
+	// Creating a new token
+	token := jwt.NewWithClaims(k.signingKey.Method, newTokenClaims(userId, sessionId, tokenUse, ttl))
+	token.Header["kid"] = k.signingKey.Id
+
+	// Token signing
+	return token.SignedString(k.signingKey.PrivateKey)
+}
+
+func (k *JWTKeys) VerifyToken(tokenString, tokenUse string) (userId int, claims TokenClaims) {
+	// Token decryption
+	token, err := jwt.ParseWithClaims(tokenString, &TokenClaims{}, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key := k.key(kid)
+		if key == nil {
+			return nil, fmt.Errorf("unknown key: %s", kid)
+		}
+
+		// The algorithm is taken from the key, so that a token can't choose it
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %s", token.Header["alg"])
+		}
+
+		return key.PublicKey, nil
+	})
+
+	return tokenData(token, err, tokenUse)
+}
+
+// JWKS returns the public keys in the JSON Web Key Set format
+func (k *JWTKeys) JWKS() models.JWKS {
+	jwks := models.JWKS{Keys: []models.JWK{}}
+	if k == nil {
+		return jwks
+	}
+
+	for _, key := range k.keys {
+		jwk := models.JWK{
+			KeyId:     key.Id,
+			Use:       "sig",
+			Algorithm: key.Method.Alg(),
+		}
+
+		switch publicKey := key.PublicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks
+}
+
+func (k *JWTKeys) key(id string) *JWTKey {
+	for _, key := range k.keys {
+		if key.Id == id {
+			return key
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package common
+
+import (
+	"regexp"
+	"strings"
+)
+
+// mentionRegexp matches the @username mentions, the @ in the middle of a word like in emails is not a mention
+var mentionRegexp = regexp.MustCompile(`(?:^|[^\w@])@([a-zA-Z0-9_-]+)`)
+
+// ParseMentions returns the unique usernames mentioned in the text in the order of their first mention
+func ParseMentions(text string) (usernames []string) {
+	mentioned := make(map[string]bool)
+	for _, match := range mentionRegexp.FindAllStringSubmatch(text, -1) {
+		username := strings.ToLower(match[1])
+		if !mentioned[username] {
+			mentioned[username] = true
+			usernames = append(usernames, username)
+		}
+	}
+	return
+}
@@ -0,0 +1,227 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Number of months that are checked when looking for the next monthly occurrence
+const rruleMaxMonths = 48
+
+var rruleWeekdays = []string{"MO", "TU", "WE", "TH", "FR", "SA", "SU"}
+
+// RRule is a subset of the RFC 5545 recurrence rule: DAILY, WEEKLY with BYDAY and MONTHLY with BYMONTHDAY,
+// limited by COUNT or UNTIL
+type RRule struct {
+	freq       string
+	interval   int
+	byDay      []int // Days from monday
+	byMonthDay []int
+	count      int
+	until      time.Time
+}
+
+func ParseRRule(rule string) (*RRule, error) {
+	r := &RRule{interval: 1}
+	rule = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(rule)), "RRULE:")
+
+	for _, part := range strings.Split(rule, ";") {
+		name, value, found := strings.Cut(part, "=")
+		if !found || value == "" {
+			return nil, fmt.Errorf("incorrect rule part %q", part)
+		}
+
+		var err error
+		switch name {
+		case "FREQ":
+			if value != "DAILY" && value != "WEEKLY" && value != "MONTHLY" {
+				return nil, fmt.Errorf("unsupported frequency %q", value)
+			}
+			r.freq = value
+		case "INTERVAL":
+			r.interval, err = strconv.Atoi(value)
+			if err != nil || r.interval < 1 {
+				return nil, errors.New("incorrect interval")
+			}
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				index := indexOf(rruleWeekdays, day)
+				if index < 0 {
+					return nil, fmt.Errorf("incorrect weekday %q", day)
+				}
+				if indexOf(r.byDay, index) < 0 {
+					r.byDay = append(r.byDay, index)
+				}
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(value, ",") {
+				monthDay, err := strconv.Atoi(day)
+				if err != nil || monthDay == 0 || monthDay < -31 || monthDay > 31 {
+					return nil, fmt.Errorf("incorrect month day %q", day)
+				}
+				r.byMonthDay = append(r.byMonthDay, monthDay)
+			}
+		case "COUNT":
+			r.count, err = strconv.Atoi(value)
+			if err != nil || r.count < 1 {
+				return nil, errors.New("incorrect count")
+			}
+		case "UNTIL":
+			if r.until, err = parseRRuleUntil(value); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unsupported rule part %q", name)
+		}
+	}
+
+	// Rule parts check
+	switch {
+	case r.freq == "":
+		return nil, errors.New("missing frequency")
+	case r.count != 0 && !r.until.IsZero():
+		return nil, errors.New("count and until can't be used together")
+	case len(r.byDay) != 0 && r.freq != "WEEKLY":
+		return nil, errors.New("weekdays are supported only for the weekly frequency")
+	case len(r.byMonthDay) != 0 && r.freq != "MONTHLY":
+		return nil, errors.New("month days are supported only for the monthly frequency")
+	}
+
+	sort.Ints(r.byDay)
+	sort.Ints(r.byMonthDay)
+	return r, nil
+}
+
+func parseRRuleUntil(value string) (until time.Time, err error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405"} {
+		if until, err = time.Parse(layout, value); err == nil {
+			return
+		}
+	}
+
+	// A date without a time includes the whole day
+	if until, err = time.Parse("20060102", value); err == nil {
+		return until.Add(24*time.Hour - time.Nanosecond), nil
+	}
+	return time.Time{}, fmt.Errorf("incorrect until %q", value)
+}
+
+// Next returns the occurrence following the current one, occurrence is the number of the current occurrence in the
+// series starting from 0. False is returned when the series is over.
+func (r *RRule) Next(current time.Time, occurrence int) (time.Time, bool) {
+	if r.count != 0 && occurrence+1 >= r.count {
+		return time.Time{}, false
+	}
+
+	var next time.Time
+	switch r.freq {
+	case "DAILY":
+		next = current.AddDate(0, 0, r.interval)
+	case "WEEKLY":
+		next = r.nextWeekly(current)
+	case "MONTHLY":
+		var found bool
+		if next, found = r.nextMonthly(current); !found {
+			return time.Time{}, false
+		}
+	}
+
+	if !r.until.IsZero() && next.After(r.until) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+func (r *RRule) nextWeekly(current time.Time) time.Time {
+	if len(r.byDay) == 0 {
+		return current.AddDate(0, 0, 7*r.interval)
+	}
+
+	// Weeks start on monday
+	offset := (int(current.Weekday()) + 6) % 7
+	weekStart := current.AddDate(0, 0, -offset)
+	for _, day := range r.byDay {
+		if day > offset {
+			return weekStart.AddDate(0, 0, day)
+		}
+	}
+	return weekStart.AddDate(0, 0, 7*r.interval+r.byDay[0])
+}
+
+func (r *RRule) nextMonthly(current time.Time) (time.Time, bool) {
+	monthDays := r.byMonthDay
+	if len(monthDays) == 0 {
+		monthDays = []int{current.Day()}
+	}
+
+	// Months without a suitable day are skipped
+	firstDay := time.Date(current.Year(), current.Month(), 1, current.Hour(), current.Minute(), current.Second(), current.Nanosecond(), current.Location())
+	for step := 0; step < rruleMaxMonths; step++ {
+		month := firstDay.AddDate(0, step*r.interval, 0)
+		for _, day := range daysOfMonth(month, monthDays) {
+			if step == 0 && day <= current.Day() {
+				continue
+			}
+			return month.AddDate(0, 0, day-1), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// daysOfMonth converts month days into days of the given month, negative days are counted from the end of the month
+func daysOfMonth(month time.Time, monthDays []int) (days []int) {
+	daysInMonth := month.AddDate(0, 1, -1).Day()
+	for _, day := range monthDays {
+		if day < 0 {
+			day += daysInMonth + 1
+		}
+		if day >= 1 && day <= daysInMonth && indexOf(days, day) < 0 {
+			days = append(days, day)
+		}
+	}
+
+	sort.Ints(days)
+	return
+}
+
+func (r *RRule) String() string {
+	parts := []string{"FREQ=" + r.freq}
+	if r.interval != 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.interval))
+	}
+	if len(r.byDay) != 0 {
+		var days []string
+		for _, day := range r.byDay {
+			days = append(days, rruleWeekdays[day])
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.byMonthDay) != 0 {
+		var days []string
+		for _, day := range r.byMonthDay {
+			days = append(days, strconv.Itoa(day))
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.count != 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.count))
+	}
+	if !r.until.IsZero() {
+		parts = append(parts, "UNTIL="+r.until.UTC().Format("20060102T150405Z"))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+func indexOf[T comparable](values []T, value T) int {
+	for index, v := range values {
+		if v == value {
+			return index
+		}
+	}
+	return -1
+}
@@ -0,0 +1,19 @@
+package common
+
+import (
+	"strings"
+	"unicode"
+)
+
+// PrefixSearchQuery converts the search text into a tsquery where every word matches as a prefix. Only letters and
+// digits are kept, so the text can't break the tsquery syntax.
+func PrefixSearchQuery(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(char rune) bool {
+		return !unicode.IsLetter(char) && !unicode.IsDigit(char)
+	})
+
+	for index, word := range words {
+		words[index] = word + ":*"
+	}
+	return strings.Join(words, " & ")
+}
@@ -0,0 +1,118 @@
+package common
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	totpDigits = 6
+	totpPeriod = 30
+	totpSkew   = 1 // Number of neighbouring time steps that are still accepted
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func NewTOTPSecret() (string, error) {
+	secret := make([]byte, 20)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+func TOTPURI(secret, issuer, account string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(totpDigits))
+	query.Set("period", fmt.Sprint(totpPeriod))
+
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+func TOTPCode(secret string, t time.Time) (string, error) {
+	return hotpCode(secret, uint64(t.Unix()/totpPeriod))
+}
+
+// VerifyTOTP returns the time step of the code, the steps up to the last accepted one are skipped, so a code can't be
+// used twice
+func VerifyTOTP(secret, code string, lastStep int64) (int64, bool) {
+	if len(code) != totpDigits {
+		return 0, false
+	}
+
+	// Checking the code for the current time step and its neighbours
+	counter := time.Now().Unix() / totpPeriod
+	for step := counter - totpSkew; step <= counter+totpSkew; step++ {
+		if step <= lastStep {
+			continue
+		}
+
+		expected, err := hotpCode(secret, uint64(step))
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// hotpCode implements the HOTP algorithm from RFC 4226
+func hotpCode(secret string, counter uint64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+
+	message := make([]byte, 8)
+	binary.BigEndian.PutUint64(message, counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(message)
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		modulo *= 10
+	}
+
+	return fmt.Sprintf("%0*d", totpDigits, value%modulo), nil
+}
+
+func NewRecoveryCodes(count int) (codes []string, err error) {
+	for i := 0; i < count; i++ {
+		code := make([]byte, 5)
+		if _, err = rand.Read(code); err != nil {
+			return nil, err
+		}
+
+		encoded := strings.ToLower(hex.EncodeToString(code))
+		codes = append(codes, encoded[:5]+"-"+encoded[5:])
+	}
+
+	return
+}
+
+func HashRecoveryCode(code string) string {
+	return HashToken(strings.ToLower(strings.TrimSpace(code)))
+}
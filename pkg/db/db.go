@@ -2,22 +2,41 @@ package db
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/minio/minio-go/v7"
 
 	"github.com/NKTKLN/todo-api/models"
 )
 
+var ErrTooManyAttempts = errors.New("too many attempts")
+
 type PostgresDB interface {
 	UserOperations
+	WorkspaceOperations
+	WorkspaceMemberOperations
 	ListOperations
+	ListMemberOperations
 	TaskOperations
 	SubtaskOperations
+	TaskAssigneeOperations
+	TaskCommentOperations
+	PersonalTokenOperations
+	ReminderOperations
+	SavedFilterOperations
+	TagOperations
+	SearchOperations
 }
 
 type RedisClient interface {
 	EmailOperations
 	TokenOperations
+	SessionOperations
+	ChallengeOperations
+	LimitOperations
+	DisabledUserOperations
+	ReminderQueueOperations
 }
 
 type MinIOClient interface {
@@ -32,66 +51,210 @@ type MinIOClient interface {
 type UserOperations interface {
 	CrateUser(models.Users) (int, error)
 	GetUserByEmail(string) models.Users
+	GetUserByUsername(string) models.Users
 	CheckUserUsername(string) bool
 	CheckUserEmail(string) bool
 	GetUserById(int) models.Users
+	GetUsers(string, int, int) ([]models.Users, int64)
+	GetDisabledUserIds() []int
 	UpdateUser(models.Users, models.Users) error
 	UpdateUserPassword(string, string) error
 	UpdateUserIcon(int, string) error
+	UpdateUserDisabled(int, bool) error
+	UpdateUserCompletionRollup(int, bool) error
 	CheckUserPassword(string, string) error
+	UpdateUserTOTPSecret(int, string) error
+	EnableUserTOTP(int, []string) error
+	DisableUserTOTP(int) error
+	UseUserTOTPStep(int, int64) bool
+	UseUserRecoveryCode(int, string) bool
 	DeleteUser(MinIOClient, context.Context, models.Users) error
 }
 
+type WorkspaceOperations interface {
+	CreateWorkspace(models.Workspaces, int) error
+	GetUserWorkspaces(int) []models.WorkspacesData
+	UpdateWorkspace(models.Workspaces) error
+	DeleteWorkspace(int) error
+}
+
+type WorkspaceMemberOperations interface {
+	CreateWorkspaceMember(models.WorkspaceMembers) error
+	GetWorkspaceRole(int, int) string
+	GetWorkspaceMembers(int) []models.WorkspaceMemberData
+	UpdateWorkspaceMemberRole(models.WorkspaceMembers) error
+	DeleteWorkspaceMember(models.WorkspaceMembers) error
+	DeleteUserWorkspaceMembers(int) error
+}
+
 type ListOperations interface {
 	CreateList(models.Lists) error
 	GetAllUserLists(int) []models.ListsData
-	GetListsForEditIndex(int, int) []models.Lists
+	GetUserLists(int, string, []interface{}, int) ([]models.ListsData, []interface{})
+	GetListsForEditIndex(models.Lists) []models.Lists
 	GetListById(int) models.Lists
 	GetListByIdAndUserId(int, int) models.Lists
-	GetListMaxIndex(int) int
+	GetListMaxIndex(models.Lists) int
 	UpdateListData(models.Lists) error
 	UpdateListIndex(int, int) error
 	UpdateListsIndexes(models.Lists) error
 	DeleteList(int) error
 }
 
+type ListMemberOperations interface {
+	CreateListMember(models.ListMembers) error
+	GetListMember(int, int) models.ListMembers
+	GetListMembers(int) []models.ListMemberData
+	GetListMembersCount(int) int64
+	GetListRole(int, int) string
+	UpdateListMemberRole(models.ListMembers) error
+	DeleteListMember(models.ListMembers) error
+	DeleteListMembers(int) error
+	DeleteUserListMembers(int) error
+}
+
 type TaskOperations interface {
 	CreateTask(models.Tasks) error
+	CreateTaskOccurrence(models.Tasks, time.Time) error
 	GetAllTasks(int) []models.TasksData
+	GetTasks(int, models.TasksFilter, string, string, []interface{}, int) ([]models.TasksData, []interface{})
+	GetUserTasks(int, models.TasksFilter, []interface{}, int) ([]models.ViewTasksData, []interface{})
 	GetTaskById(int) models.Tasks
 	GetTasksForEditIndex(int, int) []models.Tasks
 	GetListIdWhereTask(int, int) int
 	GetTaskMaxIndex(int) int
 	UpdateTaskData(models.Tasks) error
+	UpdateTaskRecurrence(models.Tasks) error
 	UpdateTaskIndex(int, int) error
 	UpdateTasksIndexes(models.Tasks) error
+	MoveTask(models.Tasks) error
 	DeleteTask(int) error
 }
 
 type SubtaskOperations interface {
 	CreateSubtask(models.Tasks) error
 	GetAllSubtasks(int) []models.SubtasksData
+	GetSubtasks(int, models.TasksFilter, []interface{}, int) ([]models.SubtasksData, []interface{})
 	GetSubtasksForEditIndex(int, int) []models.Tasks
 	GetTaskIdWhereSubtask(int) int
+	GetRootTaskId(int) int
+	GetTaskTree(int) []models.TaskTreeData
 	GetSubtaskMaxIndex(int) int
 	UpdateSubtasksIndexes(models.Tasks) error
+	RollupTaskCompletion(int) error
 	DeleteSubtask(int) error
 }
 
+type TaskAssigneeOperations interface {
+	CreateTaskAssignee(models.TaskAssignees) error
+	GetTaskAssignee(int, int) models.TaskAssignees
+	GetTaskAssignees(int) []models.AssigneeData
+	GetAssignedTasks(int, models.TasksFilter, []interface{}, int) ([]models.ViewTasksData, []interface{})
+	DeleteTaskAssignee(models.TaskAssignees) error
+}
+
+type TaskCommentOperations interface {
+	CreateTaskComment(models.TaskComments) (int, error)
+	GetTaskCommentById(int) models.TaskComments
+	GetTaskComments(int, []interface{}, int) ([]models.CommentData, []interface{})
+	UpdateTaskComment(models.TaskComments) error
+	DeleteTaskComment(int) error
+}
+
+type SavedFilterOperations interface {
+	CreateSavedFilter(models.SavedFilters) error
+	GetSavedFilterByIdAndUserId(int, int) models.SavedFilters
+	GetUserSavedFilters(int, string, []interface{}, int) ([]models.SavedFilters, []interface{})
+	UpdateSavedFilter(models.SavedFilters) error
+	DeleteSavedFilter(int) error
+	DeleteUserSavedFilters(int) error
+}
+
+type TagOperations interface {
+	CreateTag(models.Tags) error
+	GetTagByIdAndUserId(int, int) models.Tags
+	GetTagByNameAndUserId(string, int) models.Tags
+	GetUserTags(int) []models.Tags
+	GetUserTagsUsage(int, string, int) []models.TagUsageData
+	UpdateTag(models.Tags, string) error
+	DeleteTag(models.Tags) error
+	DeleteUserTags(int) error
+}
+
+type SearchOperations interface {
+	Search(int, string, []interface{}, int) ([]models.SearchResultData, []interface{})
+}
+
+type PersonalTokenOperations interface {
+	CreatePersonalToken(models.PersonalTokens) (int, error)
+	GetPersonalTokenByHash(string) models.PersonalTokens
+	GetPersonalTokenByIdAndUserId(int, int) models.PersonalTokens
+	GetAllUserPersonalTokens(int) []models.PersonalTokens
+	DeletePersonalToken(int) error
+	DeleteAllUserPersonalTokens(int) error
+}
+
+type ReminderOperations interface {
+	CreateReminder(models.Reminders) (int, error)
+	GetReminderById(int) models.Reminders
+	GetReminderByIdAndUserId(int, int) models.Reminders
+	GetTaskReminders(int) []models.Reminders
+	GetTaskTreeReminders(int) []models.Reminders
+	GetUnsentReminders() []models.Reminders
+	UpdateReminderTime(int, time.Time) error
+	MarkReminderSent(int) (bool, error)
+	DeleteReminder(int) error
+	DeleteTaskReminders(int) error
+}
+
 // Redis operations
 type EmailOperations interface {
-	AddEmailData(context.Context, interface{}) (string, error)
-	GetEmailData(context.Context, string) (string, error)
-	GetUserData(context.Context, string) (models.Users, error)
-	DeleteEmailData(context.Context, string) error
+	AddEmailData(context.Context, string, interface{}) (string, error)
+	GetEmailData(context.Context, string, string) (string, error)
+	GetUserData(context.Context, string, string) (models.Users, error)
+	GetSignUpData(context.Context, string) (models.UserData, error)
+	AddListInvite(context.Context, models.ListInvites) (string, error)
+	GetListInvite(context.Context, string) (models.ListInvites, error)
+	CheckListInvite(context.Context, string, string) bool
 }
 
 type TokenOperations interface {
 	VerifyToken(context.Context, string) int
-	VerifyRefreshToken(context.Context, string) int
-	CreateTokens(context.Context, int) (string, string, error)
-	CheckTokens(context.Context, int) (string, string, error)
+	VerifySession(context.Context, string) models.Sessions
+	VerifyRefreshToken(context.Context, string) models.Sessions
+	CreateTokens(context.Context, models.Sessions) (string, string, error)
 	DeleteRefreshTokensData(context.Context, int) error
 	DeleteAccessTokensData(context.Context, int) error
 }
 
+type SessionOperations interface {
+	CreateSession(context.Context, models.Sessions) (string, string, error)
+	GetSession(context.Context, string) (models.Sessions, error)
+	GetUserSessions(context.Context, int) ([]models.Sessions, error)
+	DeleteSession(context.Context, int, string) error
+}
+
+type ChallengeOperations interface {
+	CreateChallenge(context.Context, models.Challenges) (string, error)
+	GetChallenge(context.Context, string) (models.Challenges, error)
+	DeleteChallenge(context.Context, string) error
+}
+
+type LimitOperations interface {
+	GetLimitLock(context.Context, string) (time.Duration, error)
+	HitLimit(context.Context, string, models.Limit) (time.Duration, error)
+	ResetLimit(context.Context, string) error
+}
+
+type DisabledUserOperations interface {
+	AddDisabledUser(context.Context, int) error
+	RemoveDisabledUser(context.Context, int) error
+	IsUserDisabled(context.Context, int) bool
+}
+
+type ReminderQueueOperations interface {
+	ScheduleReminder(context.Context, int, time.Time) error
+	RestoreReminder(context.Context, int, time.Time) error
+	ClaimReminders(context.Context, time.Time, time.Duration, int) ([]int, error)
+	UnscheduleReminder(context.Context, int) error
+}
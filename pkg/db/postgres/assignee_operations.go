@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/NKTKLN/todo-api/models"
+)
+
+// assignedRootsSql returns the lists of the tasks and subtasks assigned to the user, subtasks are walked up to their
+// top-level task
+const assignedRootsSql = `WITH RECURSIVE ancestors AS (SELECT id AS assigned_id, task_id, list_id FROM tasks WHERE id IN (SELECT task_id FROM task_assignees WHERE user_id = ?) UNION SELECT ancestors.assigned_id, tasks.task_id, tasks.list_id FROM tasks INNER JOIN ancestors ON tasks.id = ancestors.task_id) SELECT assigned_id AS id, list_id FROM ancestors WHERE task_id = 0`
+
+func (d *PDB) CreateTaskAssignee(model models.TaskAssignees) error {
+	return d.DB.Table("task_assignees").Create(&model).Error
+}
+
+func (d *PDB) GetTaskAssignee(taskId, userId int) (assigneeData models.TaskAssignees) {
+	d.DB.Table("task_assignees").Where("task_id = ? AND user_id = ?", taskId, userId).Take(&assigneeData)
+	return
+}
+
+func (d *PDB) GetTaskAssignees(taskId int) []models.AssigneeData {
+	return d.tasksAssignees([]int{taskId})[taskId]
+}
+
+// tasksAssignees returns the assignees of the tasks by one query, the keys are the task ids
+func (d *PDB) tasksAssignees(taskIds []int) map[int][]models.AssigneeData {
+	var rows []struct {
+		TaskId   int
+		Id       int
+		Username string
+	}
+	d.DB.Table("task_assignees").
+		Select("task_assignees.task_id, users.id, users.username").
+		Joins("INNER JOIN users ON users.id = task_assignees.user_id").
+		Where("task_assignees.task_id IN ?", taskIds).
+		Order("users.username").Scan(&rows)
+
+	assignees := make(map[int][]models.AssigneeData)
+	for _, row := range rows {
+		assignees[row.TaskId] = append(assignees[row.TaskId], models.AssigneeData{Id: row.Id, Username: row.Username})
+	}
+	return assignees
+}
+
+// GetAssignedTasks returns the filtered tasks and subtasks assigned to the user from all available lists sorted by
+// the end time like GetUserTasks and the key of the last task if there is a next page. A zero limit returns all tasks.
+func (d *PDB) GetAssignedTasks(userId int, filter models.TasksFilter, after []interface{}, limit int) ([]models.ViewTasksData, []interface{}) {
+	var tasks []struct {
+		models.Tasks
+		RootListId int
+		PageRow
+	}
+	keys := []string{dueKey, "roots.list_id", "index", "tasks.id"}
+	userLists := d.availableLists(d.DB.Table("lists").Select("id"), userId)
+	query := filterTasks(d.DB.Table("tasks").
+		Select("tasks.*, roots.list_id AS root_list_id, "+pageKeyColumn(keys)).
+		Joins("INNER JOIN (?) AS roots ON roots.id = tasks.id", gorm.Expr(assignedRootsSql, userId)).
+		Where("roots.list_id IN (?)", userLists), filter)
+	keysetPage(query, keys, false, after, limit).Scan(&tasks)
+	tasks, next := cutPage(tasks, limit)
+
+	// Subtasks are shown in the list of their top-level task
+	rootTasks := make([]models.Tasks, len(tasks))
+	for index, task := range tasks {
+		rootTasks[index] = task.Tasks
+		rootTasks[index].ListId = task.RootListId
+	}
+	return d.viewTasks(userId, rootTasks), next
+}
+
+func (d *PDB) DeleteTaskAssignee(model models.TaskAssignees) error {
+	return d.DB.Table("task_assignees").Where("task_id = ? AND user_id = ?", model.TaskId, model.UserId).Delete(&models.TaskAssignees{}).Error
+}
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/NKTKLN/todo-api/models"
+)
+
+func (d *PDB) CreateTaskComment(model models.TaskComments) (commentId int, err error) {
+	// Generating new data for the comment
+	commentId = int(uuid.New().ID())
+	for !d.checkTaskCommentId(commentId) {
+		commentId = int(uuid.New().ID())
+	}
+
+	// Creating new comment
+	err = d.DB.Table("task_comments").Create(&models.TaskComments{
+		Id:        commentId,
+		TaskId:    model.TaskId,
+		UserId:    model.UserId,
+		Text:      model.Text,
+		CreatedAt: model.CreatedAt,
+	}).Error
+	return
+}
+
+func (d *PDB) checkTaskCommentId(id int) bool {
+	var commentData models.TaskComments
+	result := d.DB.Table("task_comments").Where("id = ?", id).Take(&commentData).Error
+	return errors.Is(result, gorm.ErrRecordNotFound)
+}
+
+func (d *PDB) GetTaskCommentById(id int) (commentData models.TaskComments) {
+	d.DB.Table("task_comments").Where("id = ?", id).Take(&commentData)
+	return
+}
+
+// GetTaskComments returns the comments of the task from the oldest one and the key of the last comment if there is a
+// next page. The comments of the deleted users stay in the discussion without the username.
+func (d *PDB) GetTaskComments(taskId int, after []interface{}, limit int) ([]models.CommentData, []interface{}) {
+	var comments []struct {
+		models.TaskComments
+		Username string
+		PageRow
+	}
+	keys := []string{"task_comments.created_at", "task_comments.id"}
+	query := d.DB.Table("task_comments").
+		Select("task_comments.*, users.username, "+pageKeyColumn(keys)).
+		Joins("LEFT JOIN users ON users.id = task_comments.user_id").
+		Where("task_comments.task_id = ?", taskId)
+	keysetPage(query, keys, false, after, limit).Scan(&comments)
+	comments, next := cutPage(comments, limit)
+
+	commentsData := make([]models.CommentData, len(comments))
+	for index, comment := range comments {
+		commentsData[index] = models.CommentData{
+			Id:        comment.Id,
+			UserId:    comment.UserId,
+			Username:  comment.Username,
+			Text:      comment.Text,
+			CreatedAt: comment.CreatedAt.UTC().Format("2006-01-02 15:04"),
+		}
+		if comment.EditedAt != nil {
+			commentsData[index].EditedAt = comment.EditedAt.UTC().Format("2006-01-02 15:04")
+		}
+	}
+	return commentsData, next
+}
+
+func (d *PDB) UpdateTaskComment(model models.TaskComments) error {
+	return d.DB.Table("task_comments").Where("id = ?", model.Id).Updates(map[string]interface{}{"text": model.Text, "edited_at": model.EditedAt}).Error
+}
+
+func (d *PDB) DeleteTaskComment(id int) error {
+	return d.DB.Table("task_comments").Delete(&models.TaskComments{}, id).Error
+}
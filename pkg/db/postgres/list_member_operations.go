@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/NKTKLN/todo-api/models"
+)
+
+// The owner is not stored in the members, so the owner row is taken from the list. The members of the workspace are
+// the members of its lists.
+const listMembersSql = `SELECT users.id, users.username, users.name, ? AS role, 0 AS position FROM lists INNER JOIN users ON users.id = lists.user_id WHERE lists.id = ?
+UNION ALL
+SELECT users.id, users.username, users.name, list_access.role, 1 FROM list_access INNER JOIN users ON users.id = list_access.user_id WHERE list_access.list_id = ?
+ORDER BY position, username`
+
+func (d *PDB) CreateListMember(model models.ListMembers) error {
+	return d.DB.Table("list_members").Create(&model).Error
+}
+
+func (d *PDB) GetListMember(listId, userId int) (memberData models.ListMembers) {
+	d.DB.Table("list_members").Where("list_id = ? AND user_id = ?", listId, userId).Take(&memberData)
+	return
+}
+
+// GetListMembers returns the list owner and members
+func (d *PDB) GetListMembers(listId int) (membersData []models.ListMemberData) {
+	d.DB.Raw(listMembersSql, models.LIST_OWNER_ROLE, listId, listId).Scan(&membersData)
+	return
+}
+
+// GetListMembersCount returns the number of the list members, the owner and the members of the workspace are not
+// counted
+func (d *PDB) GetListMembersCount(listId int) (count int64) {
+	d.DB.Table("list_members").Where("list_id = ?", listId).Count(&count)
+	return
+}
+
+// GetListRole returns the role of the user in the list, an empty role means that the user has no access to the list.
+// Admins of the workspace are the owners of its lists. A member of the workspace can also be a member of its list, the
+// highest of the roles is taken.
+func (d *PDB) GetListRole(listId, userId int) (role string) {
+	d.DB.Table("lists").
+		Select("CASE WHEN lists.user_id = ? THEN ? ELSE coalesce(list_access.role, '') END", userId, models.LIST_OWNER_ROLE).
+		Joins("LEFT JOIN list_access ON list_access.list_id = lists.id AND list_access.user_id = ?", userId).
+		Where("lists.id = ?", listId).
+		Order(fmt.Sprintf("array_position(ARRAY['%s'], list_access.role) DESC NULLS LAST", strings.Join(models.LIST_ROLES, "','"))).
+		Take(&role)
+	return
+}
+
+func (d *PDB) UpdateListMemberRole(model models.ListMembers) error {
+	return d.DB.Table("list_members").Where("list_id = ? AND user_id = ?", model.ListId, model.UserId).Update("role", model.Role).Error
+}
+
+// DeleteListMember deletes the member together with their reminders and assignments in the list tasks
+func (d *PDB) DeleteListMember(model models.ListMembers) error {
+	return d.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Table("reminders").Where("user_id = ? AND task_id IN (?)", model.UserId, gorm.Expr(listTaskIdsSql, model.ListId)).Delete(&models.Reminders{}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Table("task_assignees").Where("user_id = ? AND task_id IN (?)", model.UserId, gorm.Expr(listTaskIdsSql, model.ListId)).Delete(&models.TaskAssignees{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Table("list_members").Where("list_id = ? AND user_id = ?", model.ListId, model.UserId).Delete(&models.ListMembers{}).Error
+	})
+}
+
+func (d *PDB) DeleteListMembers(listId int) error {
+	return d.DB.Table("list_members").Where("list_id = ?", listId).Delete(&models.ListMembers{}).Error
+}
+
+// DeleteUserListMembers deletes the user from all shared lists. The lists of the user are deleted before, so all
+// remaining reminders and assignments of the user are in the shared lists.
+func (d *PDB) DeleteUserListMembers(userId int) error {
+	return d.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("reminders").Where("user_id = ?", userId).Delete(&models.Reminders{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Table("task_assignees").Where("user_id = ?", userId).Delete(&models.TaskAssignees{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Table("list_members").Where("user_id = ?", userId).Delete(&models.ListMembers{}).Error
+	})
+}
@@ -17,12 +17,22 @@ func (d *PDB) CreateList(model models.Lists) error {
 	}
 
 	var index int
-	if len(d.GetAllUserLists(model.UserId)) > 0 {
-		index = d.GetListMaxIndex(model.UserId) + 1
+	var lists []models.Lists
+	if d.listsScope(d.DB.Table("lists"), model).Order("index").Find(&lists); len(lists) > 0 {
+		index = d.GetListMaxIndex(model) + 1
 	}
 
 	// Creating new list
-	return d.DB.Table("lists").Create(&models.Lists{Id: listId, UserId: model.UserId, Name: model.Name, Comment: model.Comment, Index: index}).Error
+	return d.DB.Table("lists").Create(&models.Lists{Id: listId, UserId: model.UserId, WorkspaceId: model.WorkspaceId, Name: model.Name, Comment: model.Comment, Index: index}).Error
+}
+
+// listsScope limits the lists query to the lists among which the list is sorted, the lists of the workspace or the
+// personal lists of the owner
+func (d *PDB) listsScope(query *gorm.DB, model models.Lists) *gorm.DB {
+	if model.WorkspaceId != 0 {
+		return query.Where("workspace_id = ?", model.WorkspaceId)
+	}
+	return query.Where("user_id = ?", model.UserId)
 }
 
 func (d *PDB) checkListId(id int) bool {
@@ -31,16 +41,56 @@ func (d *PDB) checkListId(id int) bool {
 	return errors.Is(result, gorm.ErrRecordNotFound)
 }
 
+// GetAllUserLists returns the lists owned by the user, the workspace lists are not owned by users
 func (d *PDB) GetAllUserLists(userId int) (listsData []models.ListsData) {
 	d.DB.Table("lists").Where("user_id = ?", userId).Order("index").Find(&listsData)
-	if len(listsData) == 0 {
-		return nil
+	for index := range listsData {
+		listsData[index].Type = models.LIST_TYPE
 	}
 	return
 }
 
-func (d *PDB) GetListsForEditIndex(userId, listIndex int) (listData []models.Lists) {
-	d.DB.Table("lists").Where("user_id = ? AND index > ?", userId, listIndex).Find(&listData)
+// GetUserLists returns the user lists with the search in the name and comment and the key of the last list if there is
+// a next page, a zero limit returns all lists. Lists shared with the user go after the own lists, the workspace lists
+// go last grouped by the workspace.
+func (d *PDB) GetUserLists(userId int, search string, after []interface{}, limit int) ([]models.ListsData, []interface{}) {
+	keys := []string{"workspaces.name IS NOT NULL", "coalesce(workspaces.name, '')", "lists.workspace_id", "list_access.user_id IS NOT NULL", "lists.index", "lists.id"}
+	query := d.DB.Table("lists").
+		Select("lists.*, coalesce(list_access.role, ?) AS role, coalesce(workspaces.name, '') AS workspace, "+pageKeyColumn(keys), models.LIST_OWNER_ROLE).
+		Joins("LEFT JOIN list_access ON list_access.list_id = lists.id AND list_access.user_id = ?", userId).
+		Joins("LEFT JOIN workspaces ON workspaces.id = lists.workspace_id").
+		Where("lists.user_id = ? OR list_access.user_id IS NOT NULL", userId)
+	if search != "" {
+		pattern := "%" + likeEscaper.Replace(search) + "%"
+		query = query.Where("lists.name ILIKE ? OR lists.comment ILIKE ?", pattern, pattern)
+	}
+
+	var lists []struct {
+		models.ListsData
+		PageRow
+	}
+	keysetPage(query, keys, false, after, limit).Scan(&lists)
+	lists, next := cutPage(lists, limit)
+	if len(lists) == 0 {
+		return nil, nil
+	}
+
+	listsData := make([]models.ListsData, len(lists))
+	for index, list := range lists {
+		listsData[index] = list.ListsData
+		listsData[index].Type = models.LIST_TYPE
+	}
+	return listsData, next
+}
+
+// availableLists limits the lists query to the lists owned by the user, shared with them or in their workspaces
+func (d *PDB) availableLists(query *gorm.DB, userId int) *gorm.DB {
+	return query.Where("lists.user_id = ? OR lists.id IN (?)", userId, d.DB.Table("list_access").Select("list_id").Where("user_id = ?", userId))
+}
+
+// GetListsForEditIndex returns the lists placed after the list among the lists of its owner or workspace
+func (d *PDB) GetListsForEditIndex(model models.Lists) (listData []models.Lists) {
+	d.listsScope(d.DB.Table("lists"), model).Where("index > ?", model.Index).Find(&listData)
 	return
 }
 
@@ -49,13 +99,14 @@ func (d *PDB) GetListById(id int) (listData models.Lists) {
 	return
 }
 
+// GetListByIdAndUserId returns the list if the user owns it or is its member
 func (d *PDB) GetListByIdAndUserId(id, userId int) (listData models.Lists) {
-	d.DB.Table("lists").Where("id = ? AND user_id = ?", id, userId).Take(&listData)
+	d.availableLists(d.DB.Table("lists").Where("id = ?", id), userId).Take(&listData)
 	return
 }
 
-func (d *PDB) GetListMaxIndex(userId int) (index int) {
-	d.DB.Table("lists").Select("max(index)").Where("user_id = ?", userId).Take(&index)
+func (d *PDB) GetListMaxIndex(model models.Lists) (index int) {
+	d.listsScope(d.DB.Table("lists").Select("max(index)"), model).Take(&index)
 	return
 }
 
@@ -74,9 +125,9 @@ func (d *PDB) UpdateListsIndexes(model models.Lists) (err error) {
 	// Obtaining lists for the update
 	var userLists []models.Lists
 	if listIndex > model.Index {
-		d.DB.Table("lists").Where("user_id = ? AND index >= ? AND index < ?", model.UserId, model.Index, listIndex).Find(&userLists)
+		d.listsScope(d.DB.Table("lists"), model).Where("index >= ?", model.Index).Where("index < ?", listIndex).Find(&userLists)
 	} else {
-		d.DB.Table("lists").Where("user_id = ? AND index <= ? AND index > ?", model.UserId, model.Index, listIndex).Find(&userLists)
+		d.listsScope(d.DB.Table("lists"), model).Where("index <= ?", model.Index).Where("index > ?", listIndex).Find(&userLists)
 		step *= -1
 	}
 
@@ -98,6 +149,11 @@ func (d *PDB) DeleteList(id int) error {
 		}
 	}
 
+	// Deleting list members
+	if err := d.DeleteListMembers(id); err != nil {
+		return err
+	}
+
 	// Deleting list
 	return d.DB.Delete(&models.Lists{}, id).Error
 }
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/NKTKLN/todo-api/models"
+)
+
+func (d *PDB) CreatePersonalToken(model models.PersonalTokens) (tokenId int, err error) {
+	// Generating new data for the token
+	tokenId = int(uuid.New().ID())
+	for !d.checkPersonalTokenId(tokenId) {
+		tokenId = int(uuid.New().ID())
+	}
+
+	// Creating new token
+	err = d.DB.Table("personal_tokens").Create(&models.PersonalTokens{
+		Id:        tokenId,
+		UserId:    model.UserId,
+		Name:      model.Name,
+		TokenHash: model.TokenHash,
+		Scopes:    model.Scopes,
+		ExpiresAt: model.ExpiresAt,
+		CreatedAt: model.CreatedAt,
+	}).Error
+	return
+}
+
+func (d *PDB) checkPersonalTokenId(id int) bool {
+	var tokenData models.PersonalTokens
+	result := d.DB.Table("personal_tokens").Where("id = ?", id).Take(&tokenData).Error
+	return errors.Is(result, gorm.ErrRecordNotFound)
+}
+
+func (d *PDB) GetPersonalTokenByHash(tokenHash string) (tokenData models.PersonalTokens) {
+	d.DB.Table("personal_tokens").Where("token_hash = ?", tokenHash).Take(&tokenData)
+	return
+}
+
+func (d *PDB) GetPersonalTokenByIdAndUserId(id, userId int) (tokenData models.PersonalTokens) {
+	d.DB.Table("personal_tokens").Where("id = ? AND user_id = ?", id, userId).Take(&tokenData)
+	return
+}
+
+func (d *PDB) GetAllUserPersonalTokens(userId int) (tokensData []models.PersonalTokens) {
+	d.DB.Table("personal_tokens").Where("user_id = ?", userId).Order("created_at").Find(&tokensData)
+	return
+}
+
+func (d *PDB) DeletePersonalToken(id int) error {
+	return d.DB.Table("personal_tokens").Delete(&models.PersonalTokens{}, id).Error
+}
+
+func (d *PDB) DeleteAllUserPersonalTokens(userId int) error {
+	return d.DB.Table("personal_tokens").Where("user_id = ?", userId).Delete(&models.PersonalTokens{}).Error
+}
@@ -1,7 +1,9 @@
 package postgres
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -32,3 +34,68 @@ func Connect(postgresHost, postgresUser, postgresPassword, postgresDBName string
 
 	return &PDB{DB: db}, nil
 }
+
+// pageKeyColumn selects the sort key of the row to start the next page after it
+func pageKeyColumn(keys []string) string {
+	return "json_build_array(" + strings.Join(keys, ", ") + ") AS page_key"
+}
+
+// keysetPage orders the rows by the key and returns the page after the key of the previous page. All parts of the
+// key are sorted in one direction and the last one is unique, so the rows are compared as a whole with
+// (key, id) > (?, ?). A zero limit returns all rows, otherwise one more row is requested to find out whether there is
+// a next page.
+func keysetPage(query *gorm.DB, keys []string, desc bool, after []interface{}, limit int) *gorm.DB {
+	direction, operator := "", ">"
+	if desc {
+		direction, operator = " DESC", "<"
+	}
+
+	switch {
+	case len(after) == 0:
+	case len(after) != len(keys):
+		// The cursor of another sort mode
+		query = query.Where("FALSE")
+	default:
+		// Numbers are passed as text to keep the precision, postgres converts them to the type of the key
+		values := make([]interface{}, len(after))
+		for index, value := range after {
+			if number, ok := value.(json.Number); ok {
+				value = number.String()
+			}
+			values[index] = value
+		}
+		query = query.Where("("+strings.Join(keys, ", ")+") "+operator+" ?", values)
+	}
+
+	for _, key := range keys {
+		query = query.Order(key + direction)
+	}
+	if limit > 0 {
+		query = query.Limit(limit + 1)
+	}
+	return query
+}
+
+// PageRow is embedded into the scanned rows to get the key selected by pageKeyColumn
+type PageRow struct {
+	PageKey string
+}
+
+func (row PageRow) pageKey() string {
+	return row.PageKey
+}
+
+// cutPage cuts the extra row of the page and returns the key of the last row if there is a next page
+func cutPage[T interface{ pageKey() string }](rows []T, limit int) ([]T, []interface{}) {
+	if limit == 0 || len(rows) <= limit {
+		return rows, nil
+	}
+
+	var key []interface{}
+	decoder := json.NewDecoder(strings.NewReader(rows[limit-1].pageKey()))
+	decoder.UseNumber()
+	if decoder.Decode(&key) != nil {
+		return rows[:limit], nil
+	}
+	return rows[:limit], key
+}
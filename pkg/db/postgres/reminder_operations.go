@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/NKTKLN/todo-api/models"
+)
+
+func (d *PDB) CreateReminder(model models.Reminders) (reminderId int, err error) {
+	// Generating new data for the reminder
+	reminderId = int(uuid.New().ID())
+	for !d.checkReminderId(reminderId) {
+		reminderId = int(uuid.New().ID())
+	}
+
+	// Creating new reminder
+	err = d.DB.Table("reminders").Create(&models.Reminders{
+		Id:       reminderId,
+		TaskId:   model.TaskId,
+		UserId:   model.UserId,
+		Before:   model.Before,
+		RemindAt: model.RemindAt,
+	}).Error
+	return
+}
+
+func (d *PDB) checkReminderId(id int) bool {
+	var reminderData models.Reminders
+	result := d.DB.Table("reminders").Where("id = ?", id).Take(&reminderData).Error
+	return errors.Is(result, gorm.ErrRecordNotFound)
+}
+
+func (d *PDB) GetReminderById(id int) (reminderData models.Reminders) {
+	d.DB.Table("reminders").Where("id = ?", id).Take(&reminderData)
+	return
+}
+
+func (d *PDB) GetReminderByIdAndUserId(id, userId int) (reminderData models.Reminders) {
+	d.DB.Table("reminders").Where("id = ? AND user_id = ?", id, userId).Take(&reminderData)
+	return
+}
+
+func (d *PDB) GetTaskReminders(taskId int) (remindersData []models.Reminders) {
+	d.DB.Table("reminders").Where("task_id = ?", taskId).Order("remind_at").Find(&remindersData)
+	return
+}
+
+// GetTaskTreeReminders returns the reminders of the task and all levels of its subtasks
+func (d *PDB) GetTaskTreeReminders(taskId int) (remindersData []models.Reminders) {
+	d.DB.Table("reminders").Where("task_id IN (?)", gorm.Expr(subtreeTasksSql, taskId)).Find(&remindersData)
+	return
+}
+
+func (d *PDB) GetUnsentReminders() (remindersData []models.Reminders) {
+	d.DB.Table("reminders").Where("sent = ?", false).Find(&remindersData)
+	return
+}
+
+// UpdateReminderTime moves the reminder to the new time, the moved reminder is sent again
+func (d *PDB) UpdateReminderTime(id int, remindAt time.Time) error {
+	return d.DB.Table("reminders").Where("id = ?", id).Updates(map[string]interface{}{"remind_at": remindAt, "sent": false}).Error
+}
+
+// MarkReminderSent returns false if the reminder has already been marked by someone else
+func (d *PDB) MarkReminderSent(id int) (bool, error) {
+	result := d.DB.Table("reminders").Where("id = ? AND sent = ?", id, false).Update("sent", true)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (d *PDB) DeleteReminder(id int) error {
+	return d.DB.Table("reminders").Delete(&models.Reminders{}, id).Error
+}
+
+func (d *PDB) DeleteTaskReminders(taskId int) error {
+	return d.DB.Table("reminders").Where("task_id = ?", taskId).Delete(&models.Reminders{}).Error
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/NKTKLN/todo-api/models"
+)
+
+func (d *PDB) CreateSavedFilter(model models.SavedFilters) error {
+	// Generating new data for the filter
+	filterId := int(uuid.New().ID())
+	for !d.checkSavedFilterId(filterId) {
+		filterId = int(uuid.New().ID())
+	}
+
+	// Creating new filter
+	return d.DB.Table("saved_filters").Create(&models.SavedFilters{Id: filterId, UserId: model.UserId, Name: model.Name, Query: model.Query}).Error
+}
+
+func (d *PDB) checkSavedFilterId(id int) bool {
+	var filterData models.SavedFilters
+	result := d.DB.Table("saved_filters").Where("id = ?", id).Take(&filterData).Error
+	return errors.Is(result, gorm.ErrRecordNotFound)
+}
+
+func (d *PDB) GetSavedFilterByIdAndUserId(id, userId int) (filterData models.SavedFilters) {
+	d.DB.Table("saved_filters").Where("id = ? AND user_id = ?", id, userId).Take(&filterData)
+	return
+}
+
+// GetUserSavedFilters returns the user filters with the search in the name and the key of the last filter if there is
+// a next page, a zero limit returns all filters
+func (d *PDB) GetUserSavedFilters(userId int, search string, after []interface{}, limit int) ([]models.SavedFilters, []interface{}) {
+	keys := []string{"name", "id"}
+	query := d.DB.Table("saved_filters").Select("saved_filters.*, "+pageKeyColumn(keys)).Where("user_id = ?", userId)
+	if search != "" {
+		query = query.Where("name ILIKE ?", "%"+likeEscaper.Replace(search)+"%")
+	}
+
+	var filters []struct {
+		models.SavedFilters
+		PageRow
+	}
+	keysetPage(query, keys, false, after, limit).Scan(&filters)
+	filters, next := cutPage(filters, limit)
+
+	filtersData := make([]models.SavedFilters, len(filters))
+	for index, filter := range filters {
+		filtersData[index] = filter.SavedFilters
+	}
+	return filtersData, next
+}
+
+func (d *PDB) UpdateSavedFilter(model models.SavedFilters) error {
+	return d.DB.Table("saved_filters").Select("name", "query").Updates(model).Error
+}
+
+func (d *PDB) DeleteSavedFilter(id int) error {
+	return d.DB.Table("saved_filters").Delete(&models.SavedFilters{}, id).Error
+}
+
+func (d *PDB) DeleteUserSavedFilters(userId int) error {
+	return d.DB.Table("saved_filters").Where("user_id = ?", userId).Delete(&models.SavedFilters{}).Error
+}
@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"gorm.io/gorm/clause"
+
+	"github.com/NKTKLN/todo-api/models"
+)
+
+// Lists, tasks and subtasks of the user are found through the lists they belong to, shared and workspace lists
+// included. Subtasks of any depth are collected by walking the task trees of the lists like listTaskIdsSql.
+const searchSql = `WITH RECURSIVE search AS (SELECT to_tsquery('simple', @query) AS query),
+user_lists AS (SELECT id FROM lists WHERE user_id = @user OR id IN (SELECT list_id FROM list_access WHERE user_id = @user)),
+tree AS (SELECT id, list_id AS root_list_id FROM tasks WHERE list_id IN (SELECT id FROM user_lists) UNION SELECT tasks.id, tree.root_list_id FROM tasks INNER JOIN tree ON tasks.task_id = tree.id)
+SELECT 'list' AS type, lists.id, lists.id AS list_id, 0 AS task_id, lists.name,
+	ts_headline('simple', lists.name || ' ' || lists.comment, search.query, @options) AS snippet,
+	ts_rank(lists.search, search.query) AS rank
+FROM lists CROSS JOIN search
+WHERE lists.id IN (SELECT id FROM user_lists) AND lists.search @@ search.query
+UNION ALL
+SELECT CASE WHEN tasks.task_id = 0 THEN 'task' ELSE 'subtask' END, tasks.id, tree.root_list_id, tasks.task_id, tasks.name,
+	ts_headline('simple', tasks.name || ' ' || tasks.comment, search.query, @options),
+	ts_rank(tasks.search, search.query)
+FROM tree INNER JOIN tasks ON tasks.id = tree.id CROSS JOIN search
+WHERE tasks.search @@ search.query`
+
+// Search returns the lists, tasks and subtasks of the user matching the tsquery and the key of the last result if there
+// is a next page, the most relevant go first. Lists and tasks can have the same id, so the type is a part of the key.
+func (d *PDB) Search(userId int, query string, after []interface{}, limit int) ([]models.SearchResultData, []interface{}) {
+	var results []struct {
+		models.SearchResultData
+		PageRow
+	}
+	keys := []string{"rank", "type", "id"}
+	search := d.DB.Table("(?) AS results", clause.NamedExpr{SQL: searchSql, Vars: []interface{}{map[string]interface{}{
+		"query":   query,
+		"options": models.SEARCH_HEADLINE_OPTIONS,
+		"user":    userId,
+	}}}).Select("*, " + pageKeyColumn(keys))
+	keysetPage(search, keys, true, after, limit).Scan(&results)
+	results, next := cutPage(results, limit)
+
+	resultsData := make([]models.SearchResultData, len(results))
+	for index, result := range results {
+		resultsData[index] = result.SearchResultData
+	}
+	return resultsData, next
+}
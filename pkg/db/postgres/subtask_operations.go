@@ -3,10 +3,21 @@ package postgres
 import (
 	"github.com/google/uuid"
 	"github.com/jinzhu/copier"
+	"gorm.io/gorm"
 
 	"github.com/NKTKLN/todo-api/models"
 )
 
+// Subtasks can be nested at any depth, the trees are walked with recursive queries. UNION stops the walk if the
+// tree is somehow looped.
+const (
+	ancestorTasksSql = `WITH RECURSIVE ancestors AS (SELECT id, task_id FROM tasks WHERE id = ? UNION SELECT tasks.id, tasks.task_id FROM tasks INNER JOIN ancestors ON tasks.id = ancestors.task_id) SELECT id FROM ancestors WHERE task_id = 0 LIMIT 1`
+	subtreeTasksSql  = `WITH RECURSIVE subtree AS (SELECT id FROM tasks WHERE id = ? UNION SELECT tasks.id FROM tasks INNER JOIN subtree ON tasks.task_id = subtree.id) SELECT id FROM subtree`
+	listTasksTreeSql = `WITH RECURSIVE tree AS (SELECT * FROM tasks WHERE list_id = ? UNION ALL SELECT tasks.* FROM tasks INNER JOIN tree ON tasks.task_id = tree.id) SELECT * FROM tree ORDER BY index`
+	listTaskIdsSql   = `WITH RECURSIVE tree AS (SELECT id FROM tasks WHERE list_id = ? UNION SELECT tasks.id FROM tasks INNER JOIN tree ON tasks.task_id = tree.id) SELECT id FROM tree`
+	listsTaskIdsSql  = `WITH RECURSIVE tree AS (SELECT id FROM tasks WHERE list_id IN (?) UNION SELECT tasks.id FROM tasks INNER JOIN tree ON tasks.task_id = tree.id) SELECT id FROM tree`
+)
+
 func (d *PDB) CreateSubtask(model models.Tasks) error {
 	// Generating new data for the subtask
 	subtaskId := int(uuid.New().ID())
@@ -19,19 +30,54 @@ func (d *PDB) CreateSubtask(model models.Tasks) error {
 	}
 
 	// Creating new subtask
-	return d.DB.Table("tasks").Create(&models.Tasks{Id: subtaskId, TaskId: model.TaskId, Name: model.Name, Comment: model.Comment, Index: index}).Error
+	return d.DB.Table("tasks").Create(&models.Tasks{Id: subtaskId, TaskId: model.TaskId, Name: model.Name, Comment: model.Comment, Index: index, Priority: models.TASK_PRIORITIES[0]}).Error
+}
+
+func (d *PDB) GetAllSubtasks(taskId int) []models.SubtasksData {
+	subtasksData, _ := d.taskSubtasks(taskId, models.TasksFilter{}, nil, 0)
+	return subtasksData
 }
 
-func (d *PDB) GetAllSubtasks(taskId int) (subTasksData []models.SubtasksData) {
+// GetSubtasks returns the filtered task subtasks with their assignees and the key of the last subtask if there is a
+// next page, a zero limit returns all subtasks
+func (d *PDB) GetSubtasks(taskId int, filter models.TasksFilter, after []interface{}, limit int) ([]models.SubtasksData, []interface{}) {
+	subtasksData, next := d.taskSubtasks(taskId, filter, after, limit)
+	if len(subtasksData) == 0 {
+		return subtasksData, next
+	}
+
+	subtaskIds := make([]int, len(subtasksData))
+	for index, subtask := range subtasksData {
+		subtaskIds[index] = subtask.Id
+	}
+	assignees := d.tasksAssignees(subtaskIds)
+	for index, subtask := range subtasksData {
+		subtasksData[index].Assignees = assignees[subtask.Id]
+	}
+	return subtasksData, next
+}
+
+func (d *PDB) taskSubtasks(taskId int, filter models.TasksFilter, after []interface{}, limit int) (subTasksData []models.SubtasksData, next []interface{}) {
+	var rows []struct {
+		models.Tasks
+		PageRow
+	}
+	keys := []string{"index", "id"}
+	query := filterTasks(d.DB.Table("tasks").Select("tasks.*, "+pageKeyColumn(keys)).Where("task_id = ?", taskId), filter)
+	keysetPage(query, keys, false, after, limit).Scan(&rows)
+	rows, next = cutPage(rows, limit)
+
 	var subtasks []models.Tasks
-	d.DB.Table("tasks").Where("task_id = ?", taskId).Order("index").Find(&subtasks)
-	
+	for _, row := range rows {
+		subtasks = append(subtasks, row.Tasks)
+	}
+
 	if copier.Copy(&subTasksData, &subtasks) != nil {
 		return
 	}
 
 	for index, task := range subtasks {
-		subTasksData[index].EndTime = task.EndTime.Format("2006-01-02 15:04")
+		subTasksData[index].EndTime = task.EndTime.UTC().Format("2006-01-02 15:04")
 	}
 	return
 }
@@ -46,6 +92,44 @@ func (d *PDB) GetTaskIdWhereSubtask(subtaskId int) (taskId int) {
 	return
 }
 
+// GetRootTaskId returns the top-level task of the subtask at any depth, the top-level task is its own root
+func (d *PDB) GetRootTaskId(taskId int) (rootId int) {
+	d.DB.Raw(ancestorTasksSql, taskId).Scan(&rootId)
+	return
+}
+
+// GetTaskTree returns the list tasks with all levels of their subtasks
+func (d *PDB) GetTaskTree(listId int) []models.TaskTreeData {
+	var tasks []models.Tasks
+	d.DB.Raw(listTasksTreeSql, listId).Scan(&tasks)
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	// The tasks are sorted by the index, so the subtasks keep their order
+	children := make(map[int][]models.Tasks)
+	taskIds := make([]int, len(tasks))
+	for index, task := range tasks {
+		children[task.TaskId] = append(children[task.TaskId], task)
+		taskIds[index] = task.Id
+	}
+	return taskTree(children, d.tasksAssignees(taskIds), 0)
+}
+
+func taskTree(children map[int][]models.Tasks, assignees map[int][]models.AssigneeData, taskId int) (tree []models.TaskTreeData) {
+	for _, task := range children[taskId] {
+		var taskData models.TasksData
+		if copier.Copy(&taskData, &task) != nil {
+			return nil
+		}
+		taskData.EndTime = task.EndTime.UTC().Format("2006-01-02 15:04")
+		taskData.Assignees = assignees[task.Id]
+
+		tree = append(tree, models.TaskTreeData{TasksData: taskData, Subtasks: taskTree(children, assignees, task.Id)})
+	}
+	return
+}
+
 func (d *PDB) GetSubtaskMaxIndex(taskId int) (index int) {
 	d.DB.Table("tasks").Select("max(index)").Where("task_id = ?", taskId).Take(&index)
 	return
@@ -78,6 +162,43 @@ func (d *PDB) UpdateSubtasksIndexes(model models.Tasks) (err error) {
 	return
 }
 
+// RollupTaskCompletion marks the ancestors of the subtask done while all their subtasks are done. Recurring tasks
+// are completed only by the user, so their next occurrence is created.
+func (d *PDB) RollupTaskCompletion(subtaskId int) error {
+	for taskId := d.GetTaskIdWhereSubtask(subtaskId); taskId != 0; taskId = d.GetTaskIdWhereSubtask(taskId) {
+		var undone int64
+		d.DB.Table("tasks").Where("task_id = ? AND done = ?", taskId, false).Count(&undone)
+		if undone != 0 {
+			return nil
+		}
+
+		result := d.DB.Table("tasks").Where("id = ? AND recurrence = ?", taskId, "").Update("done", true)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+	}
+	return nil
+}
+
+// DeleteSubtask deletes the subtask together with all levels of its subtasks and their reminders, assignees and
+// comments
 func (d *PDB) DeleteSubtask(id int) error {
-	return d.DB.Table("tasks").Delete(&models.Tasks{}, id).Error
+	return d.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Table("reminders").Where("task_id IN (?)", gorm.Expr(subtreeTasksSql, id)).Delete(&models.Reminders{}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Table("task_assignees").Where("task_id IN (?)", gorm.Expr(subtreeTasksSql, id)).Delete(&models.TaskAssignees{}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Table("task_comments").Where("task_id IN (?)", gorm.Expr(subtreeTasksSql, id)).Delete(&models.TaskComments{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Table("tasks").Where("id IN (?)", gorm.Expr(subtreeTasksSql, id)).Delete(&models.Tasks{}).Error
+	})
 }
@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/NKTKLN/todo-api/models"
+)
+
+func (d *PDB) CreateTag(model models.Tags) error {
+	// Generating new data for the tag
+	tagId := int(uuid.New().ID())
+	for !d.checkTagId(tagId) {
+		tagId = int(uuid.New().ID())
+	}
+
+	// Creating new tag
+	return d.DB.Table("tags").Create(&models.Tags{Id: tagId, UserId: model.UserId, Name: model.Name, Color: model.Color}).Error
+}
+
+func (d *PDB) checkTagId(id int) bool {
+	var tagData models.Tags
+	result := d.DB.Table("tags").Where("id = ?", id).Take(&tagData).Error
+	return errors.Is(result, gorm.ErrRecordNotFound)
+}
+
+func (d *PDB) GetTagByIdAndUserId(id, userId int) (tagData models.Tags) {
+	d.DB.Table("tags").Where("id = ? AND user_id = ?", id, userId).Take(&tagData)
+	return
+}
+
+func (d *PDB) GetTagByNameAndUserId(name string, userId int) (tagData models.Tags) {
+	d.DB.Table("tags").Where("name = ? AND user_id = ?", name, userId).Take(&tagData)
+	return
+}
+
+func (d *PDB) GetUserTags(userId int) (tagsData []models.Tags) {
+	d.DB.Table("tags").Where("user_id = ?", userId).Order("name").Find(&tagsData)
+	return
+}
+
+// GetUserTagsUsage returns the user tags starting with the prefix, the most used tags go first
+func (d *PDB) GetUserTagsUsage(userId int, prefix string, limit int) (tagsData []models.TagUsageData) {
+	d.DB.Table("tags").Select("tags.id, tags.name, tags.color, count(tasks.id) AS count").
+		Joins("LEFT JOIN tasks ON tags.name = ANY(tasks.categories) AND tasks.id IN (?)", d.userTasks(userId)).
+		Where("tags.user_id = ? AND tags.name ILIKE ?", userId, likeEscaper.Replace(prefix)+"%").
+		Group("tags.id").Order("count DESC, tags.name").Limit(limit).Scan(&tagsData)
+	return
+}
+
+// UpdateTag changes the tag and renames the category in the tasks and subtasks the user can edit
+func (d *PDB) UpdateTag(model models.Tags, oldName string) error {
+	return d.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("tags").Select("name", "color").Updates(model).Error; err != nil {
+			return err
+		}
+		if model.Name == oldName {
+			return nil
+		}
+
+		// The new name is removed first, so the category isn't repeated in the task
+		return tx.Table("tasks").Where("? = ANY(categories) AND id IN (?)", oldName, d.editableTasks(model.UserId)).
+			Update("categories", gorm.Expr("array_replace(array_remove(categories, ?), ?, ?)", model.Name, oldName, model.Name)).Error
+	})
+}
+
+// DeleteTag deletes the tag and removes the category from the tasks and subtasks the user can edit
+func (d *PDB) DeleteTag(model models.Tags) error {
+	return d.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("tags").Delete(&models.Tags{}, model.Id).Error; err != nil {
+			return err
+		}
+
+		return tx.Table("tasks").Where("? = ANY(categories) AND id IN (?)", model.Name, d.editableTasks(model.UserId)).
+			Update("categories", gorm.Expr("array_remove(categories, ?)", model.Name)).Error
+	})
+}
+
+func (d *PDB) DeleteUserTags(userId int) error {
+	return d.DB.Table("tags").Where("user_id = ?", userId).Delete(&models.Tags{}).Error
+}
+
+// userTasks returns the subquery of the ids of all tasks and subtasks in the lists available to the user, shared and
+// workspace lists included
+func (d *PDB) userTasks(userId int) clause.Expr {
+	return gorm.Expr(listsTaskIdsSql, d.availableLists(d.DB.Table("lists").Select("id"), userId))
+}
+
+// editableTasks returns the subquery of the ids of all tasks and subtasks in the lists where the user is an editor or
+// higher, the tasks of the lists where the user is only a viewer are left untouched
+func (d *PDB) editableTasks(userId int) clause.Expr {
+	editorRoles := models.LIST_ROLES[1:]
+	editableLists := d.DB.Table("list_access").Select("list_id").Where("user_id = ? AND role IN ?", userId, editorRoles)
+	return gorm.Expr(listsTaskIdsSql, d.DB.Table("lists").Select("id").Where("lists.user_id = ? OR lists.id IN (?)", userId, editableLists))
+}
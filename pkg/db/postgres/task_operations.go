@@ -2,6 +2,9 @@ package postgres
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jinzhu/copier"
@@ -23,7 +26,85 @@ func (d *PDB) CreateTask(model models.Tasks) error {
 	}
 
 	// Creating new task
-	return d.DB.Table("tasks").Create(&models.Tasks{Id: taskId, ListId: model.ListId, Name: model.Name, Comment: model.Comment, Index: index}).Error
+	return d.DB.Table("tasks").Create(&models.Tasks{Id: taskId, ListId: model.ListId, Name: model.Name, Comment: model.Comment, Index: index, Priority: models.TASK_PRIORITIES[0]}).Error
+}
+
+// CreateTaskOccurrence creates the next occurrence of the recurring task together with copies of its subtasks
+func (d *PDB) CreateTaskOccurrence(model models.Tasks, endTime time.Time) error {
+	// Generating new data for the task
+	taskId := int(uuid.New().ID())
+	for !d.checkTaskId(taskId) {
+		taskId = int(uuid.New().ID())
+	}
+
+	var index int
+	if len(d.GetAllTasks(model.ListId)) > 0 {
+		index = d.GetTaskMaxIndex(model.ListId) + 1
+	}
+
+	// Creating new task
+	err := d.DB.Table("tasks").Create(&models.Tasks{
+		Id:         taskId,
+		ListId:     model.ListId,
+		Name:       model.Name,
+		Comment:    model.Comment,
+		Index:      index,
+		Categories: model.Categories,
+		EndTime:    endTime,
+		Special:    model.Special,
+		Priority:   model.Priority,
+		Recurrence: model.Recurrence,
+		Occurrence: model.Occurrence + 1,
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	// Copying all levels of subtasks, their end time moves together with the task
+	var subtasks []models.Tasks
+	d.DB.Table("tasks").Where("id IN (?)", gorm.Expr(subtreeTasksSql, model.Id)).Where("id <> ?", model.Id).Order("index").Find(&subtasks)
+
+	children := make(map[int][]models.Tasks)
+	for _, subtask := range subtasks {
+		children[subtask.TaskId] = append(children[subtask.TaskId], subtask)
+	}
+	return d.copySubtasks(children, model.Id, taskId, endTime.Sub(model.EndTime))
+}
+
+// copySubtasks copies the subtasks of the task to the new parent task, the copies get new ids and their own subtasks
+// are copied after them
+func (d *PDB) copySubtasks(children map[int][]models.Tasks, taskId, newTaskId int, shift time.Duration) error {
+	for _, subtask := range children[taskId] {
+		subtaskId := int(uuid.New().ID())
+		for !d.checkTaskId(subtaskId) {
+			subtaskId = int(uuid.New().ID())
+		}
+
+		subtaskEndTime := subtask.EndTime
+		if !subtaskEndTime.IsZero() {
+			subtaskEndTime = subtaskEndTime.Add(shift)
+		}
+
+		err := d.DB.Table("tasks").Create(&models.Tasks{
+			Id:         subtaskId,
+			TaskId:     newTaskId,
+			Name:       subtask.Name,
+			Comment:    subtask.Comment,
+			Index:      subtask.Index,
+			Categories: subtask.Categories,
+			EndTime:    subtaskEndTime,
+			Special:    subtask.Special,
+			Priority:   subtask.Priority,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		if err = d.copySubtasks(children, subtask.Id, subtaskId, shift); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (d *PDB) checkTaskId(id int) bool {
@@ -32,20 +113,161 @@ func (d *PDB) checkTaskId(id int) bool {
 	return errors.Is(result, gorm.ErrRecordNotFound)
 }
 
-func (d *PDB) GetAllTasks(listId int) (tasksData []models.TasksData) {
+func (d *PDB) GetAllTasks(listId int) []models.TasksData {
+	tasksData, _ := d.listTasks(listId, models.TasksFilter{}, models.MANUAL_SORT, "asc", nil, 0)
+	return tasksData
+}
+
+// GetTasks returns the filtered list tasks with their assignees sorted by the sort mode and the key of the last task
+// if there is a next page, the manual sort is used for unknown modes. A zero limit returns all tasks.
+func (d *PDB) GetTasks(listId int, filter models.TasksFilter, sort, order string, after []interface{}, limit int) ([]models.TasksData, []interface{}) {
+	tasksData, next := d.listTasks(listId, filter, sort, order, after, limit)
+	if len(tasksData) == 0 {
+		return tasksData, next
+	}
+
+	taskIds := make([]int, len(tasksData))
+	for index, task := range tasksData {
+		taskIds[index] = task.Id
+	}
+	assignees := d.tasksAssignees(taskIds)
+	for index, task := range tasksData {
+		tasksData[index].Assignees = assignees[task.Id]
+	}
+	return tasksData, next
+}
+
+// dueKey sorts the tasks by the end time, tasks without the end time are at the end
+const dueKey = "CASE WHEN end_time = '0001-01-01 00:00:00+00' THEN 'infinity' ELSE end_time END"
+
+func (d *PDB) listTasks(listId int, filter models.TasksFilter, sort, order string, after []interface{}, limit int) (tasksData []models.TasksData, next []interface{}) {
+	desc := strings.EqualFold(order, "desc")
+
+	var keys []string
+	switch sort {
+	case models.PRIORITY_SORT:
+		keys = []string{fmt.Sprintf("coalesce(array_position(ARRAY['%s'], priority), 0)", strings.Join(models.TASK_PRIORITIES, "','"))}
+	case models.DUE_SORT:
+		// Tasks without the end time are always at the end, the zero end time is the lowest one
+		keys = []string{dueKey}
+		if desc {
+			keys = []string{"end_time"}
+		}
+	case models.CREATED_SORT:
+		keys = []string{"created_at"}
+	case models.NAME_SORT:
+		keys = []string{"lower(name)"}
+	}
+
+	// Tasks with equal values keep the manual order
+	switch {
+	case len(keys) == 0:
+		keys = []string{"index", "id"}
+	case desc:
+		keys = append(keys, "-index", "-id")
+	default:
+		keys = append(keys, "index", "id")
+	}
+
+	var rows []struct {
+		models.Tasks
+		PageRow
+	}
+	query := filterTasks(d.DB.Table("tasks").Select("tasks.*, "+pageKeyColumn(keys)).Where("list_id = ?", listId), filter)
+	keysetPage(query, keys, desc, after, limit).Scan(&rows)
+	rows, next = cutPage(rows, limit)
+
 	var tasks []models.Tasks
-	d.DB.Table("tasks").Where("list_id = ?", listId).Order("index").Find(&tasks)
+	for _, row := range rows {
+		tasks = append(tasks, row.Tasks)
+	}
 
 	if copier.Copy(&tasksData, &tasks) != nil {
 		return
 	}
 
 	for index, task := range tasks {
-		tasksData[index].EndTime = task.EndTime.Format("2006-01-02 15:04")
+		tasksData[index].EndTime = task.EndTime.UTC().Format("2006-01-02 15:04")
 	}
 	return
 }
 
+// GetUserTasks returns the filtered tasks from all user lists sorted by the end time and the key of the last task if
+// there is a next page, tasks without the end time are at the end. A zero limit returns all tasks.
+func (d *PDB) GetUserTasks(userId int, filter models.TasksFilter, after []interface{}, limit int) ([]models.ViewTasksData, []interface{}) {
+	var rows []struct {
+		models.Tasks
+		PageRow
+	}
+	keys := []string{dueKey, "list_id", "index", "id"}
+	userLists := d.availableLists(d.DB.Table("lists").Select("id"), userId)
+	query := filterTasks(d.DB.Table("tasks").Select("tasks.*, "+pageKeyColumn(keys)).Where("list_id IN (?)", userLists), filter)
+	keysetPage(query, keys, false, after, limit).Scan(&rows)
+	rows, next := cutPage(rows, limit)
+
+	var tasks []models.Tasks
+	for _, row := range rows {
+		tasks = append(tasks, row.Tasks)
+	}
+	return d.viewTasks(userId, tasks), next
+}
+
+// viewTasks adds the list names and assignees to the tasks from different lists
+func (d *PDB) viewTasks(userId int, tasks []models.Tasks) (tasksData []models.ViewTasksData) {
+	if len(tasks) == 0 {
+		return
+	}
+
+	listNames := make(map[int]string)
+	lists, _ := d.GetUserLists(userId, "", nil, 0)
+	for _, list := range lists {
+		listNames[list.Id] = list.Name
+	}
+
+	taskIds := make([]int, len(tasks))
+	for index, task := range tasks {
+		taskIds[index] = task.Id
+	}
+	assignees := d.tasksAssignees(taskIds)
+
+	for _, task := range tasks {
+		var taskData models.TasksData
+		if copier.Copy(&taskData, &task) != nil {
+			return nil
+		}
+		taskData.EndTime = task.EndTime.UTC().Format("2006-01-02 15:04")
+		taskData.Assignees = assignees[task.Id]
+
+		tasksData = append(tasksData, models.ViewTasksData{ListId: task.ListId, ListName: listNames[task.ListId], TasksData: taskData})
+	}
+	return
+}
+
+// filterTasks adds the filter conditions to the tasks query
+func filterTasks(query *gorm.DB, filter models.TasksFilter) *gorm.DB {
+	if filter.Done != nil {
+		query = query.Where("done = ?", *filter.Done)
+	}
+	if filter.Special != nil {
+		query = query.Where("special = ?", *filter.Special)
+	}
+	if filter.Category != "" {
+		query = query.Where("? = ANY(categories)", filter.Category)
+	}
+	// Tasks without the end time are not due
+	if !filter.DueBefore.IsZero() {
+		query = query.Where("end_time < ? AND end_time > ?", filter.DueBefore, time.Time{})
+	}
+	if !filter.DueAfter.IsZero() {
+		query = query.Where("end_time >= ?", filter.DueAfter)
+	}
+	if filter.Search != "" {
+		pattern := "%" + likeEscaper.Replace(filter.Search) + "%"
+		query = query.Where("name ILIKE ? OR comment ILIKE ?", pattern, pattern)
+	}
+	return query
+}
+
 func (d *PDB) GetTaskById(id int) (taksData models.Tasks) {
 	d.DB.Table("tasks").Where("id = ?", id).Take(&taksData)
 	return
@@ -56,8 +278,9 @@ func (d *PDB) GetTasksForEditIndex(listId, taskIndex int) (taskData []models.Tas
 	return
 }
 
+// GetListIdWhereTask returns the list of the task if the user owns the list or is its member
 func (d *PDB) GetListIdWhereTask(userId, taskId int) (listId int) {
-	d.DB.Table("lists").Select("lists.id").Joins("INNER JOIN tasks ON lists.id=tasks.list_id").Where("user_id = ? AND tasks.id = ?", userId, taskId).Take(&listId)
+	d.availableLists(d.DB.Table("lists").Select("lists.id").Joins("INNER JOIN tasks ON lists.id=tasks.list_id"), userId).Where("tasks.id = ?", taskId).Take(&listId)
 	return
 }
 
@@ -67,7 +290,11 @@ func (d *PDB) GetTaskMaxIndex(listId int) (index int) {
 }
 
 func (d *PDB) UpdateTaskData(model models.Tasks) error {
-	return d.DB.Table("tasks").Select("name", "comment", "categories", "end_time", "done", "special").Updates(model).Error
+	return d.DB.Table("tasks").Select("name", "comment", "categories", "end_time", "done", "special", "priority").Updates(model).Error
+}
+
+func (d *PDB) UpdateTaskRecurrence(model models.Tasks) error {
+	return d.DB.Table("tasks").Select("end_time", "recurrence", "occurrence").Updates(model).Error
 }
 
 func (d *PDB) UpdateTaskIndex(id, index int) error {
@@ -101,6 +328,57 @@ func (d *PDB) UpdateTasksIndexes(model models.Tasks) (err error) {
 	return
 }
 
+// treeListUsersSql returns the owner and the members of the list the task tree belongs to
+const treeListUsersSql = `WITH RECURSIVE ancestors AS (SELECT id, task_id, list_id FROM tasks WHERE id = ? UNION SELECT tasks.id, tasks.task_id, tasks.list_id FROM tasks INNER JOIN ancestors ON tasks.id = ancestors.task_id), root AS (SELECT list_id FROM ancestors WHERE task_id = 0 LIMIT 1) SELECT lists.user_id FROM lists INNER JOIN root ON lists.id = root.list_id UNION SELECT list_access.user_id FROM list_access INNER JOIN root ON list_access.list_id = root.list_id`
+
+// MoveTask moves the task or the subtask to the index of the list or the task from the model, the subtasks of the
+// task are moved together with it. Both old and new neighbours are reindexed. When the tree can get into another list,
+// the reminders and assignees of the users without access to that list are deleted.
+func (d *PDB) MoveTask(model models.Tasks) error {
+	task := d.GetTaskById(model.Id)
+
+	return d.DB.Transaction(func(tx *gorm.DB) error {
+		// Closing the gap in the old place
+		err := siblingTasks(tx, task).Where("index > ?", task.Index).Update("index", gorm.Expr("index - 1")).Error
+		if err != nil {
+			return err
+		}
+
+		// Making room in the new place
+		err = siblingTasks(tx, model).Where("index >= ?", model.Index).Update("index", gorm.Expr("index + 1")).Error
+		if err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{"list_id": model.ListId, "task_id": model.TaskId, "index": model.Index}
+		if model.TaskId != 0 {
+			// Subtasks don't recur, so the series ends
+			updates["recurrence"], updates["occurrence"] = "", 0
+		}
+		if err := tx.Table("tasks").Where("id = ?", model.Id).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		if task.TaskId == 0 && model.TaskId == 0 && task.ListId == model.ListId {
+			return nil
+		}
+		subtree, listUsers := gorm.Expr(subtreeTasksSql, model.Id), gorm.Expr(treeListUsersSql, model.Id)
+		err = tx.Table("reminders").Where("task_id IN (?) AND user_id NOT IN (?)", subtree, listUsers).Delete(&models.Reminders{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Table("task_assignees").Where("task_id IN (?) AND user_id NOT IN (?)", subtree, listUsers).Delete(&models.TaskAssignees{}).Error
+	})
+}
+
+// siblingTasks returns the query of the tasks of the same list or the subtasks of the same task
+func siblingTasks(tx *gorm.DB, task models.Tasks) *gorm.DB {
+	if task.TaskId != 0 {
+		return tx.Table("tasks").Where("task_id = ?", task.TaskId)
+	}
+	return tx.Table("tasks").Where("list_id = ?", task.ListId)
+}
+
 func (d *PDB) DeleteTask(id int) error {
 	// Deleting all task subtasks
 	for _, subtask := range d.GetAllSubtasks(id) {
@@ -109,6 +387,17 @@ func (d *PDB) DeleteTask(id int) error {
 		}
 	}
 
+	// Deleting task reminders, assignees and comments
+	if err := d.DeleteTaskReminders(id); err != nil {
+		return err
+	}
+	if err := d.DB.Table("task_assignees").Where("task_id = ?", id).Delete(&models.TaskAssignees{}).Error; err != nil {
+		return err
+	}
+	if err := d.DB.Table("task_comments").Where("task_id = ?", id).Delete(&models.TaskComments{}).Error; err != nil {
+		return err
+	}
+
 	// Deleting task
 	return d.DB.Table("tasks").Delete(&models.Tasks{}, id).Error
 }
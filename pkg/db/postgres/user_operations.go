@@ -3,8 +3,10 @@ package postgres
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 
@@ -26,7 +28,7 @@ func (d *PDB) CrateUser(model models.Users) (userId int, err error) {
 	}
 
 	// Creating new user
-	err = d.DB.Create(&models.Users{Id: userId, Email: model.Email, Password: string(hashedPassword), Name: model.Name, Username: model.Username}).Error
+	err = d.DB.Create(&models.Users{Id: userId, Email: model.Email, Password: string(hashedPassword), Name: model.Name, Username: model.Username, Role: models.USER_ROLE, Timezone: models.DEFAULT_TIMEZONE}).Error
 	return
 }
 
@@ -58,11 +60,37 @@ func (d *PDB) GetUserByEmail(email string) (userData models.Users) {
 	return
 }
 
+func (d *PDB) GetUserByUsername(username string) (userData models.Users) {
+	d.DB.Where("username = ?", username).Take(&userData)
+	return
+}
+
 func (d *PDB) GetUserById(id int) (userData models.Users) {
 	d.DB.Where("id = ?", id).Take(&userData)
 	return
 }
 
+func (d *PDB) GetUsers(search string, offset, limit int) (usersData []models.Users, total int64) {
+	query := d.DB.Table("users")
+	if search != "" {
+		pattern := "%" + likeEscaper.Replace(search) + "%"
+		query = query.Where("email ILIKE ? OR username ILIKE ? OR name ILIKE ?", pattern, pattern, pattern)
+	}
+
+	// Every next method of the query has to start from the same conditions
+	query = query.Session(&gorm.Session{})
+	query.Count(&total)
+	query.Order("username").Offset(offset).Limit(limit).Find(&usersData)
+	return
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func (d *PDB) GetDisabledUserIds() (ids []int) {
+	d.DB.Table("users").Where("disabled = ?", true).Pluck("id", &ids)
+	return
+}
+
 func (d *PDB) UpdateUser(where, model models.Users) error {
 	return d.DB.Where(&where).Updates(&model).Error
 }
@@ -75,6 +103,14 @@ func (d *PDB) UpdateUserPassword(password, email string) (err error) {
 	return d.DB.Where("email = ?", email).Updates(models.Users{Password: string(hashedPassword)}).Error
 }
 
+func (d *PDB) UpdateUserDisabled(id int, disabled bool) error {
+	return d.DB.Table("users").Where("id = ?", id).Update("disabled", disabled).Error
+}
+
+func (d *PDB) UpdateUserCompletionRollup(id int, rollup bool) error {
+	return d.DB.Table("users").Where("id = ?", id).Update("completion_rollup", rollup).Error
+}
+
 func (d *PDB) UpdateUserIcon(id int, icon string) error {
 	return d.DB.Table("users").Where("id = ?", id).Update("icon", icon).Error
 }
@@ -89,8 +125,40 @@ func (d *PDB) CheckUserPassword(password, email string) (err error) {
 	return
 }
 
+func (d *PDB) UpdateUserTOTPSecret(id int, secret string) error {
+	return d.DB.Table("users").Where("id = ?", id).Update("totp_secret", secret).Error
+}
+
+func (d *PDB) EnableUserTOTP(id int, recoveryCodes []string) error {
+	return d.DB.Table("users").Where("id = ?", id).Updates(map[string]interface{}{
+		"totp_enabled":   true,
+		"recovery_codes": pq.StringArray(recoveryCodes),
+	}).Error
+}
+
+func (d *PDB) DisableUserTOTP(id int) error {
+	return d.DB.Table("users").Where("id = ?", id).Updates(map[string]interface{}{
+		"totp_secret":    "",
+		"totp_enabled":   false,
+		"recovery_codes": pq.StringArray{},
+	}).Error
+}
+
+// UseUserTOTPStep saves the time step of the accepted TOTP code, it fails if the step is already used
+func (d *PDB) UseUserTOTPStep(id int, step int64) bool {
+	result := d.DB.Table("users").Where("id = ? AND totp_last_step < ?", id, step).Update("totp_last_step", step)
+	return result.Error == nil && result.RowsAffected == 1
+}
+
+func (d *PDB) UseUserRecoveryCode(id int, codeHash string) bool {
+	// Removing the code from the array, so that it can only be used once
+	result := d.DB.Table("users").Where("id = ? AND ? = ANY(recovery_codes)", id, codeHash).
+		Update("recovery_codes", gorm.Expr("array_remove(recovery_codes, ?)", codeHash))
+	return result.Error == nil && result.RowsAffected == 1
+}
+
 func (d *PDB) DeleteUser(storage db.MinIOClient, ctx context.Context, model models.Users) error {
-	// Deleting all user lists
+	// Deleting all personal lists of the user
 	for _, list := range d.GetAllUserLists(model.Id) {
 		if err := d.DeleteList(list.Id); err != nil {
 			return err
@@ -104,6 +172,31 @@ func (d *PDB) DeleteUser(storage db.MinIOClient, ctx context.Context, model mode
 		}
 	}
 
+	// Deleting all user saved filters
+	if err := d.DeleteUserSavedFilters(model.Id); err != nil {
+		return err
+	}
+
+	// Deleting all user tags
+	if err := d.DeleteUserTags(model.Id); err != nil {
+		return err
+	}
+
+	// Leaving all shared lists
+	if err := d.DeleteUserListMembers(model.Id); err != nil {
+		return err
+	}
+
+	// Leaving all workspaces, the workspace lists are not owned by the user and stay in the workspaces
+	if err := d.DeleteUserWorkspaceMembers(model.Id); err != nil {
+		return err
+	}
+
+	// Deleting all user personal tokens
+	if err := d.DeleteAllUserPersonalTokens(model.Id); err != nil {
+		return err
+	}
+
 	// Deleting a user account
 	return d.DB.Delete(&models.Users{}, model.Id).Error
 }
@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/NKTKLN/todo-api/models"
+)
+
+// workspaceTaskIdsSql returns the ids of all tasks and subtasks in the lists of the workspace
+const workspaceTaskIdsSql = `WITH RECURSIVE tree AS (SELECT tasks.id FROM tasks INNER JOIN lists ON lists.id = tasks.list_id WHERE lists.workspace_id = ? UNION SELECT tasks.id FROM tasks INNER JOIN tree ON tasks.task_id = tree.id) SELECT id FROM tree`
+
+// CreateWorkspaceMember adds the member to the workspace. The workspace role replaces the roles the user had in the
+// workspace lists shared with them before.
+func (d *PDB) CreateWorkspaceMember(model models.WorkspaceMembers) error {
+	return d.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("workspace_members").Create(&model).Error; err != nil {
+			return err
+		}
+
+		workspaceLists := tx.Table("lists").Select("id").Where("workspace_id = ?", model.WorkspaceId)
+		return tx.Table("list_members").Where("user_id = ? AND list_id IN (?)", model.UserId, workspaceLists).Delete(&models.ListMembers{}).Error
+	})
+}
+
+// GetWorkspaceRole returns the role of the user in the workspace, an empty role means that the user is not its member
+func (d *PDB) GetWorkspaceRole(workspaceId, userId int) (role string) {
+	d.DB.Table("workspace_members").Select("role").Where("workspace_id = ? AND user_id = ?", workspaceId, userId).Take(&role)
+	return
+}
+
+func (d *PDB) GetWorkspaceMembers(workspaceId int) (membersData []models.WorkspaceMemberData) {
+	d.DB.Table("workspace_members").
+		Select("users.id, users.username, users.name, workspace_members.role").
+		Joins("INNER JOIN users ON users.id = workspace_members.user_id").
+		Where("workspace_members.workspace_id = ?", workspaceId).
+		Order("users.username").Scan(&membersData)
+	return
+}
+
+func (d *PDB) UpdateWorkspaceMemberRole(model models.WorkspaceMembers) error {
+	return d.DB.Table("workspace_members").Where("workspace_id = ? AND user_id = ?", model.WorkspaceId, model.UserId).Update("role", model.Role).Error
+}
+
+// DeleteWorkspaceMember deletes the member together with their reminders and assignments in the workspace tasks
+func (d *PDB) DeleteWorkspaceMember(model models.WorkspaceMembers) error {
+	return d.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Table("reminders").Where("user_id = ? AND task_id IN (?)", model.UserId, gorm.Expr(workspaceTaskIdsSql, model.WorkspaceId)).Delete(&models.Reminders{}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Table("task_assignees").Where("user_id = ? AND task_id IN (?)", model.UserId, gorm.Expr(workspaceTaskIdsSql, model.WorkspaceId)).Delete(&models.TaskAssignees{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Table("workspace_members").Where("workspace_id = ? AND user_id = ?", model.WorkspaceId, model.UserId).Delete(&models.WorkspaceMembers{}).Error
+	})
+}
+
+// DeleteUserWorkspaceMembers deletes the user from all workspaces, the workspace lists are kept. Workspaces left
+// without admins pass to the remaining members with the highest role, so their lists don't lose the owners.
+func (d *PDB) DeleteUserWorkspaceMembers(userId int) error {
+	var workspaceIds []int
+	d.DB.Table("workspace_members").Where("user_id = ? AND role = ?", userId, models.LIST_ADMIN_ROLE).Pluck("workspace_id", &workspaceIds)
+
+	return d.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("workspace_members").Where("user_id = ?", userId).Delete(&models.WorkspaceMembers{}).Error; err != nil {
+			return err
+		}
+
+		for _, workspaceId := range workspaceIds {
+			if err := promoteWorkspaceMembers(tx, workspaceId); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// promoteWorkspaceMembers makes the members with the highest role the admins if the workspace has no admins
+func promoteWorkspaceMembers(tx *gorm.DB, workspaceId int) error {
+	var roles []string
+	tx.Table("workspace_members").Where("workspace_id = ?", workspaceId).Pluck("role", &roles)
+
+	highest := -1
+	for _, role := range roles {
+		for rank, listRole := range models.LIST_ROLES {
+			if listRole == role && rank > highest {
+				highest = rank
+			}
+		}
+	}
+
+	// Nothing changes while an admin is left, workspaces without members keep their lists
+	if highest < 0 || models.LIST_ROLES[highest] == models.LIST_ADMIN_ROLE {
+		return nil
+	}
+	return tx.Table("workspace_members").Where("workspace_id = ? AND role = ?", workspaceId, models.LIST_ROLES[highest]).Update("role", models.LIST_ADMIN_ROLE).Error
+}
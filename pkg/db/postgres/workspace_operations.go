@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/NKTKLN/todo-api/models"
+)
+
+// CreateWorkspace creates the workspace, the user who created it becomes its admin
+func (d *PDB) CreateWorkspace(model models.Workspaces, userId int) error {
+	// Generating new data for the workspace
+	workspaceId := int(uuid.New().ID())
+	for !d.checkWorkspaceId(workspaceId) {
+		workspaceId = int(uuid.New().ID())
+	}
+
+	// Creating new workspace
+	return d.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("workspaces").Create(&models.Workspaces{Id: workspaceId, Name: model.Name}).Error; err != nil {
+			return err
+		}
+		return tx.Table("workspace_members").Create(&models.WorkspaceMembers{WorkspaceId: workspaceId, UserId: userId, Role: models.LIST_ADMIN_ROLE}).Error
+	})
+}
+
+func (d *PDB) checkWorkspaceId(id int) bool {
+	var workspaceData models.Workspaces
+	result := d.DB.Table("workspaces").Where("id = ?", id).Take(&workspaceData).Error
+	return errors.Is(result, gorm.ErrRecordNotFound)
+}
+
+// GetUserWorkspaces returns the workspaces of the user together with the role of the user in them
+func (d *PDB) GetUserWorkspaces(userId int) (workspacesData []models.WorkspacesData) {
+	d.DB.Table("workspaces").
+		Select("workspaces.id, workspaces.name, workspace_members.role").
+		Joins("INNER JOIN workspace_members ON workspace_members.workspace_id = workspaces.id").
+		Where("workspace_members.user_id = ?", userId).
+		Order("workspaces.name, workspaces.id").Scan(&workspacesData)
+	return
+}
+
+func (d *PDB) UpdateWorkspace(model models.Workspaces) error {
+	return d.DB.Table("workspaces").Where("id = ?", model.Id).Update("name", model.Name).Error
+}
+
+// DeleteWorkspace deletes the workspace together with its lists
+func (d *PDB) DeleteWorkspace(id int) error {
+	// Deleting all workspace lists
+	var lists []models.Lists
+	d.DB.Table("lists").Where("workspace_id = ?", id).Find(&lists)
+	for _, list := range lists {
+		if err := d.DeleteList(list.Id); err != nil {
+			return err
+		}
+	}
+
+	// Deleting workspace members
+	if err := d.DB.Table("workspace_members").Where("workspace_id = ?", id).Delete(&models.WorkspaceMembers{}).Error; err != nil {
+		return err
+	}
+
+	// Deleting workspace
+	return d.DB.Delete(&models.Workspaces{}, id).Error
+}
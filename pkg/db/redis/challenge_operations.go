@@ -0,0 +1,63 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+
+	"github.com/NKTKLN/todo-api/models"
+	"github.com/NKTKLN/todo-api/pkg/db"
+)
+
+func (c *RedisClients) CreateChallenge(ctx context.Context, challenge models.Challenges) (challengeId string, err error) {
+	challenge.Id = uuid.New().String()
+
+	// Convert data to json
+	jsonData, err := json.Marshal(challenge)
+	if err != nil {
+		return
+	}
+
+	// Adding challenge to the db
+	if err = c.SessionClient.Set(ctx, challengeKey(challenge.Id), jsonData, models.CHALLENGE_LIVE).Err(); err != nil {
+		return
+	}
+
+	return challenge.Id, nil
+}
+
+func (c *RedisClients) GetChallenge(ctx context.Context, challengeId string) (challenge models.Challenges, err error) {
+	val, err := c.SessionClient.Get(ctx, challengeKey(challengeId)).Result()
+	if err != nil {
+		return
+	}
+
+	// Counting the attempts to pass the challenge
+	attemptsPipe := c.SessionClient.Pipeline()
+	attempts := attemptsPipe.Incr(ctx, challengeAttemptsKey(challengeId))
+	attemptsPipe.Expire(ctx, challengeAttemptsKey(challengeId), models.CHALLENGE_LIVE)
+	if _, err = attemptsPipe.Exec(ctx); err != nil {
+		return
+	}
+	if attempts.Val() > models.MAX_CHALLENGE_ATTEMPTS {
+		c.DeleteChallenge(ctx, challengeId)
+		return challenge, db.ErrTooManyAttempts
+	}
+
+	// Converting challenge data from json
+	err = json.Unmarshal([]byte(val), &challenge)
+	return
+}
+
+func (c *RedisClients) DeleteChallenge(ctx context.Context, challengeId string) error {
+	return c.SessionClient.Del(ctx, challengeKey(challengeId), challengeAttemptsKey(challengeId)).Err()
+}
+
+func challengeKey(challengeId string) string {
+	return "challenge:" + challengeId
+}
+
+func challengeAttemptsKey(challengeId string) string {
+	return "challenge-attempts:" + challengeId
+}
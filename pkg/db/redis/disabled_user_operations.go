@@ -0,0 +1,19 @@
+package redis
+
+import "context"
+
+// Disabled users are kept in the redis, so that the tokens can be checked without the postgres
+const disabledUsersKey = "disabled-users"
+
+func (c *RedisClients) AddDisabledUser(ctx context.Context, userId int) error {
+	return c.SessionClient.SAdd(ctx, disabledUsersKey, userId).Err()
+}
+
+func (c *RedisClients) RemoveDisabledUser(ctx context.Context, userId int) error {
+	return c.SessionClient.SRem(ctx, disabledUsersKey, userId).Err()
+}
+
+func (c *RedisClients) IsUserDisabled(ctx context.Context, userId int) bool {
+	disabled, err := c.SessionClient.SIsMember(ctx, disabledUsersKey, userId).Result()
+	return err == nil && disabled
+}
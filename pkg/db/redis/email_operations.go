@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,44 +12,93 @@ import (
 	"github.com/NKTKLN/todo-api/models"
 )
 
-func (c *RedisClients) AddEmailData(ctx context.Context, data interface{}) (key string, err error) {
+func (c *RedisClients) AddEmailData(ctx context.Context, flow string, data interface{}) (key string, err error) {
+	return c.addEmailData(ctx, flow, data, models.EMAIL_KEY_LIVE)
+}
+
+func (c *RedisClients) addEmailData(ctx context.Context, flow string, data interface{}, live time.Duration) (key string, err error) {
 	// Create a temporary access key to verify mail fidelity and then send it
 	for {
 		key = strconv.Itoa(int(uuid.New().ID()))
 
-		val, err := c.EmailClient.Get(ctx, key).Result()
-		if err != nil || val == "" {
+		exists, err := c.EmailClient.Exists(ctx, emailDataKey(flow, key)).Result()
+		if err != nil || exists == 0 {
 			break
 		}
 	}
 
-	err = c.EmailClient.Set(ctx, key, data, 15*time.Minute).Err()
+	err = c.EmailClient.Set(ctx, emailDataKey(flow, key), data, live).Err()
+	return
+}
+
+// GetEmailData deletes the key along with getting its data, so every key can be used only once
+func (c *RedisClients) GetEmailData(ctx context.Context, flow, key string) (val string, err error) {
+	pipe := c.EmailClient.TxPipeline()
+	data := pipe.Get(ctx, emailDataKey(flow, key))
+	pipe.Del(ctx, emailDataKey(flow, key))
+	if _, err = pipe.Exec(ctx); err != nil {
+		return
+	}
+
+	return data.Val(), nil
+}
+
+func (c *RedisClients) GetUserData(ctx context.Context, flow, key string) (userParam models.Users, err error) {
+	// Checking that the key is in working order
+	val, err := c.GetEmailData(ctx, flow, key)
+	if err != nil {
+		return
+	}
+
+	// Converting user data from json
+	err = json.Unmarshal([]byte(val), &userParam)
+	return
+}
+
+// GetSignUpData returns the data of the new user together with the invitation key
+func (c *RedisClients) GetSignUpData(ctx context.Context, key string) (data models.UserData, err error) {
+	val, err := c.GetEmailData(ctx, models.VERIFICATION_FLOW, key)
 	if err != nil {
 		return
 	}
 
+	err = json.Unmarshal([]byte(val), &data)
 	return
 }
 
-func (c *RedisClients) GetEmailData(ctx context.Context, key string) (val string, err error) {
-	return c.EmailClient.Get(ctx, key).Result()
+// AddListInvite creates the invitation key, it lives longer than other email keys
+func (c *RedisClients) AddListInvite(ctx context.Context, invite models.ListInvites) (key string, err error) {
+	jsonData, err := json.Marshal(invite)
+	if err != nil {
+		return
+	}
+
+	return c.addEmailData(ctx, models.LIST_INVITE_FLOW, jsonData, models.INVITE_KEY_LIVE)
 }
 
-func (c *RedisClients) GetUserData(ctx context.Context, key string) (userParam models.Users, err error) {
-	// Checking that the key is in working order
-	val, err := c.EmailClient.Get(ctx, key).Result()
+// GetListInvite deletes the invitation key along with getting the invitation
+func (c *RedisClients) GetListInvite(ctx context.Context, key string) (invite models.ListInvites, err error) {
+	val, err := c.GetEmailData(ctx, models.LIST_INVITE_FLOW, key)
 	if err != nil {
 		return
 	}
 
-	// Converting user data from json
-	err = json.Unmarshal([]byte(val), &userParam)
+	err = json.Unmarshal([]byte(val), &invite)
 	return
 }
 
-func (c *RedisClients) DeleteEmailData(ctx context.Context, key string) (err error) {
-	pipe := c.EmailClient.Pipeline()
-	pipe.Del(ctx, key)
-	_, err = pipe.Exec(ctx)
-	return 
+// CheckListInvite checks that the invitation key is valid and was sent to the email without using it
+func (c *RedisClients) CheckListInvite(ctx context.Context, key, email string) bool {
+	val, err := c.EmailClient.Get(ctx, emailDataKey(models.LIST_INVITE_FLOW, key)).Result()
+	if err != nil {
+		return false
+	}
+
+	var invite models.ListInvites
+	return json.Unmarshal([]byte(val), &invite) == nil && strings.EqualFold(invite.Email, email)
+}
+
+// Keys of different flows are stored separately, so a key can't be used in another flow
+func emailDataKey(flow, key string) string {
+	return flow + ":" + key
 }
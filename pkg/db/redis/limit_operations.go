@@ -0,0 +1,75 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/NKTKLN/todo-api/models"
+)
+
+func (c *RedisClients) GetLimitLock(ctx context.Context, key string) (retryAfter time.Duration, err error) {
+	retryAfter, err = c.LimitClient.TTL(ctx, limitLockKey(key)).Result()
+	if err != nil || retryAfter < 0 {
+		return 0, err
+	}
+	return
+}
+
+func (c *RedisClients) HitLimit(ctx context.Context, key string, limit models.Limit) (lockout time.Duration, err error) {
+	// A limit without attempts is disabled
+	if limit.MaxAttempts <= 0 {
+		return
+	}
+
+	attempts, err := c.LimitClient.Incr(ctx, limitAttemptsKey(key)).Result()
+	if err != nil {
+		return
+	}
+	if attempts == 1 {
+		if err = c.LimitClient.Expire(ctx, limitAttemptsKey(key), limit.Window).Err(); err != nil {
+			return
+		}
+	}
+	if attempts < int64(limit.MaxAttempts) {
+		return
+	}
+
+	// Every next lockout is twice as long as the previous one
+	lockouts, err := c.LimitClient.Incr(ctx, limitLockoutsKey(key)).Result()
+	if err != nil {
+		return
+	}
+	if err = c.LimitClient.Expire(ctx, limitLockoutsKey(key), limit.MaxLockout).Err(); err != nil {
+		return
+	}
+
+	lockout = limit.Lockout
+	for i := int64(1); i < lockouts && lockout < limit.MaxLockout; i++ {
+		lockout *= 2
+	}
+	if lockout > limit.MaxLockout {
+		lockout = limit.MaxLockout
+	}
+
+	lockPipe := c.LimitClient.Pipeline()
+	lockPipe.Set(ctx, limitLockKey(key), attempts, lockout)
+	lockPipe.Del(ctx, limitAttemptsKey(key))
+	_, err = lockPipe.Exec(ctx)
+	return
+}
+
+func (c *RedisClients) ResetLimit(ctx context.Context, key string) error {
+	return c.LimitClient.Del(ctx, limitAttemptsKey(key), limitLockoutsKey(key)).Err()
+}
+
+func limitAttemptsKey(key string) string {
+	return "attempts:" + key
+}
+
+func limitLockoutsKey(key string) string {
+	return "lockouts:" + key
+}
+
+func limitLockKey(key string) string {
+	return "lock:" + key
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-redis/redis/v8"
 
+	"github.com/NKTKLN/todo-api/pkg/common"
 	"github.com/NKTKLN/todo-api/pkg/db"
 )
 
@@ -12,10 +13,14 @@ type RedisClients struct {
 	EmailClient        *redis.Client
 	AccessTokenClient  *redis.Client
 	RefreshTokenClient *redis.Client
+	SessionClient      *redis.Client
+	LimitClient        *redis.Client
+	ReminderClient     *redis.Client
+	JWTKeys            *common.JWTKeys
 }
 
 // Connecting to a redis database
-func Connect(redisAddr, redisPassword string, redisEmailDB, redisAccessTokenDB, redisRefreshTokenDB int) (db.RedisClient, error) {
+func Connect(redisAddr, redisPassword string, redisEmailDB, redisAccessTokenDB, redisRefreshTokenDB, redisSessionDB, redisLimitDB, redisReminderDB int, jwtKeys *common.JWTKeys) (db.RedisClient, error) {
 	var ctx = context.Background()
 	
 	emailClient := redis.NewClient(&redis.Options{
@@ -42,10 +47,38 @@ func Connect(redisAddr, redisPassword string, redisEmailDB, redisAccessTokenDB,
 	if err := refreshTokenClient.Ping(ctx).Err(); err != nil {
 		return &RedisClients{}, err
 	}
+	sessionClient := redis.NewClient(&redis.Options{
+		Addr:     redisAddr,
+		Password: redisPassword,
+		DB:       redisSessionDB,
+	})
+	if err := sessionClient.Ping(ctx).Err(); err != nil {
+		return &RedisClients{}, err
+	}
+	limitClient := redis.NewClient(&redis.Options{
+		Addr:     redisAddr,
+		Password: redisPassword,
+		DB:       redisLimitDB,
+	})
+	if err := limitClient.Ping(ctx).Err(); err != nil {
+		return &RedisClients{}, err
+	}
+	reminderClient := redis.NewClient(&redis.Options{
+		Addr:     redisAddr,
+		Password: redisPassword,
+		DB:       redisReminderDB,
+	})
+	if err := reminderClient.Ping(ctx).Err(); err != nil {
+		return &RedisClients{}, err
+	}
 
 	return &RedisClients{
 		EmailClient:        emailClient,
 		AccessTokenClient:  accessTokenClient,
 		RefreshTokenClient: refreshTokenClient,
+		SessionClient:      sessionClient,
+		LimitClient:        limitClient,
+		ReminderClient:     reminderClient,
+		JWTKeys:            jwtKeys,
 	}, nil
 }
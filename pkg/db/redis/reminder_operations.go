@@ -0,0 +1,52 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Reminders are queued in the sorted set by the time of sending
+const remindersKey = "reminders"
+
+// Due reminders get the lease time as a score, so other replicas can't claim them until the lease expires
+var claimRemindersScript = redis.NewScript(`
+local ids = redis.call("ZRANGEBYSCORE", KEYS[1], "-inf", ARGV[1], "LIMIT", 0, ARGV[3])
+for _, id in ipairs(ids) do
+	redis.call("ZADD", KEYS[1], ARGV[2], id)
+end
+return ids
+`)
+
+func (c *RedisClients) ScheduleReminder(ctx context.Context, id int, remindAt time.Time) error {
+	return c.ReminderClient.ZAdd(ctx, remindersKey, &redis.Z{Score: float64(remindAt.Unix()), Member: id}).Err()
+}
+
+// RestoreReminder adds the reminder to the queue only if it isn't there yet, so the claimed reminders keep their lease
+func (c *RedisClients) RestoreReminder(ctx context.Context, id int, remindAt time.Time) error {
+	return c.ReminderClient.ZAddNX(ctx, remindersKey, &redis.Z{Score: float64(remindAt.Unix()), Member: id}).Err()
+}
+
+func (c *RedisClients) ClaimReminders(ctx context.Context, now time.Time, lease time.Duration, count int) ([]int, error) {
+	result, err := claimRemindersScript.Run(ctx, c.ReminderClient, []string{remindersKey}, now.Unix(), now.Add(lease).Unix(), count).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(result))
+	for _, member := range result {
+		id, err := strconv.Atoi(member)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (c *RedisClients) UnscheduleReminder(ctx context.Context, id int) error {
+	return c.ReminderClient.ZRem(ctx, remindersKey, id).Err()
+}
@@ -0,0 +1,102 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/NKTKLN/todo-api/models"
+)
+
+func (c *RedisClients) CreateSession(ctx context.Context, session models.Sessions) (accessToken, refreshToken string, err error) {
+	// Generating new data for the session
+	session.Id = uuid.New().String()
+	session.CreatedAt = time.Now()
+	session.LastActive = session.CreatedAt
+
+	// Adding session to the db
+	if err = c.setSession(ctx, session, models.REFRESH_TOKEN_LIVE); err != nil {
+		return
+	}
+
+	userSessionsPipe := c.SessionClient.Pipeline()
+	userSessionsPipe.SAdd(ctx, userSessionsKey(session.UserId), session.Id)
+	userSessionsPipe.Expire(ctx, userSessionsKey(session.UserId), models.REFRESH_TOKEN_LIVE)
+	if _, err = userSessionsPipe.Exec(ctx); err != nil {
+		return
+	}
+
+	// Creating tokens for the new session
+	return c.CreateTokens(ctx, session)
+}
+
+func (c *RedisClients) GetSession(ctx context.Context, sessionId string) (session models.Sessions, err error) {
+	val, err := c.SessionClient.Get(ctx, sessionId).Result()
+	if err != nil {
+		return
+	}
+
+	// Converting session data from json
+	err = json.Unmarshal([]byte(val), &session)
+	return
+}
+
+func (c *RedisClients) GetUserSessions(ctx context.Context, userId int) (sessions []models.Sessions, err error) {
+	sessionIds, err := c.SessionClient.SMembers(ctx, userSessionsKey(userId)).Result()
+	if err != nil {
+		return
+	}
+
+	for _, sessionId := range sessionIds {
+		session, err := c.GetSession(ctx, sessionId)
+		if err != nil {
+			// Removing the expired session from the user sessions
+			c.SessionClient.SRem(ctx, userSessionsKey(userId), sessionId)
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.Before(sessions[j].CreatedAt)
+	})
+	return
+}
+
+func (c *RedisClients) DeleteSession(ctx context.Context, userId int, sessionId string) (err error) {
+	accessTokenPipe := c.AccessTokenClient.Pipeline()
+	accessTokenPipe.Del(ctx, sessionId)
+	if _, err = accessTokenPipe.Exec(ctx); err != nil {
+		return
+	}
+
+	refreshTokenPipe := c.RefreshTokenClient.Pipeline()
+	refreshTokenPipe.Del(ctx, sessionId, rotatedTokensKey(sessionId))
+	if _, err = refreshTokenPipe.Exec(ctx); err != nil {
+		return
+	}
+
+	sessionPipe := c.SessionClient.Pipeline()
+	sessionPipe.Del(ctx, sessionId)
+	sessionPipe.SRem(ctx, userSessionsKey(userId), sessionId)
+	_, err = sessionPipe.Exec(ctx)
+	return
+}
+
+func (c *RedisClients) setSession(ctx context.Context, session models.Sessions, ttl time.Duration) error {
+	// Convert data to json
+	jsonData, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	return c.SessionClient.Set(ctx, session.Id, jsonData, ttl).Err()
+}
+
+func userSessionsKey(userId int) string {
+	return "user:" + strconv.Itoa(userId)
+}
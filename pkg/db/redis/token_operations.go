@@ -2,118 +2,169 @@ package redis
 
 import (
 	"context"
-	"strconv"
+	"time"
 
 	"github.com/NKTKLN/todo-api/models"
 	"github.com/NKTKLN/todo-api/pkg/common"
 	"github.com/spf13/viper"
 )
 
-func (c *RedisClients) CreateTokens(ctx context.Context, userId int) (accessToken, refreshToken string, err error) {
+func (c *RedisClients) CreateTokens(ctx context.Context, session models.Sessions) (accessToken, refreshToken string, err error) {
 	// Generation of refreshes and access tokens
-	accessToken, err = common.NewJWT(userId, models.ACCESS_TOKEN_LIVE, viper.GetString("api.jwt.access-secret"))
+	accessToken, err = c.newJWT(session, models.ACCESS_TOKEN, models.ACCESS_TOKEN_LIVE)
 	if err != nil {
 		return
 	}
-	refreshToken, err = common.NewJWT(userId, models.ACCESS_TOKEN_LIVE, viper.GetString("api.jwt.refresh-secret"))
+	refreshToken, err = c.newJWT(session, models.REFRESH_TOKEN, models.REFRESH_TOKEN_LIVE)
 	if err != nil {
 		return
 	}
 
 	// Adding tokens to the db
-	if err = c.AccessTokenClient.Set(ctx, strconv.Itoa(userId), accessToken, models.ACCESS_TOKEN_LIVE).Err(); err != nil {
+	if err = c.AccessTokenClient.Set(ctx, session.Id, accessToken, models.ACCESS_TOKEN_LIVE).Err(); err != nil {
 		return
 	}
-	if err = c.RefreshTokenClient.Set(ctx, strconv.Itoa(userId), accessToken, models.REFRESH_TOKEN_LIVE).Err(); err != nil {
+	if err = c.RefreshTokenClient.Set(ctx, session.Id, refreshToken, models.REFRESH_TOKEN_LIVE).Err(); err != nil {
 		return
 	}
 
+	// Renewing the life of a session and of the user sessions index
+	sessionPipe := c.SessionClient.Pipeline()
+	sessionPipe.Expire(ctx, session.Id, models.REFRESH_TOKEN_LIVE)
+	sessionPipe.Expire(ctx, userSessionsKey(session.UserId), models.REFRESH_TOKEN_LIVE)
+	_, err = sessionPipe.Exec(ctx)
 	return
 }
 
-func (c *RedisClients) CheckTokens(ctx context.Context, userId int) (accessToken, refreshToken string, err error) {
-	// Check if the access token exists in the db if not, then create it
-	accessToken, _ = c.AccessTokenClient.Get(ctx, strconv.Itoa(userId)).Result()
-	if accessToken == "" {
-		accessToken, err = common.NewJWT(userId, models.ACCESS_TOKEN_LIVE, viper.GetString("api.jwt.access-secret"))
-		if err != nil {
-			return
-		}
-
-		if err = c.AccessTokenClient.Set(ctx, strconv.Itoa(userId), accessToken, models.ACCESS_TOKEN_LIVE).Err(); err != nil {
-			return
-		}
-	}
-
-	// Check if the refresh token exists in the db, and if not, create it
-	refreshToken, _ = c.RefreshTokenClient.Get(ctx, strconv.Itoa(userId)).Result()
-	if refreshToken == "" {
-		refreshToken, err = common.NewJWT(userId, models.REFRESH_TOKEN_LIVE, viper.GetString("api.jwt.refresh-secret"))
-		if err != nil {
-			return
-		}
-
-		if err = c.RefreshTokenClient.Set(ctx, strconv.Itoa(userId), refreshToken, models.REFRESH_TOKEN_LIVE).Err(); err != nil {
-			return
-		}
-
-	}
-
-	return
+func (c *RedisClients) VerifyToken(ctx context.Context, tokenString string) int {
+	return c.VerifySession(ctx, tokenString).UserId
 }
 
-func (c *RedisClients) VerifyToken(ctx context.Context, tokenString string) int {
-	// Check the validity of the token and get the user id from it
-	userId := common.VerifyToken(tokenString, viper.GetString("api.jwt.access-secret"))
+func (c *RedisClients) VerifySession(ctx context.Context, tokenString string) models.Sessions {
+	// Check the validity of the token and get the user and session ids from it
+	userId, claims := c.verifyJWT(tokenString, models.ACCESS_TOKEN)
 	if userId == 0 {
-		return 0
+		return models.Sessions{}
 	}
+	sessionId := claims.SessionId
 
 	// Token validity check
-	dbToken := c.AccessTokenClient.Get(ctx, strconv.Itoa(userId)).Val()
+	dbToken := c.AccessTokenClient.Get(ctx, sessionId).Val()
 	if dbToken != tokenString {
-		return 0
+		return models.Sessions{}
+	}
+
+	// Session validity check
+	session, err := c.GetSession(ctx, sessionId)
+	if err != nil || session.UserId != userId {
+		return models.Sessions{}
 	}
 
 	// Renewing the life of a token
-	if c.AccessTokenClient.Set(ctx, strconv.Itoa(userId), tokenString, models.ACCESS_TOKEN_LIVE).Err() != nil {
-		return 0
+	if c.AccessTokenClient.Set(ctx, sessionId, tokenString, models.ACCESS_TOKEN_LIVE).Err() != nil {
+		return models.Sessions{}
 	}
 
-	return userId
+	// Updating the session activity time
+	sessionTTL := c.SessionClient.TTL(ctx, sessionId).Val()
+	if sessionTTL <= 0 {
+		return models.Sessions{}
+	}
+	session.LastActive = time.Now()
+	if c.setSession(ctx, session, sessionTTL) != nil {
+		return models.Sessions{}
+	}
+
+	return session
 }
 
-func (c *RedisClients) VerifyRefreshToken(ctx context.Context, tokenString string) int {
-	// Check the validity of the token and get the user id from it
-	userId := common.VerifyToken(tokenString, viper.GetString("api.jwt.refresh-secret"))
+func (c *RedisClients) VerifyRefreshToken(ctx context.Context, tokenString string) models.Sessions {
+	// Check the validity of the token and get the user and session ids from it
+	userId, claims := c.verifyJWT(tokenString, models.REFRESH_TOKEN)
 	if userId == 0 {
-		return 0
+		return models.Sessions{}
 	}
 
-	// Token validity check
-	dbToken := c.RefreshTokenClient.Get(ctx, strconv.Itoa(userId)).Val()
+	// Token validity check, the reuse of an already rotated token revokes the whole session
+	dbToken := c.RefreshTokenClient.Get(ctx, claims.SessionId).Val()
 	if dbToken != tokenString {
-		return 0
+		if c.RefreshTokenClient.SIsMember(ctx, rotatedTokensKey(claims.SessionId), claims.ID).Val() {
+			c.DeleteSession(ctx, userId, claims.SessionId)
+		}
+		return models.Sessions{}
 	}
 
-	// Renewing the life of a token
-	if c.RefreshTokenClient.Set(ctx, strconv.Itoa(userId), tokenString, models.REFRESH_TOKEN_LIVE).Err() != nil {
-		return 0
+	// Session validity check
+	session, err := c.GetSession(ctx, claims.SessionId)
+	if err != nil || session.UserId != userId {
+		return models.Sessions{}
 	}
 
-	return userId
+	// Marking the token as rotated, only one request can rotate it
+	rotatedTokensPipe := c.RefreshTokenClient.Pipeline()
+	rotated := rotatedTokensPipe.SAdd(ctx, rotatedTokensKey(session.Id), claims.ID)
+	rotatedTokensPipe.Expire(ctx, rotatedTokensKey(session.Id), models.REFRESH_TOKEN_LIVE)
+	if _, err = rotatedTokensPipe.Exec(ctx); err != nil {
+		return models.Sessions{}
+	}
+	if rotated.Val() == 0 {
+		c.DeleteSession(ctx, userId, session.Id)
+		return models.Sessions{}
+	}
+
+	return session
 }
 
 func (c *RedisClients) DeleteRefreshTokensData(ctx context.Context, userId int) (err error) {
+	sessionIds, err := c.SessionClient.SMembers(ctx, userSessionsKey(userId)).Result()
+	if err != nil || len(sessionIds) == 0 {
+		return
+	}
+
+	// Deleting refresh tokens and data of all user sessions
 	refreshTokenPipe := c.RefreshTokenClient.Pipeline()
-	refreshTokenPipe.Del(ctx, strconv.Itoa(userId))
-	_, err = refreshTokenPipe.Exec(ctx)
-	return 
+	refreshTokenPipe.Del(ctx, sessionIds...)
+	for _, sessionId := range sessionIds {
+		refreshTokenPipe.Del(ctx, rotatedTokensKey(sessionId))
+	}
+	if _, err = refreshTokenPipe.Exec(ctx); err != nil {
+		return
+	}
+
+	sessionPipe := c.SessionClient.Pipeline()
+	sessionPipe.Del(ctx, append(sessionIds, userSessionsKey(userId))...)
+	_, err = sessionPipe.Exec(ctx)
+	return
 }
 
 func (c *RedisClients) DeleteAccessTokensData(ctx context.Context, userId int) (err error) {
+	sessionIds, err := c.SessionClient.SMembers(ctx, userSessionsKey(userId)).Result()
+	if err != nil || len(sessionIds) == 0 {
+		return
+	}
+
+	// Deleting access tokens of all user sessions
 	accessTokenPipe := c.AccessTokenClient.Pipeline()
-	accessTokenPipe.Del(ctx, strconv.Itoa(userId))
+	accessTokenPipe.Del(ctx, sessionIds...)
 	_, err = accessTokenPipe.Exec(ctx)
-	return 
+	return
+}
+
+// Without asymmetric keys the tokens are signed with HS256 and a separate secret for each token use
+func (c *RedisClients) newJWT(session models.Sessions, tokenUse string, ttl time.Duration) (string, error) {
+	if c.JWTKeys == nil {
+		return common.NewJWT(session.UserId, session.Id, ttl, viper.GetString("api.jwt."+tokenUse+"-secret"))
+	}
+	return c.JWTKeys.NewJWT(session.UserId, session.Id, tokenUse, ttl)
+}
+
+func (c *RedisClients) verifyJWT(tokenString, tokenUse string) (int, common.TokenClaims) {
+	if c.JWTKeys == nil {
+		return common.VerifyToken(tokenString, viper.GetString("api.jwt."+tokenUse+"-secret"))
+	}
+	return c.JWTKeys.VerifyToken(tokenString, tokenUse)
+}
+
+func rotatedTokensKey(sessionId string) string {
+	return "rotated:" + sessionId
 }
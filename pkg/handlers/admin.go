@@ -0,0 +1,283 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/copier"
+
+	"github.com/NKTKLN/todo-api/models"
+	"github.com/NKTKLN/todo-api/pkg/common"
+)
+
+// @Summary   Shows users with search and pagination
+// @Tags      Admin
+// @Accept    json
+// @Produce   json
+// @Param     search  query     string  false  "Part of the email, username or name"
+// @Param     page    query     int     false  "Page number, starts from 1"
+// @Param     limit   query     int     false  "Number of users on the page, all users without the limit and the cursor"
+// @Success   200     {object}  models.ApiShowUsers
+// @Failure   400     {object}  models.ApiError
+// @Failure   401     {object}  models.ApiError
+// @Failure   403     {object}  models.ApiError
+// @Failure   500     {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /admin/users [get]
+func (h *Handler) ShowUsers(c *gin.Context) {
+	page, pageErr := queryInt(c, "page", 1)
+	limit, limitErr := queryInt(c, "limit", models.DEFAULT_PAGE_LIMIT)
+
+	// Input data check
+	switch {
+	case pageErr != nil || page < 1:
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect page.")
+	case limitErr != nil || limit < 1 || limit > models.MAX_PAGE_LIMIT:
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect limit.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	// Get data from the db
+	users, total := h.PostgresDB.GetUsers(c.Query("search"), (page-1)*limit, limit)
+
+	// Generating users output data
+	usersData := make([]models.AdminUserData, 0, len(users))
+	if err := copier.Copy(&usersData, &users); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiShowUsers{
+		Users: usersData,
+		Page:  page,
+		Limit: limit,
+		Total: total,
+	})
+}
+
+// @Summary   Disable user account
+// @Tags      Admin
+// @Accept    json
+// @Produce   json
+// @Param     id   path      int  true  "User id"
+// @Success   200  {object}  models.ApiMessage
+// @Failure   400  {object}  models.ApiError
+// @Failure   401  {object}  models.ApiError
+// @Failure   403  {object}  models.ApiError
+// @Failure   404  {object}  models.ApiError
+// @Failure   500  {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /admin/users/{id}/disable [post]
+func (h *Handler) DisableUser(c *gin.Context) {
+	userData, ok := h.moderatedUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.PostgresDB.UpdateUserDisabled(userData.Id, true); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err := h.RedisClient.AddDisabledUser(c.Request.Context(), userData.Id); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// Revoking the tokens of all user sessions
+	if !h.revokeSessions(c, userData.Id) {
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The account has been disabled.",
+	})
+}
+
+// @Summary   Enable user account
+// @Tags      Admin
+// @Accept    json
+// @Produce   json
+// @Param     id   path      int  true  "User id"
+// @Success   200  {object}  models.ApiMessage
+// @Failure   400  {object}  models.ApiError
+// @Failure   401  {object}  models.ApiError
+// @Failure   403  {object}  models.ApiError
+// @Failure   404  {object}  models.ApiError
+// @Failure   500  {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /admin/users/{id}/enable [post]
+func (h *Handler) EnableUser(c *gin.Context) {
+	userData, ok := h.moderatedUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.PostgresDB.UpdateUserDisabled(userData.Id, false); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err := h.RedisClient.RemoveDisabledUser(c.Request.Context(), userData.Id); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The account has been enabled.",
+	})
+}
+
+// @Summary   Force user password reset
+// @Tags      Admin
+// @Accept    json
+// @Produce   json
+// @Param     id   path      int  true  "User id"
+// @Success   200  {object}  models.ApiMessage
+// @Failure   400  {object}  models.ApiError
+// @Failure   401  {object}  models.ApiError
+// @Failure   403  {object}  models.ApiError
+// @Failure   404  {object}  models.ApiError
+// @Failure   500  {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /admin/users/{id}/reset-password [post]
+func (h *Handler) ForceUserPasswordReset(c *gin.Context) {
+	userData, ok := h.moderatedUser(c)
+	if !ok {
+		return
+	}
+
+	// Replacing the password with a random one, so that the old password stops working
+	password, err := common.NewSecret()
+	if err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err := h.PostgresDB.UpdateUserPassword(password, userData.Email); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// Revoking the tokens of all user sessions and the personal access tokens
+	if !h.revokeCredentials(c, userData.Id) {
+		return
+	}
+
+	// Create a temporary access key to set a new password and then send it
+	if err := h.EmailAuthData.UserPasswordReset(c.Request.Context(), h.RedisClient, userData.Email); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The password has been reset, a reset key was sent to the user's email.",
+	})
+}
+
+// @Summary   Revoke all user sessions
+// @Tags      Admin
+// @Accept    json
+// @Produce   json
+// @Param     id   path      int  true  "User id"
+// @Success   200  {object}  models.ApiMessage
+// @Failure   400  {object}  models.ApiError
+// @Failure   401  {object}  models.ApiError
+// @Failure   403  {object}  models.ApiError
+// @Failure   404  {object}  models.ApiError
+// @Failure   500  {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /admin/users/{id}/sessions [delete]
+func (h *Handler) RevokeUserSessions(c *gin.Context) {
+	userData, ok := h.moderatedUser(c)
+	if !ok {
+		return
+	}
+
+	// Revoking the tokens of all user sessions
+	if !h.revokeSessions(c, userData.Id) {
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The sessions have been revoked.",
+	})
+}
+
+// @Summary   Delete user account
+// @Tags      Admin
+// @Accept    json
+// @Produce   json
+// @Param     id   path      int  true  "User id"
+// @Success   200  {object}  models.ApiMessage
+// @Failure   400  {object}  models.ApiError
+// @Failure   401  {object}  models.ApiError
+// @Failure   403  {object}  models.ApiError
+// @Failure   404  {object}  models.ApiError
+// @Failure   500  {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /admin/users/{id} [delete]
+func (h *Handler) AdminDeleteUser(c *gin.Context) {
+	userData, ok := h.moderatedUser(c)
+	if !ok {
+		return
+	}
+
+	// Delete all user data
+	if err := h.PostgresDB.DeleteUser(h.MinIOClient, c.Request.Context(), userData); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.revokeSessions(c, userData.Id) {
+		return
+	}
+
+	if err := h.RedisClient.RemoveDisabledUser(c.Request.Context(), userData.Id); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The account has been deleted.",
+	})
+}
+
+// moderatedUser gets the user from the id param, admins can't moderate their own account and the accounts of other
+// admins
+func (h *Handler) moderatedUser(c *gin.Context) (userData models.Users, ok bool) {
+	userId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Error when converting id.")
+		return
+	}
+	userData = h.PostgresDB.GetUserById(userId)
+
+	// Input data check
+	switch {
+	case userData.Id == 0:
+		NewErrorResponse(c, http.StatusNotFound, "User not found.")
+	case userData.Id == c.GetInt(userIdKey):
+		NewErrorResponse(c, http.StatusBadRequest, "You can't moderate your own account.")
+	case userData.Role == models.ADMIN_ROLE:
+		NewErrorResponse(c, http.StatusForbidden, "You can't moderate other admins.")
+	}
+
+	return userData, !c.IsAborted()
+}
+
+func queryInt(c *gin.Context, key string, defaultValue int) (int, error) {
+	if c.Query(key) == "" {
+		return defaultValue, nil
+	}
+	return strconv.Atoi(c.Query(key))
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/NKTKLN/todo-api/models"
+)
+
+// @Summary   Assign the task or subtask to a member of the list, the assignee is notified by email
+// @Tags      Working with assignees
+// @Accept    json
+// @Produce   json
+// @Param     AssigneeData  body      models.ApiAssigneeData  true  "Assignee data"
+// @Success   200           {object}  models.ApiMessage
+// @Failure   400           {object}  models.ApiError
+// @Failure   401           {object}  models.ApiError
+// @Failure   403           {object}  models.ApiError
+// @Failure   404           {object}  models.ApiError
+// @Failure   500           {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/assignee/add [post]
+func (h *Handler) AddAssignee(c *gin.Context) {
+	/*
+		Example of JSON received
+
+		{
+		  "task_id": 1023456789,
+		  "user_id": 1023456789
+		}
+	*/
+
+	var data models.ApiAssigneeData
+	if c.ShouldBindJSON(&data) != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+		return
+	}
+	userId := c.GetInt(userIdKey)
+	listId := h.PostgresDB.GetListIdWhereTask(userId, h.PostgresDB.GetRootTaskId(data.TaskId))
+
+	// Input data check
+	switch {
+	case listId == 0:
+		NewErrorResponse(c, http.StatusNotFound, "This task not found.")
+	case !h.checkListRole(listId, userId, models.LIST_EDITOR_ROLE):
+		NewErrorResponse(c, http.StatusForbidden, "Not enough rights.")
+	case h.PostgresDB.GetListRole(listId, data.UserId) == "":
+		NewErrorResponse(c, http.StatusBadRequest, "This user is not a member of the list.")
+	case h.PostgresDB.GetTaskAssignee(data.TaskId, data.UserId).UserId != 0:
+		NewErrorResponse(c, http.StatusBadRequest, "This user is already assigned to the task.")
+	case len(h.PostgresDB.GetTaskAssignees(data.TaskId)) >= models.MAX_TASK_ASSIGNEES:
+		NewErrorResponse(c, http.StatusBadRequest, "Too many assignees for the task.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	// Assigning the task
+	if err := h.PostgresDB.CreateTaskAssignee(models.TaskAssignees{TaskId: data.TaskId, UserId: data.UserId}); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// Users are not notified about the tasks they assign to themselves. The task is already assigned, so a failed
+	// notification is only logged.
+	if data.UserId != userId {
+		assignee := h.PostgresDB.GetUserById(data.UserId)
+		taskName := h.PostgresDB.GetTaskById(data.TaskId).Name
+		listName := h.PostgresDB.GetListById(listId).Name
+		assignerName := h.PostgresDB.GetUserById(userId).Name
+		if err := h.EmailAuthData.TaskAssigned(assignee.Email, taskName, listName, assignerName); err != nil {
+			logrus.Errorf("error when notifying the assignee %d: %s", data.UserId, err.Error())
+		}
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The task has been assigned.",
+	})
+}
+
+// @Summary   Remove the assignee from the task or subtask, the assignees can remove themselves
+// @Tags      Working with assignees
+// @Accept    json
+// @Produce   json
+// @Param     task_id  query     int  true  "Id of the task or subtask"
+// @Param     user_id  query     int  true  "Id of the assignee"
+// @Success   200      {object}  models.ApiMessage
+// @Failure   401      {object}  models.ApiError
+// @Failure   403      {object}  models.ApiError
+// @Failure   404      {object}  models.ApiError
+// @Failure   500      {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/assignee/delete [delete]
+func (h *Handler) DeleteAssignee(c *gin.Context) {
+	taskId, taskErr := strconv.Atoi(c.Query("task_id"))
+	assigneeId, assigneeErr := strconv.Atoi(c.Query("user_id"))
+	userId := c.GetInt(userIdKey)
+
+	// Input data check
+	switch {
+	case taskErr != nil:
+		NewErrorResponse(c, http.StatusInternalServerError, "Error when converting task_id.")
+	case assigneeErr != nil:
+		NewErrorResponse(c, http.StatusInternalServerError, "Error when converting user_id.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	listId := h.PostgresDB.GetListIdWhereTask(userId, h.PostgresDB.GetRootTaskId(taskId))
+	switch {
+	case listId == 0:
+		NewErrorResponse(c, http.StatusNotFound, "This task not found.")
+	case assigneeId != userId && !h.checkListRole(listId, userId, models.LIST_EDITOR_ROLE):
+		NewErrorResponse(c, http.StatusForbidden, "Not enough rights.")
+	case h.PostgresDB.GetTaskAssignee(taskId, assigneeId).UserId == 0:
+		NewErrorResponse(c, http.StatusNotFound, "This assignee not found.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	// Removing the assignee
+	if err := h.PostgresDB.DeleteTaskAssignee(models.TaskAssignees{TaskId: taskId, UserId: assigneeId}); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The assignee has been removed from the task.",
+	})
+}
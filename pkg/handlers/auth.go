@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 	"regexp"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"golang.org/x/crypto/bcrypt"
@@ -18,6 +19,7 @@ import (
 // @Success  200       {object}  models.ApiMessage
 // @Failure  400       {object}  models.ApiError
 // @Failure  404       {object}  models.ApiError
+// @Failure  429       {object}  models.ApiError
 // @Failure  500       {object}  models.ApiError
 // @Router   /auth/sign-up [post]
 func (h *Handler) SignUp(c *gin.Context) {
@@ -26,6 +28,7 @@ func (h *Handler) SignUp(c *gin.Context) {
 
 		{
 		  "email": "nktkln@example.com",
+		  "invite": "1023456789",
 		  "name": "NKTKLN",
 		  "password": "StRon9Pa$$w0rd",
 		  "username": "nktkln"
@@ -44,6 +47,8 @@ func (h *Handler) SignUp(c *gin.Context) {
 		NewErrorResponse(c, http.StatusBadRequest, "Mail is already in use.")
 	case !h.PostgresDB.CheckUserUsername(data.Username):
 		NewErrorResponse(c, http.StatusBadRequest, "Username is already in use.")
+	case data.Invite != "" && !h.RedisClient.CheckListInvite(c.Request.Context(), data.Invite, data.Email):
+		NewErrorResponse(c, http.StatusBadRequest, "Time has expired, your invitation key is not valid.")
 	}
 	if c.IsAborted() {
 		return
@@ -65,6 +70,10 @@ func (h *Handler) SignUp(c *gin.Context) {
 		return
 	}
 
+	if !h.limitEmailSending(c, data.Email) {
+		return
+	}
+
 	// Sending an email verification code to a user
 	if err := h.EmailAuthData.UserEmailVerification(c.Request.Context(), h.RedisClient, data); err != nil {
 		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
@@ -80,13 +89,14 @@ func (h *Handler) SignUp(c *gin.Context) {
 // @Tags      Authorization
 // @Accept    json
 // @Produce   json
-// @Param     key  query     string  true  "Verification key"
-// @Success   200  {object}  models.UserTokens
-// @Failure   400  {object}  models.ApiError
-// @Failure   500  {object}  models.ApiError
+// @Param     key     query     string  true   "Verification key"
+// @Param     device  query     string  false  "Device name"
+// @Success   200     {object}  models.UserTokens
+// @Failure   400     {object}  models.ApiError
+// @Failure   500     {object}  models.ApiError
 // @Router    /auth/verify [get]
 func (h *Handler) VerifySignUp(c *gin.Context) {
-	userParam, err := h.RedisClient.GetUserData(c.Request.Context(), c.Query("key"))
+	userParam, err := h.RedisClient.GetSignUpData(c.Request.Context(), c.Query("key"))
 
 	// Input data check
 	switch {
@@ -102,20 +112,32 @@ func (h *Handler) VerifySignUp(c *gin.Context) {
 	}
 
 	// Adding a new user to the database
-	userId, err := h.PostgresDB.CrateUser(userParam)
+	userId, err := h.PostgresDB.CrateUser(models.Users{Email: userParam.Email, Password: userParam.Password, Name: userParam.Name, Username: userParam.Username})
 	if err != nil {
 		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	// Creating new token for user
-	accessToken, refreshToken, err := h.RedisClient.CreateTokens(c.Request.Context(), userId)
-	if err != nil {
-		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
-		return
+	// Joining the list the user was invited to, an expired invitation, another email or a full list doesn't stop the
+	// sign up
+	if userParam.Invite != "" {
+		invite, err := h.RedisClient.GetListInvite(c.Request.Context(), userParam.Invite)
+		if err == nil && strings.EqualFold(invite.Email, userParam.Email) && h.PostgresDB.GetListById(invite.ListId).Id != 0 &&
+			h.PostgresDB.GetListMembersCount(invite.ListId) < models.MAX_LIST_MEMBERS {
+			if err := h.PostgresDB.CreateListMember(models.ListMembers{ListId: invite.ListId, UserId: userId, Role: invite.Role}); err != nil {
+				NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+				return
+			}
+		}
 	}
 
-	if err := h.RedisClient.DeleteEmailData(c.Request.Context(), c.Query("key")); err != nil {
+	// Creating new session for user
+	accessToken, refreshToken, err := h.RedisClient.CreateSession(c.Request.Context(), models.Sessions{
+		UserId:    userId,
+		Device:    c.Query("device"),
+		UserAgent: c.Request.UserAgent(),
+	})
+	if err != nil {
 		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -132,7 +154,10 @@ func (h *Handler) VerifySignUp(c *gin.Context) {
 // @Produce  json
 // @Param    LoginData  body      models.UserLoginData  true  "User data"
 // @Success  200        {object}  models.UserTokens
+// @Success  202        {object}  models.ApiTwoFactorChallenge
 // @Failure  400        {object}  models.ApiError
+// @Failure  403        {object}  models.ApiError
+// @Failure  429        {object}  models.ApiError
 // @Failure  500        {object}  models.ApiError
 // @Router   /auth/sign-in [post]
 func (h *Handler) SignIn(c *gin.Context) {
@@ -140,6 +165,7 @@ func (h *Handler) SignIn(c *gin.Context) {
 		Example of JSON received
 
 		{
+		  "device": "iPhone 13",
 		  "email": "nktkln@example.com",
 		  "password": "StRon9Pa$$w0rd"
 		}
@@ -153,14 +179,53 @@ func (h *Handler) SignIn(c *gin.Context) {
 		return
 	}
 
+	// Checking the limits of sign in attempts
+	emailKey, ipKey := signInLimitKey(data.Email), signInIpLimitKey(c)
+	if !h.checkLimits(c, emailKey, ipKey) {
+		return
+	}
+
 	// User data check
 	userData := h.PostgresDB.GetUserByEmail(data.Email)
 	if bcrypt.CompareHashAndPassword([]byte(userData.Password), []byte(data.Password)) != nil {
-		NewErrorResponse(c, http.StatusBadRequest, "Wrong email or password.")
+		h.signInFailed(c, userData, emailKey, ipKey, "Wrong email or password.")
 		return
 	}
 
-	accessToken, refreshToken, err := h.RedisClient.CheckTokens(c.Request.Context(), userData.Id)
+	if userData.Disabled {
+		NewErrorResponse(c, http.StatusForbidden, "Account is disabled.")
+		return
+	}
+
+	// Users with two-factor authentication have to pass a challenge first, the limit is reset after it
+	if userData.TotpEnabled {
+		challenge, err := h.RedisClient.CreateChallenge(c.Request.Context(), models.Challenges{
+			UserId:    userData.Id,
+			Device:    data.Device,
+			UserAgent: c.Request.UserAgent(),
+		})
+		if err != nil {
+			NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		c.JSON(http.StatusAccepted, models.ApiTwoFactorChallenge{
+			Challenge: challenge,
+		})
+		return
+	}
+
+	if err := h.RedisClient.ResetLimit(c.Request.Context(), emailKey); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// Creating new session for user
+	accessToken, refreshToken, err := h.RedisClient.CreateSession(c.Request.Context(), models.Sessions{
+		UserId:    userData.Id,
+		Device:    data.Device,
+		UserAgent: c.Request.UserAgent(),
+	})
 	if err != nil {
 		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
@@ -171,3 +236,56 @@ func (h *Handler) SignIn(c *gin.Context) {
 		RefreshToken: refreshToken,
 	})
 }
+
+// @Summary   Sign out of the current session
+// @Tags      Authorization
+// @Accept    json
+// @Produce   json
+// @Success   200  {object}  models.ApiMessage
+// @Failure   401  {object}  models.ApiError
+// @Failure   500  {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /auth/sign-out [post]
+func (h *Handler) SignOut(c *gin.Context) {
+	// Revoking the tokens of the current session
+	if err := h.RedisClient.DeleteSession(c.Request.Context(), c.GetInt(userIdKey), c.GetString(sessionIdKey)); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "You have been signed out.",
+	})
+}
+
+// @Summary   Sign out of all sessions
+// @Tags      Authorization
+// @Accept    json
+// @Produce   json
+// @Success   200  {object}  models.ApiMessage
+// @Failure   401  {object}  models.ApiError
+// @Failure   500  {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /auth/sign-out-all [post]
+func (h *Handler) SignOutAll(c *gin.Context) {
+	// Revoking the tokens of all user sessions
+	if !h.revokeSessions(c, c.GetInt(userIdKey)) {
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "You have been signed out of all sessions.",
+	})
+}
+
+// @Summary  Public keys for verifying tokens
+// @Tags     Authorization
+// @Accept   json
+// @Produce  json
+// @Success  200  {object}  models.JWKS
+// @Router   /.well-known/jwks.json [get]
+func (h *Handler) ShowJWKS(c *gin.Context) {
+	c.JSON(http.StatusOK, h.JWTKeys.JWKS())
+}
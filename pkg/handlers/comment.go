@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/NKTKLN/todo-api/models"
+	"github.com/NKTKLN/todo-api/pkg/common"
+)
+
+// @Summary   Comment the task or subtask, the mentioned members of the list are notified by email
+// @Tags      Working with comments
+// @Accept    json
+// @Produce   json
+// @Param     CommentData  body      models.ApiCommentData  true  "Comment data, members are mentioned as @username"
+// @Success   200          {object}  models.ApiMessage
+// @Failure   400          {object}  models.ApiError
+// @Failure   401          {object}  models.ApiError
+// @Failure   403          {object}  models.ApiError
+// @Failure   404          {object}  models.ApiError
+// @Failure   500          {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/comment/add [post]
+func (h *Handler) AddComment(c *gin.Context) {
+	/*
+		Example of JSON received
+
+		{
+		  "task_id": 1023456789,
+		  "text": "@nktkln the design is ready"
+		}
+	*/
+
+	var data models.ApiCommentData
+	if c.ShouldBindJSON(&data) != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+		return
+	}
+	userId := c.GetInt(userIdKey)
+	listId := h.PostgresDB.GetListIdWhereTask(userId, h.PostgresDB.GetRootTaskId(data.TaskId))
+	data.Text = strings.TrimSpace(data.Text)
+
+	// Input data check
+	switch {
+	case listId == 0:
+		NewErrorResponse(c, http.StatusNotFound, "This task not found.")
+	case !h.checkListRole(listId, userId, models.LIST_EDITOR_ROLE):
+		NewErrorResponse(c, http.StatusForbidden, "Not enough rights.")
+	}
+	if c.IsAborted() || !checkCommentText(c, data.Text) {
+		return
+	}
+
+	// Creating the comment
+	_, err := h.PostgresDB.CreateTaskComment(models.TaskComments{
+		TaskId:    data.TaskId,
+		UserId:    userId,
+		Text:      data.Text,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.notifyMentions(listId, data.TaskId, userId, data.Text, common.ParseMentions(data.Text))
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The comment has been added.",
+	})
+}
+
+// @Summary   Edit the comment, only the author can edit it and only the newly mentioned members are notified
+// @Tags      Working with comments
+// @Accept    json
+// @Produce   json
+// @Param     CommentData  body      models.CommentEditData  true  "New comment data"
+// @Success   200          {object}  models.ApiMessage
+// @Failure   400          {object}  models.ApiError
+// @Failure   401          {object}  models.ApiError
+// @Failure   403          {object}  models.ApiError
+// @Failure   404          {object}  models.ApiError
+// @Failure   500          {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/comment/edit [put]
+func (h *Handler) EditComment(c *gin.Context) {
+	/*
+		Example of JSON received
+
+		{
+		  "id": 1023456789,
+		  "text": "@nktkln the design is ready, take a look"
+		}
+	*/
+
+	var data models.CommentEditData
+	if c.ShouldBindJSON(&data) != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+		return
+	}
+	userId := c.GetInt(userIdKey)
+	comment := h.PostgresDB.GetTaskCommentById(data.Id)
+	listId := h.commentListId(comment, userId)
+	data.Text = strings.TrimSpace(data.Text)
+
+	// Input data check
+	switch {
+	case listId == 0:
+		NewErrorResponse(c, http.StatusNotFound, "This comment not found.")
+	case comment.UserId != userId || !h.checkListRole(listId, userId, models.LIST_EDITOR_ROLE):
+		NewErrorResponse(c, http.StatusForbidden, "Not enough rights.")
+	}
+	if c.IsAborted() || !checkCommentText(c, data.Text) {
+		return
+	}
+
+	// Updating the comment
+	editedAt := time.Now()
+	err := h.PostgresDB.UpdateTaskComment(models.TaskComments{Id: data.Id, Text: data.Text, EditedAt: &editedAt})
+	if err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// The members mentioned before the edit were already notified
+	mentioned := make(map[string]bool)
+	for _, username := range common.ParseMentions(comment.Text) {
+		mentioned[username] = true
+	}
+	var newMentions []string
+	for _, username := range common.ParseMentions(data.Text) {
+		if !mentioned[username] {
+			newMentions = append(newMentions, username)
+		}
+	}
+	h.notifyMentions(listId, comment.TaskId, userId, data.Text, newMentions)
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The comment has been edited.",
+	})
+}
+
+// @Summary   Delete the comment, the author and the admins of the list can delete it
+// @Tags      Working with comments
+// @Accept    json
+// @Produce   json
+// @Param     comment_id  query     int  true  "The id of the comment to be deleted"
+// @Success   200         {object}  models.ApiMessage
+// @Failure   401         {object}  models.ApiError
+// @Failure   403         {object}  models.ApiError
+// @Failure   404         {object}  models.ApiError
+// @Failure   500         {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/comment/delete [delete]
+func (h *Handler) DeleteComment(c *gin.Context) {
+	commentId, err := strconv.Atoi(c.Query("comment_id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Error when converting comment_id.")
+		return
+	}
+	userId := c.GetInt(userIdKey)
+	comment := h.PostgresDB.GetTaskCommentById(commentId)
+	listId := h.commentListId(comment, userId)
+
+	// Input data check
+	switch {
+	case listId == 0:
+		NewErrorResponse(c, http.StatusNotFound, "This comment not found.")
+	case !h.checkListRole(listId, userId, models.LIST_ADMIN_ROLE) &&
+		(comment.UserId != userId || !h.checkListRole(listId, userId, models.LIST_EDITOR_ROLE)):
+		NewErrorResponse(c, http.StatusForbidden, "Not enough rights.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	// Deleting the comment
+	if err := h.PostgresDB.DeleteTaskComment(commentId); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The comment has been deleted.",
+	})
+}
+
+// @Summary   Shows the comments of the task or subtask from the oldest one
+// @Tags      Working with comments
+// @Accept    json
+// @Produce   json
+// @Param     task_id  query     int     true   "Id of the task or subtask"
+// @Param     limit    query     int     false  "Page size, 20 by default and 100 at most"
+// @Param     cursor   query     string  false  "Cursor of the page from the next_cursor of the previous page"
+// @Success   200      {object}  models.ApiShowComments
+// @Failure   400      {object}  models.ApiError
+// @Failure   401      {object}  models.ApiError
+// @Failure   404      {object}  models.ApiError
+// @Failure   500      {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/comment/show [get]
+func (h *Handler) ShowComments(c *gin.Context) {
+	userId := c.GetInt(userIdKey)
+	taskId, err := strconv.Atoi(c.Query("task_id"))
+
+	// Input data check
+	switch {
+	case err != nil:
+		NewErrorResponse(c, http.StatusInternalServerError, "Error when converting task_id.")
+	case h.PostgresDB.GetListIdWhereTask(userId, h.PostgresDB.GetRootTaskId(taskId)) == 0:
+		NewErrorResponse(c, http.StatusNotFound, "This task not found.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	after, limit, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	// Get data from the db, the times are shown in the user timezone
+	comments, next := h.PostgresDB.GetTaskComments(taskId, after, limit)
+	location := h.userLocation(userId)
+	for index, comment := range comments {
+		comments[index].CreatedAt = localTime(comment.CreatedAt, location)
+		comments[index].EditedAt = localTime(comment.EditedAt, location)
+	}
+
+	c.JSON(http.StatusOK, models.ApiShowComments{
+		Comments:   comments,
+		NextCursor: common.EncodeCursor(next),
+	})
+}
+
+// commentListId returns the list of the commented task, 0 if the comment doesn't exist or the user has no access to it
+func (h *Handler) commentListId(comment models.TaskComments, userId int) int {
+	if comment.Id == 0 {
+		return 0
+	}
+	return h.PostgresDB.GetListIdWhereTask(userId, h.PostgresDB.GetRootTaskId(comment.TaskId))
+}
+
+func checkCommentText(c *gin.Context, text string) bool {
+	switch {
+	case text == "":
+		NewErrorResponse(c, http.StatusBadRequest, "Empty comment.")
+	case utf8.RuneCountInString(text) > models.MAX_COMMENT_LENGTH:
+		NewErrorResponse(c, http.StatusBadRequest, "The comment is too long.")
+	}
+	return !c.IsAborted()
+}
+
+// notifyMentions notifies the mentioned members of the list by email, the author is not notified about their own
+// mentions. The comment is already saved, so a failed notification is only logged.
+func (h *Handler) notifyMentions(listId, taskId, authorId int, text string, usernames []string) {
+	if len(usernames) == 0 {
+		return
+	}
+
+	taskName := h.PostgresDB.GetTaskById(taskId).Name
+	authorName := h.PostgresDB.GetUserById(authorId).Name
+	for _, username := range usernames {
+		user := h.PostgresDB.GetUserByUsername(username)
+		if user.Id == 0 || user.Id == authorId || h.PostgresDB.GetListRole(listId, user.Id) == "" {
+			continue
+		}
+		if err := h.EmailAuthData.TaskMentioned(user.Email, taskName, authorName, text); err != nil {
+			logrus.Errorf("error when notifying the mentioned user %d: %s", user.Id, err.Error())
+		}
+	}
+}
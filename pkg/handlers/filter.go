@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/NKTKLN/todo-api/models"
+	"github.com/NKTKLN/todo-api/pkg/common"
+)
+
+// tasksFilter reads the tasks filter from the query parameters, the times are taken in the location
+func tasksFilter(c *gin.Context, location *time.Location) (filter models.TasksFilter, ok bool) {
+	filter.Category = c.Query("category")
+	filter.Search = c.Query("q")
+
+	done, doneErr := queryBool(c, "done")
+	special, specialErr := queryBool(c, "special")
+	dueBefore, dueBeforeErr := queryTime(c, "due_before", location)
+	dueAfter, dueAfterErr := queryTime(c, "due_after", location)
+
+	// Input data check
+	switch {
+	case doneErr != nil:
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect done.")
+	case specialErr != nil:
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect special.")
+	case dueBeforeErr != nil || dueAfterErr != nil:
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect time format.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	filter.Done, filter.Special = done, special
+	filter.DueBefore, filter.DueAfter = dueBefore, dueAfter
+	return filter, true
+}
+
+// pageParams reads the sort key of the last row of the previous page and the page size. The request without the
+// limit and the cursor returns all rows like before the pagination, the next pages have the default size.
+func pageParams(c *gin.Context) (after []interface{}, limit int, ok bool) {
+	limit, limitErr := queryInt(c, "limit", 0)
+	after, cursorErr := common.DecodeCursor(c.Query("cursor"))
+
+	// Input data check
+	switch {
+	case limitErr != nil || limit > models.MAX_PAGE_LIMIT || (c.Query("limit") != "" && limit < 1):
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect limit.")
+	case cursorErr != nil:
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect cursor.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	if limit == 0 && c.Query("cursor") != "" {
+		limit = models.DEFAULT_PAGE_LIMIT
+	}
+	return after, limit, true
+}
+
+func queryBool(c *gin.Context, key string) (*bool, error) {
+	if c.Query(key) == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseBool(c.Query(key))
+	if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+func queryTime(c *gin.Context, key string, location *time.Location) (time.Time, error) {
+	if c.Query(key) == "" {
+		return time.Time{}, nil
+	}
+	return time.ParseInLocation("2006-01-02 15:04", c.Query(key), location)
+}
@@ -17,6 +17,8 @@ type Handler struct {
 	RedisClient   db.RedisClient
 	MinIOClient   db.MinIOClient
 	EmailAuthData common.EmailProvider
+	Limits        models.LimitSettings
+	JWTKeys       *common.JWTKeys
 }
 
 func (h *Handler) InitRoutes() *gin.Engine {
@@ -25,67 +27,209 @@ func (h *Handler) InitRoutes() *gin.Engine {
 	r.Use(CORSMiddleware())
 
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	r.GET("/.well-known/jwks.json", h.ShowJWKS)
 
 	auth := r.Group("/auth")
 	{
 		auth.POST("/sign-up", h.SignUp)
 		auth.GET("/verify", h.VerifySignUp)
 		auth.POST("/sign-in", h.SignIn)
+		auth.POST("/sign-in/2fa", h.SignInTwoFactor)
+		auth.POST("/sign-out", h.AuthMiddleware(), SessionMiddleware(), h.SignOut)
+		auth.POST("/sign-out-all", h.AuthMiddleware(), SessionMiddleware(), h.SignOutAll)
 	}
 
-	user := r.Group("/user")
+	public := r.Group("/user")
 	{
+		publicUpdate := public.Group("/settings/update")
+		{
+			publicUpdate.PATCH("/email", h.UpdateUserEmail)
+			publicUpdate.PATCH("/password", h.UpdateUserPassword)
+			publicUpdate.PUT("/token", h.UpdateUserToken)
+		}
+
+		publicReset := public.Group("/settings/reset")
+		{
+			publicReset.POST("/password", h.ResetUserPassword)
+		}
+
+		publicShowData := public.Group("/show")
+		{
+			publicShowData.GET("/icon", h.GetUserIcon)
+			publicShowData.GET("/data-by-id", h.GetUserData)
+		}
+	}
+
+	user := r.Group("/user", h.AuthMiddleware())
+	{
+		sessions := user.Group("/sessions", SessionMiddleware())
+		{
+			sessions.GET("", h.ShowSessions)
+			sessions.DELETE("/:id", h.DeleteSession)
+		}
+
+		twoFactor := user.Group("/2fa", SessionMiddleware())
+		{
+			twoFactor.POST("/enable", h.EnableTwoFactor)
+			twoFactor.POST("/confirm", h.ConfirmTwoFactor)
+			twoFactor.POST("/disable", h.DisableTwoFactor)
+		}
+
+		tokens := user.Group("/tokens", SessionMiddleware())
+		{
+			tokens.POST("", h.CreatePersonalToken)
+			tokens.GET("", h.ShowPersonalTokens)
+			tokens.DELETE("/:id", h.DeletePersonalToken)
+		}
+
 		settigns := user.Group("/settings")
 		{
-			update := settigns.Group("/update")
+			update := settigns.Group("/update", ScopeMiddleware("user:write"))
 			{
 				update.PATCH("/name", h.EditUserName)
 				update.PATCH("/username", h.EditUserUsername)
-				update.PATCH("/email", h.UpdateUserEmail)
-				update.PATCH("/password", h.UpdateUserPassword)
-				update.PUT("/token", h.UpdateUserToken)
+				update.PATCH("/timezone", h.EditUserTimezone)
+				update.PATCH("/rollup", h.EditUserCompletionRollup)
 				update.PUT("/icon", h.UpdateUserIcon)
 			}
+
+			reset := settigns.Group("/reset", SessionMiddleware())
+			{
+				reset.POST("/email", h.ResetUserEmail)
+			}
 		}
 
-		showData := user.Group("/show")
+		showData := user.Group("/show", ScopeMiddleware("user:read"))
 		{
-			showData.GET("/icon", h.GetUserIcon)
-			showData.GET("/data-by-id", h.GetUserData)
 			showData.GET("/data-by-token", h.GetUserDataByToken)
 		}
 
 		deleteData := user.Group("/delete")
 		{
-			deleteData.DELETE("/icon", h.DeleteUserIcon)
-			deleteData.DELETE("/account", h.DeleteUser)
+			deleteData.DELETE("/icon", ScopeMiddleware("user:write"), h.DeleteUserIcon)
+			deleteData.DELETE("/account", SessionMiddleware(), h.DeleteUser)
+		}
+	}
+
+	admin := r.Group("/admin", h.AuthMiddleware(), SessionMiddleware(), h.AdminMiddleware())
+	{
+		users := admin.Group("/users")
+		{
+			users.GET("", h.ShowUsers)
+			users.POST("/:id/disable", h.DisableUser)
+			users.POST("/:id/enable", h.EnableUser)
+			users.POST("/:id/reset-password", h.ForceUserPasswordReset)
+			users.DELETE("/:id/sessions", h.RevokeUserSessions)
+			users.DELETE("/:id", h.AdminDeleteUser)
 		}
 	}
 
-	todo := r.Group("/todo")
+	todo := r.Group("/todo", h.AuthMiddleware())
 	{
 		list := todo.Group("/list")
 		{
-			list.POST("/add", h.AddList)
-			list.DELETE("/delete", h.DeleteList)
-			list.PUT("/edit", h.EditList)
-			list.GET("/show", h.ShowLists)
+			list.POST("/add", ScopeMiddleware("lists:write"), h.AddList)
+			list.DELETE("/delete", ScopeMiddleware("lists:write"), h.DeleteList)
+			list.PUT("/edit", ScopeMiddleware("lists:write"), h.EditList)
+			list.GET("/show", ScopeMiddleware("lists:read"), h.ShowLists)
+
+			members := list.Group("/members")
+			{
+				members.GET("", ScopeMiddleware("lists:read"), h.ShowListMembers)
+				members.POST("", ScopeMiddleware("lists:write"), h.AddListMember)
+				members.PATCH("", ScopeMiddleware("lists:write"), h.EditListMember)
+				members.DELETE("", ScopeMiddleware("lists:write"), h.DeleteListMember)
+				members.DELETE("/leave", ScopeMiddleware("lists:write"), h.LeaveList)
+				members.POST("/invite", ScopeMiddleware("lists:write"), h.InviteListMember)
+				members.POST("/accept", ScopeMiddleware("lists:write"), h.AcceptListInvite)
+			}
+		}
+
+		workspace := todo.Group("/workspace")
+		{
+			workspace.POST("/add", ScopeMiddleware("lists:write"), h.AddWorkspace)
+			workspace.DELETE("/delete", ScopeMiddleware("lists:write"), h.DeleteWorkspace)
+			workspace.PUT("/edit", ScopeMiddleware("lists:write"), h.EditWorkspace)
+			workspace.GET("/show", ScopeMiddleware("lists:read"), h.ShowWorkspaces)
+
+			members := workspace.Group("/members")
+			{
+				members.GET("", ScopeMiddleware("lists:read"), h.ShowWorkspaceMembers)
+				members.POST("", ScopeMiddleware("lists:write"), h.AddWorkspaceMember)
+				members.PATCH("", ScopeMiddleware("lists:write"), h.EditWorkspaceMember)
+				members.DELETE("", ScopeMiddleware("lists:write"), h.DeleteWorkspaceMember)
+			}
 		}
 
 		task := todo.Group("/task")
 		{
-			task.POST("/add", h.AddTask)
-			task.DELETE("/delete", h.DeleteTask)
-			task.PUT("/edit", h.EditTask)
-			task.GET("/show", h.ShowTasks)
+			task.POST("/add", ScopeMiddleware("tasks:write"), h.AddTask)
+			task.DELETE("/delete", ScopeMiddleware("tasks:write"), h.DeleteTask)
+			task.PUT("/edit", ScopeMiddleware("tasks:write"), h.EditTask)
+			task.PUT("/skip", ScopeMiddleware("tasks:write"), h.SkipTaskOccurrence)
+			task.PUT("/end-series", ScopeMiddleware("tasks:write"), h.EndTaskSeries)
+			task.PUT("/move", ScopeMiddleware("tasks:write"), h.MoveTask)
+			task.PUT("/demote", ScopeMiddleware("tasks:write"), h.DemoteTask)
+			task.GET("/show", ScopeMiddleware("tasks:read"), h.ShowTasks)
+			task.GET("/tree", ScopeMiddleware("tasks:read"), h.ShowTaskTree)
 		}
 
 		subtask := todo.Group("/subtask")
 		{
-			subtask.POST("/add", h.AddSubtask)
-			subtask.DELETE("/delete", h.DeleteSubtask)
-			subtask.PUT("/edit", h.EditSubtask)
-			subtask.GET("/show", h.ShowSubtasks)
+			subtask.POST("/add", ScopeMiddleware("tasks:write"), h.AddSubtask)
+			subtask.DELETE("/delete", ScopeMiddleware("tasks:write"), h.DeleteSubtask)
+			subtask.PUT("/edit", ScopeMiddleware("tasks:write"), h.EditSubtask)
+			subtask.PUT("/promote", ScopeMiddleware("tasks:write"), h.PromoteSubtask)
+			subtask.GET("/show", ScopeMiddleware("tasks:read"), h.ShowSubtasks)
+		}
+
+		reminder := todo.Group("/reminder")
+		{
+			reminder.POST("/add", ScopeMiddleware("tasks:write"), h.AddReminder)
+			reminder.DELETE("/delete", ScopeMiddleware("tasks:write"), h.DeleteReminder)
+			reminder.GET("/show", ScopeMiddleware("tasks:read"), h.ShowReminders)
+		}
+
+		assignee := todo.Group("/assignee")
+		{
+			assignee.POST("/add", ScopeMiddleware("tasks:write"), h.AddAssignee)
+			assignee.DELETE("/delete", ScopeMiddleware("tasks:write"), h.DeleteAssignee)
+		}
+
+		comment := todo.Group("/comment")
+		{
+			comment.POST("/add", ScopeMiddleware("tasks:write"), h.AddComment)
+			comment.PUT("/edit", ScopeMiddleware("tasks:write"), h.EditComment)
+			comment.DELETE("/delete", ScopeMiddleware("tasks:write"), h.DeleteComment)
+			comment.GET("/show", ScopeMiddleware("tasks:read"), h.ShowComments)
+		}
+
+		filter := todo.Group("/filter")
+		{
+			filter.POST("/add", ScopeMiddleware("lists:write"), h.AddSavedFilter)
+			filter.DELETE("/delete", ScopeMiddleware("lists:write"), h.DeleteSavedFilter)
+			filter.PUT("/edit", ScopeMiddleware("lists:write"), h.EditSavedFilter)
+			filter.GET("/show", ScopeMiddleware("tasks:read"), h.ShowSavedFilterTasks)
+		}
+
+		tags := todo.Group("/tags")
+		{
+			tags.GET("", ScopeMiddleware("tasks:read"), h.ShowTags)
+			tags.POST("", ScopeMiddleware("tasks:write"), h.AddTag)
+			tags.PATCH("", ScopeMiddleware("tasks:write"), h.EditTag)
+			tags.DELETE("", ScopeMiddleware("tasks:write"), h.DeleteTag)
+			tags.GET("/autocomplete", ScopeMiddleware("tasks:read"), h.AutocompleteTags)
+		}
+
+		todo.GET("/search", ScopeMiddleware("lists:read"), ScopeMiddleware("tasks:read"), h.Search)
+
+		views := todo.Group("/views", ScopeMiddleware("tasks:read"))
+		{
+			views.GET("/today", h.ShowTodayTasks)
+			views.GET("/upcoming", h.ShowUpcomingTasks)
+			views.GET("/overdue", h.ShowOverdueTasks)
+			views.GET("/starred", h.ShowStarredTasks)
+			views.GET("/assigned-to-me", h.ShowAssignedTasks)
 		}
 	}
 
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/NKTKLN/todo-api/models"
+)
+
+// checkLimits aborts the request with 429 if one of the keys is locked
+func (h *Handler) checkLimits(c *gin.Context, keys ...string) bool {
+	for _, key := range keys {
+		retryAfter, err := h.RedisClient.GetLimitLock(c.Request.Context(), key)
+		if err != nil {
+			NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+			return false
+		}
+
+		if retryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			NewErrorResponse(c, http.StatusTooManyRequests, "Too many attempts, try again later.")
+			return false
+		}
+	}
+
+	return true
+}
+
+// limitEmailSending protects the email address and the mail server from spam
+func (h *Handler) limitEmailSending(c *gin.Context, email string) bool {
+	emailKey, ipKey := "email:"+strings.ToLower(email), "email-ip:"+c.ClientIP()
+	if !h.checkLimits(c, emailKey, ipKey) {
+		return false
+	}
+
+	// Every sent email is an attempt
+	for key, limit := range map[string]models.Limit{emailKey: h.Limits.Email, ipKey: h.Limits.EmailIP} {
+		if _, err := h.RedisClient.HitLimit(c.Request.Context(), key, limit); err != nil {
+			NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+			return false
+		}
+	}
+
+	return true
+}
+
+func signInLimitKey(email string) string {
+	return "sign-in:" + strings.ToLower(email)
+}
+
+func signInIpLimitKey(c *gin.Context) string {
+	return "sign-in-ip:" + c.ClientIP()
+}
+
+// signInFailed counts a failed sign in attempt and notifies the owner of a locked account. Wrong passwords and wrong
+// second factor codes are counted together, so a known password doesn't give new attempts to guess the code.
+func (h *Handler) signInFailed(c *gin.Context, userData models.Users, emailKey, ipKey, message string) {
+	lockout, err := h.RedisClient.HitLimit(c.Request.Context(), emailKey, h.Limits.SignIn)
+	if err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if _, err := h.RedisClient.HitLimit(c.Request.Context(), ipKey, h.Limits.SignInIP); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if lockout > 0 && userData.Id != 0 {
+		if err := h.EmailAuthData.AccountLocked(userData.Email, lockout); err != nil {
+			NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	NewErrorResponse(c, http.StatusBadRequest, message)
+}
@@ -7,18 +7,22 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/NKTKLN/todo-api/models"
+	"github.com/NKTKLN/todo-api/pkg/common"
 )
 
-// @Summary   Create list
+// @Summary   Create list, editors of the workspace can create lists in it
 // @Tags      Working with lists
 // @Accept    json
 // @Produce   json
 // @Param     ListData  body      models.ApiListData  true  "List data"
 // @Success   200       {object}  models.ApiMessage
 // @Failure   400       {object}  models.ApiError
+// @Failure   401       {object}  models.ApiError
+// @Failure   403       {object}  models.ApiError
 // @Failure   404       {object}  models.ApiError
 // @Failure   500       {object}  models.ApiError
 // @Security  token
+// @Security  bearer
 // @Router    /todo/list/add [post]
 func (h *Handler) AddList(c *gin.Context) {
 	/*
@@ -26,30 +30,35 @@ func (h *Handler) AddList(c *gin.Context) {
 
 		{
 			"comment": "Products needed for the party",
-			"name": "List of products"
+			"name": "List of products",
+			"workspace_id": 1023456789
 		}
 	*/
 
 	var data models.ApiListData
-	userId := h.RedisClient.VerifyToken(c.Request.Context(), c.GetHeader("token"))
+	userId := c.GetInt(userIdKey)
 
 	// Input data check
 	switch {
 	case c.ShouldBindJSON(&data) != nil:
 		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
-	case userId == 0:
-		NewErrorResponse(c, http.StatusNotFound, "Inactive user.")
 	case data.Name == "":
 		NewErrorResponse(c, http.StatusBadRequest, "Empty name.")
 	case len(data.Name) > 32: 
 		NewErrorResponse(c, http.StatusBadRequest, "A name longer than 32 characters.")
 	}
-	if c.IsAborted() {
+	if c.IsAborted() || data.WorkspaceId != 0 && !h.checkWorkspaceRole(c, data.WorkspaceId, models.LIST_EDITOR_ROLE) {
 		return
 	}
 
+	// Workspace lists belong to the workspace instead of the user
+	list := models.Lists{UserId: userId, Name: data.Name, Comment: data.Comment}
+	if data.WorkspaceId != 0 {
+		list.UserId, list.WorkspaceId = 0, data.WorkspaceId
+	}
+
 	// Create new list
-	err := h.PostgresDB.CreateList(models.Lists{UserId: userId, Name: data.Name, Comment: data.Comment})
+	err := h.PostgresDB.CreateList(list)
 	if err != nil {
 		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
 	}
@@ -59,36 +68,39 @@ func (h *Handler) AddList(c *gin.Context) {
 	})
 }
 
-// @Summary   Delete list
+// @Summary   Delete list, only the owner can delete the list. Admins of the workspace own its lists.
 // @Tags      Working with lists
 // @Accept    json
 // @Produce   json
 // @Param     list_id  query     int  true  "The id of the list to be deleted"
 // @Success   200      {object}  models.ApiMessage
+// @Failure   401      {object}  models.ApiError
+// @Failure   403      {object}  models.ApiError
 // @Failure   404      {object}  models.ApiError
 // @Failure   500      {object}  models.ApiError
 // @Security  token
+// @Security  bearer
 // @Router   /todo/list/delete [delete]
 func (h *Handler) DeleteList(c *gin.Context) {
 	listId, err := strconv.Atoi(c.Query("list_id"))
-	userId := h.RedisClient.VerifyToken(c.Request.Context(), c.GetHeader("token"))
+	userId := c.GetInt(userIdKey)
 	listData := h.PostgresDB.GetListByIdAndUserId(listId, userId)
 
 	// Input data check
 	switch {
 	case err != nil:
 		NewErrorResponse(c, http.StatusInternalServerError, "Error when converting list_id.")
-	case userId == 0:
-		NewErrorResponse(c, http.StatusNotFound, "Inactive user.")
 	case listData.Id == 0:
 		NewErrorResponse(c, http.StatusNotFound, "This list not found.")
+	case listData.UserId != userId && !h.checkListRole(listId, userId, models.LIST_OWNER_ROLE):
+		NewErrorResponse(c, http.StatusForbidden, "Only the owner can delete the list.")
 	}
 	if c.IsAborted() {
 		return
 	}
 
 	// Updating list index
-	for _, editList := range h.PostgresDB.GetListsForEditIndex(userId, listData.Index) {
+	for _, editList := range h.PostgresDB.GetListsForEditIndex(listData) {
 		err := h.PostgresDB.UpdateListIndex(editList.Id, editList.Index-1)
 		if err != nil {
 			NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
@@ -107,16 +119,19 @@ func (h *Handler) DeleteList(c *gin.Context) {
 	})
 }
 
-// @Summary   Edit list
+// @Summary   Edit list, only admins of the list can edit it. The index is the place among the lists of the owner or workspace.
 // @Tags      Working with lists
 // @Accept    json
 // @Produce   json
 // @Param     ListData  body      models.ListEditData  true  "List data"
 // @Success   200       {object}  models.ApiMessage
 // @Failure   400       {object}  models.ApiError
+// @Failure   401       {object}  models.ApiError
+// @Failure   403       {object}  models.ApiError
 // @Failure   404       {object}  models.ApiError
 // @Failure   500       {object}  models.ApiError
 // @Security  token
+// @Security  bearer
 // @Router    /todo/list/edit [put]
 func (h *Handler) EditList(c *gin.Context) {
 	/*
@@ -131,21 +146,22 @@ func (h *Handler) EditList(c *gin.Context) {
 	*/
 
 	var data models.ListsData
-	userId := h.RedisClient.VerifyToken(c.Request.Context(), c.GetHeader("token"))
+	if c.ShouldBindJSON(&data) != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+		return
+	}
+	if !h.checkListAdmin(c, data.Id) {
+		return
+	}
+	listData := h.PostgresDB.GetListById(data.Id)
 
 	// Input data check
 	switch {
-	case c.ShouldBindJSON(&data) != nil:
-		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
-	case userId == 0:
-		NewErrorResponse(c, http.StatusNotFound, "Inactive user.")
-	case h.PostgresDB.GetListByIdAndUserId(data.Id, userId).Id == 0:
-		NewErrorResponse(c, http.StatusNotFound, "This list not found.")
 	case data.Name == "":
 		NewErrorResponse(c, http.StatusBadRequest, "Empty name.")
 	case len(data.Name) > 32: 
 		NewErrorResponse(c, http.StatusBadRequest, "A name longer than 32 characters.")
-	case data.Index < 0 || data.Index > h.PostgresDB.GetListMaxIndex(userId):
+	case data.Index < 0 || data.Index > h.PostgresDB.GetListMaxIndex(listData):
 		NewErrorResponse(c, http.StatusBadRequest, "Incorrect index.")
 	}
 	if c.IsAborted() {
@@ -160,8 +176,8 @@ func (h *Handler) EditList(c *gin.Context) {
 	}
 
 	// Updating list index
-	if h.PostgresDB.GetListById(data.Id).Index != data.Index {
-		err := h.PostgresDB.UpdateListsIndexes(models.Lists{Id: data.Id, UserId: userId, Index: data.Index})
+	if listData.Index != data.Index {
+		err := h.PostgresDB.UpdateListsIndexes(models.Lists{Id: data.Id, UserId: listData.UserId, WorkspaceId: listData.WorkspaceId, Index: data.Index})
 		if err != nil {
 			NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
 			return
@@ -173,24 +189,68 @@ func (h *Handler) EditList(c *gin.Context) {
 	})
 }
 
-// @Summary   Shows all lists created by the user
+// @Summary   Shows saved filters, lists created by the user, lists shared with the user and lists of the user workspaces grouped by the workspace
 // @Tags      Working with lists
 // @Accept    json
 // @Produce   json
-// @Success   200  {object}  models.ApiShowLists
-// @Failure   404  {object}  models.ApiError
+// @Param     q       query     string  false  "Part of the name or comment"
+// @Param     limit   query     int     false  "Number of lists on the page, all lists without the limit and the cursor"
+// @Param     cursor  query     string  false  "Cursor of the page from next_cursor"
+// @Success   200     {object}  models.ApiShowLists
+// @Failure   400     {object}  models.ApiError
+// @Failure   401     {object}  models.ApiError
 // @Security  token
+// @Security  bearer
 // @Router    /todo/list/show [get]
 func (h *Handler) ShowLists(c *gin.Context) {
-	// Checking a token in the db
-	userId := h.RedisClient.VerifyToken(c.Request.Context(), c.GetHeader("token"))
-	if userId == 0 {
-		NewErrorResponse(c, http.StatusNotFound, "Inactive user.")
+	userId := c.GetInt(userIdKey)
+	after, limit, ok := pageParams(c)
+	if !ok {
 		return
 	}
-	
-	// Get data from the db
+
+	// Saved filters go before the lists, the key of the page starts with the type of the last row
+	pageType := models.FILTER_TYPE
+	if len(after) > 0 {
+		pageType, _ = after[0].(string)
+		after = after[1:]
+	}
+
+	var lists []models.ListsData
+	var next []interface{}
+	if pageType == models.FILTER_TYPE {
+		filters, nextFilter := h.PostgresDB.GetUserSavedFilters(userId, c.Query("q"), after, limit)
+		for _, filter := range filters {
+			lists = append(lists, models.ListsData{Id: filter.Id, Type: models.FILTER_TYPE, Name: filter.Name, Query: filter.Query})
+		}
+
+		switch {
+		case nextFilter != nil:
+			next = append([]interface{}{models.FILTER_TYPE}, nextFilter...)
+		case limit > 0 && len(lists) == limit:
+			// The next page starts with the first list if there is one
+			if userLists, _ := h.PostgresDB.GetUserLists(userId, c.Query("q"), nil, 1); len(userLists) > 0 {
+				next = []interface{}{models.LIST_TYPE}
+			}
+		}
+		pageType, after = models.LIST_TYPE, nil
+	}
+
+	// A zero limit returns all filters and lists
+	listsLimit := limit - len(lists)
+	if limit == 0 {
+		listsLimit = 0
+	}
+	if pageType == models.LIST_TYPE && (limit == 0 || listsLimit > 0) {
+		userLists, nextList := h.PostgresDB.GetUserLists(userId, c.Query("q"), after, listsLimit)
+		lists = append(lists, userLists...)
+		if nextList != nil {
+			next = append([]interface{}{models.LIST_TYPE}, nextList...)
+		}
+	}
+
 	c.JSON(http.StatusOK, models.ApiShowLists{
-		Lists: h.PostgresDB.GetAllUserLists(userId),
+		Lists:      lists,
+		NextCursor: common.EncodeCursor(next),
 	})
 }
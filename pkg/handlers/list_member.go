@@ -0,0 +1,409 @@
+package handlers
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/NKTKLN/todo-api/models"
+)
+
+// @Summary   Shows the owner and members of the list
+// @Tags      Working with list members
+// @Accept    json
+// @Produce   json
+// @Param     list_id  query     int  true  "List id"
+// @Success   200      {object}  models.ApiShowListMembers
+// @Failure   401      {object}  models.ApiError
+// @Failure   404      {object}  models.ApiError
+// @Failure   500      {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/list/members [get]
+func (h *Handler) ShowListMembers(c *gin.Context) {
+	listId, err := strconv.Atoi(c.Query("list_id"))
+
+	// Input data check
+	switch {
+	case err != nil:
+		NewErrorResponse(c, http.StatusInternalServerError, "Error when converting list_id.")
+	case h.PostgresDB.GetListByIdAndUserId(listId, c.GetInt(userIdKey)).Id == 0:
+		NewErrorResponse(c, http.StatusNotFound, "This list not found.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiShowListMembers{
+		Members: h.PostgresDB.GetListMembers(listId),
+	})
+}
+
+// @Summary   Add a member to the list by the username or email, only admins of the list can add members
+// @Tags      Working with list members
+// @Accept    json
+// @Produce   json
+// @Param     MemberData  body      models.ListMemberAddData  true  "Member data"
+// @Success   200         {object}  models.ApiMessage
+// @Failure   400         {object}  models.ApiError
+// @Failure   401         {object}  models.ApiError
+// @Failure   403         {object}  models.ApiError
+// @Failure   404         {object}  models.ApiError
+// @Failure   500         {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/list/members [post]
+func (h *Handler) AddListMember(c *gin.Context) {
+	/*
+		Example of JSON received
+
+		{
+		  "list_id": 1023456789,
+		  "role": "editor",
+		  "user": "NKTKLN"
+		}
+	*/
+
+	var data models.ListMemberAddData
+	if c.ShouldBindJSON(&data) != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+		return
+	}
+	if !h.checkListAdmin(c, data.ListId) {
+		return
+	}
+
+	// Usernames can't contain the @
+	var user models.Users
+	if strings.Contains(data.User, "@") {
+		user = h.PostgresDB.GetUserByEmail(data.User)
+	} else {
+		user = h.PostgresDB.GetUserByUsername(data.User)
+	}
+
+	// Input data check
+	switch {
+	case !checkMemberRole(data.Role):
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect role.")
+	case user.Id == 0:
+		NewErrorResponse(c, http.StatusNotFound, "User not found.")
+	case h.PostgresDB.GetListRole(data.ListId, user.Id) != "":
+		NewErrorResponse(c, http.StatusBadRequest, "This user is already a member of the list.")
+	case h.PostgresDB.GetListMembersCount(data.ListId) >= models.MAX_LIST_MEMBERS:
+		NewErrorResponse(c, http.StatusBadRequest, "Too many members.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	// Adding the member
+	if err := h.PostgresDB.CreateListMember(models.ListMembers{ListId: data.ListId, UserId: user.Id, Role: data.Role}); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The member has been added to the list.",
+	})
+}
+
+// @Summary   Invite a person without an account to the list by email, only admins of the list can send invitations
+// @Tags      Working with list members
+// @Accept    json
+// @Produce   json
+// @Param     InviteData  body      models.ListInviteData  true  "Invitation data"
+// @Success   200         {object}  models.ApiMessage
+// @Failure   400         {object}  models.ApiError
+// @Failure   401         {object}  models.ApiError
+// @Failure   403         {object}  models.ApiError
+// @Failure   404         {object}  models.ApiError
+// @Failure   429         {object}  models.ApiError
+// @Failure   500         {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/list/members/invite [post]
+func (h *Handler) InviteListMember(c *gin.Context) {
+	/*
+		Example of JSON received
+
+		{
+		  "email": "nktkln@example.com",
+		  "list_id": 1023456789,
+		  "role": "editor"
+		}
+	*/
+
+	var data models.ListInviteData
+	if c.ShouldBindJSON(&data) != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+		return
+	}
+	if !h.checkListAdmin(c, data.ListId) {
+		return
+	}
+
+	emailMatched, err := regexp.MatchString(`^\w*@\w*[.]\w*$`, data.Email)
+
+	// Input data check
+	switch {
+	case !checkMemberRole(data.Role):
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect role.")
+	case !emailMatched || err != nil:
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect email.")
+	case !h.PostgresDB.CheckUserEmail(data.Email):
+		NewErrorResponse(c, http.StatusBadRequest, "This user already has an account, add them by the email.")
+	case h.PostgresDB.GetListMembersCount(data.ListId) >= models.MAX_LIST_MEMBERS:
+		NewErrorResponse(c, http.StatusBadRequest, "Too many members.")
+	}
+	if c.IsAborted() || !h.limitEmailSending(c, data.Email) {
+		return
+	}
+
+	// Sending the invitation
+	invite := models.ListInvites{ListId: data.ListId, Email: data.Email, Role: data.Role}
+	inviterName := h.PostgresDB.GetUserById(c.GetInt(userIdKey)).Name
+	listName := h.PostgresDB.GetListById(data.ListId).Name
+	if err := h.EmailAuthData.ListInvite(c.Request.Context(), h.RedisClient, invite, inviterName, listName); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The invitation has been sent.",
+	})
+}
+
+// @Summary   Accept the invitation to the list sent to the user's email, new users pass the invitation key to the sign up instead
+// @Tags      Working with list members
+// @Accept    json
+// @Produce   json
+// @Param     key  query     string  true  "Invitation key"
+// @Success   200  {object}  models.ApiMessage
+// @Failure   400  {object}  models.ApiError
+// @Failure   401  {object}  models.ApiError
+// @Failure   404  {object}  models.ApiError
+// @Failure   500  {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/list/members/accept [post]
+func (h *Handler) AcceptListInvite(c *gin.Context) {
+	userId := c.GetInt(userIdKey)
+
+	// The invitation can be accepted only by the user with the email it was sent to
+	if !h.RedisClient.CheckListInvite(c.Request.Context(), c.Query("key"), h.PostgresDB.GetUserById(userId).Email) {
+		NewErrorResponse(c, http.StatusBadRequest, "Time has expired, your key is not valid.")
+		return
+	}
+
+	invite, err := h.RedisClient.GetListInvite(c.Request.Context(), c.Query("key"))
+
+	// Input data check
+	switch {
+	case err != nil:
+		NewErrorResponse(c, http.StatusBadRequest, "Time has expired, your key is not valid.")
+	case h.PostgresDB.GetListById(invite.ListId).Id == 0:
+		NewErrorResponse(c, http.StatusNotFound, "This list not found.")
+	case h.PostgresDB.GetListRole(invite.ListId, userId) != "":
+		NewErrorResponse(c, http.StatusBadRequest, "You are already a member of the list.")
+	case h.PostgresDB.GetListMembersCount(invite.ListId) >= models.MAX_LIST_MEMBERS:
+		NewErrorResponse(c, http.StatusBadRequest, "Too many members.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	// Joining the list
+	if err := h.PostgresDB.CreateListMember(models.ListMembers{ListId: invite.ListId, UserId: userId, Role: invite.Role}); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "You have joined the list.",
+	})
+}
+
+// @Summary   Change the role of the list member, only admins of the list can change roles
+// @Tags      Working with list members
+// @Accept    json
+// @Produce   json
+// @Param     MemberData  body      models.ListMemberEditData  true  "Member data"
+// @Success   200         {object}  models.ApiMessage
+// @Failure   400         {object}  models.ApiError
+// @Failure   401         {object}  models.ApiError
+// @Failure   403         {object}  models.ApiError
+// @Failure   404         {object}  models.ApiError
+// @Failure   500         {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/list/members [patch]
+func (h *Handler) EditListMember(c *gin.Context) {
+	/*
+		Example of JSON received
+
+		{
+		  "list_id": 1023456789,
+		  "role": "viewer",
+		  "user_id": 1023456789
+		}
+	*/
+
+	var data models.ListMemberEditData
+	if c.ShouldBindJSON(&data) != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+		return
+	}
+	if !h.checkListAdmin(c, data.ListId) {
+		return
+	}
+
+	// Input data check
+	switch {
+	case !checkMemberRole(data.Role):
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect role.")
+	case h.PostgresDB.GetListMember(data.ListId, data.UserId).UserId == 0:
+		NewErrorResponse(c, http.StatusNotFound, "This member not found.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	// Updating the member role
+	if err := h.PostgresDB.UpdateListMemberRole(models.ListMembers{ListId: data.ListId, UserId: data.UserId, Role: data.Role}); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The member role has been changed.",
+	})
+}
+
+// @Summary   Remove the member from the list, only admins of the list can remove members
+// @Tags      Working with list members
+// @Accept    json
+// @Produce   json
+// @Param     list_id  query     int  true  "List id"
+// @Param     user_id  query     int  true  "Id of the member to be removed"
+// @Success   200      {object}  models.ApiMessage
+// @Failure   401      {object}  models.ApiError
+// @Failure   403      {object}  models.ApiError
+// @Failure   404      {object}  models.ApiError
+// @Failure   500      {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/list/members [delete]
+func (h *Handler) DeleteListMember(c *gin.Context) {
+	listId, listErr := strconv.Atoi(c.Query("list_id"))
+	memberId, memberErr := strconv.Atoi(c.Query("user_id"))
+
+	// Input data check
+	switch {
+	case listErr != nil:
+		NewErrorResponse(c, http.StatusInternalServerError, "Error when converting list_id.")
+	case memberErr != nil:
+		NewErrorResponse(c, http.StatusInternalServerError, "Error when converting user_id.")
+	}
+	if c.IsAborted() || !h.checkListAdmin(c, listId) {
+		return
+	}
+
+	member := h.PostgresDB.GetListMember(listId, memberId)
+	if member.UserId == 0 {
+		NewErrorResponse(c, http.StatusNotFound, "This member not found.")
+		return
+	}
+
+	// Removing the member
+	if err := h.PostgresDB.DeleteListMember(member); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The member has been removed from the list.",
+	})
+}
+
+// @Summary   Leave the shared list
+// @Tags      Working with list members
+// @Accept    json
+// @Produce   json
+// @Param     list_id  query     int  true  "List id"
+// @Success   200      {object}  models.ApiMessage
+// @Failure   400      {object}  models.ApiError
+// @Failure   401      {object}  models.ApiError
+// @Failure   404      {object}  models.ApiError
+// @Failure   500      {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/list/members/leave [delete]
+func (h *Handler) LeaveList(c *gin.Context) {
+	listId, err := strconv.Atoi(c.Query("list_id"))
+	userId := c.GetInt(userIdKey)
+	role := h.PostgresDB.GetListRole(listId, userId)
+
+	// Input data check
+	switch {
+	case err != nil:
+		NewErrorResponse(c, http.StatusInternalServerError, "Error when converting list_id.")
+	case role == "":
+		NewErrorResponse(c, http.StatusNotFound, "This list not found.")
+	case role == models.LIST_OWNER_ROLE:
+		NewErrorResponse(c, http.StatusBadRequest, "The owner can't leave the list.")
+	case h.PostgresDB.GetListMember(listId, userId).UserId == 0:
+		NewErrorResponse(c, http.StatusBadRequest, "The workspace lists are left together with the workspace.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	// Leaving the list
+	if err := h.PostgresDB.DeleteListMember(models.ListMembers{ListId: listId, UserId: userId}); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "You have left the list.",
+	})
+}
+
+// checkListAdmin checks that the user is the admin or owner of the list
+func (h *Handler) checkListAdmin(c *gin.Context, listId int) bool {
+	switch role := h.PostgresDB.GetListRole(listId, c.GetInt(userIdKey)); {
+	case role == "":
+		NewErrorResponse(c, http.StatusNotFound, "This list not found.")
+	case !hasListRole(role, models.LIST_ADMIN_ROLE):
+		NewErrorResponse(c, http.StatusForbidden, "Not enough rights.")
+	}
+	return !c.IsAborted()
+}
+
+// checkListRole checks that the user has the required role or a higher one in the list
+func (h *Handler) checkListRole(listId, userId int, required string) bool {
+	return hasListRole(h.PostgresDB.GetListRole(listId, userId), required)
+}
+
+func hasListRole(role, required string) bool {
+	rank := listRoleRank(role)
+	return rank >= 0 && rank >= listRoleRank(required)
+}
+
+// listRoleRank returns the position of the role in the list roles, -1 for an unknown role
+func listRoleRank(role string) int {
+	for rank, listRole := range models.LIST_ROLES {
+		if listRole == role {
+			return rank
+		}
+	}
+	return -1
+}
+
+// checkMemberRole checks the role given to the member, nobody can be made the owner
+func checkMemberRole(role string) bool {
+	return role != models.LIST_OWNER_ROLE && listRoleRank(role) >= 0
+}
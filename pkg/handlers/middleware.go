@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/NKTKLN/todo-api/models"
+	"github.com/NKTKLN/todo-api/pkg/common"
+)
+
+const (
+	userIdKey    = "userId"
+	sessionIdKey = "sessionId"
+	scopesKey    = "scopes"
+)
+
+func (h *Handler) AuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := accessToken(c)
+		if token == "" {
+			c.Header("WWW-Authenticate", `Bearer realm="todo-api"`)
+			NewErrorResponse(c, http.StatusUnauthorized, "Missing access token.")
+			return
+		}
+
+		// Personal access tokens are stored hashed in the postgres
+		if strings.HasPrefix(token, models.PERSONAL_TOKEN_PREFIX) {
+			tokenData := h.PostgresDB.GetPersonalTokenByHash(common.HashToken(token))
+			if tokenData.Id == 0 || (tokenData.ExpiresAt != nil && tokenData.ExpiresAt.Before(time.Now())) {
+				invalidTokenResponse(c)
+				return
+			}
+
+			// The postgres is already asked for the token, its disabled flag doesn't depend on the redis
+			if h.PostgresDB.GetUserById(tokenData.UserId).Disabled {
+				NewErrorResponse(c, http.StatusForbidden, "Account is disabled.")
+				return
+			}
+
+			c.Set(userIdKey, tokenData.UserId)
+			c.Set(scopesKey, []string(tokenData.Scopes))
+		} else {
+			// Checking a token in the db
+			session := h.RedisClient.VerifySession(c.Request.Context(), token)
+			if session.UserId == 0 {
+				invalidTokenResponse(c)
+				return
+			}
+
+			c.Set(userIdKey, session.UserId)
+			c.Set(sessionIdKey, session.Id)
+		}
+
+		// Tokens of disabled users are not accepted
+		if h.RedisClient.IsUserDisabled(c.Request.Context(), c.GetInt(userIdKey)) {
+			NewErrorResponse(c, http.StatusForbidden, "Account is disabled.")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// AdminMiddleware closes the route for everyone except admins
+func (h *Handler) AdminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if h.PostgresDB.GetUserById(c.GetInt(userIdKey)).Role != models.ADMIN_ROLE {
+			NewErrorResponse(c, http.StatusForbidden, "Admin access required.")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// ScopeMiddleware limits personal access tokens to the routes of their scopes, sessions have access to everything
+func ScopeMiddleware(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scopes, ok := c.Get(scopesKey)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		for _, tokenScope := range scopes.([]string) {
+			if tokenScope == scope {
+				c.Next()
+				return
+			}
+		}
+
+		c.Header("WWW-Authenticate", fmt.Sprintf(`Bearer realm="todo-api", error="insufficient_scope", scope="%s"`, scope))
+		NewErrorResponse(c, http.StatusForbidden, "Insufficient token scope.")
+	}
+}
+
+// SessionMiddleware closes the route for personal access tokens
+func SessionMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get(scopesKey); ok {
+			NewErrorResponse(c, http.StatusForbidden, "Not available for personal access tokens.")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func invalidTokenResponse(c *gin.Context) {
+	c.Header("WWW-Authenticate", `Bearer realm="todo-api", error="invalid_token"`)
+	NewErrorResponse(c, http.StatusUnauthorized, "Invalid access token.")
+}
+
+// accessToken takes the token from the Authorization header or from the legacy token header
+func accessToken(c *gin.Context) string {
+	if authorization := c.GetHeader("Authorization"); authorization != "" {
+		scheme, token, found := strings.Cut(authorization, " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") {
+			return ""
+		}
+		return strings.TrimSpace(token)
+	}
+
+	return c.GetHeader("token")
+}
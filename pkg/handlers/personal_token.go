@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/NKTKLN/todo-api/models"
+	"github.com/NKTKLN/todo-api/pkg/common"
+)
+
+// @Summary   Create personal access token
+// @Tags      Personal access tokens
+// @Accept    json
+// @Produce   json
+// @Param     TokenData  body      models.PersonalTokenData  true  "Token data"
+// @Success   200        {object}  models.ApiPersonalToken
+// @Failure   400        {object}  models.ApiError
+// @Failure   401        {object}  models.ApiError
+// @Failure   403        {object}  models.ApiError
+// @Failure   500        {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /user/tokens [post]
+func (h *Handler) CreatePersonalToken(c *gin.Context) {
+	/*
+		Example of JSON received
+
+		{
+		  "expires_at": "2077-12-10 13:13",
+		  "name": "CI",
+		  "scopes": ["lists:read", "tasks:write"]
+		}
+	*/
+
+	var data models.PersonalTokenData
+
+	// Input data check
+	switch {
+	case c.ShouldBindJSON(&data) != nil:
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+	case data.Name == "":
+		NewErrorResponse(c, http.StatusBadRequest, "Empty name.")
+	case len(data.Name) > 32:
+		NewErrorResponse(c, http.StatusBadRequest, "A name longer than 32 characters.")
+	case len(data.Scopes) == 0:
+		NewErrorResponse(c, http.StatusBadRequest, "Empty scopes.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	for _, scope := range data.Scopes {
+		if _, ex := models.TOKEN_SCOPES[scope]; !ex {
+			NewErrorResponse(c, http.StatusBadRequest, "Incorrect scope: "+scope+".")
+			return
+		}
+	}
+
+	// A token without an expiration time lives until it is revoked
+	var expiresAt *time.Time
+	if data.ExpiresAt != "" {
+		endTime, err := time.Parse("2006-01-02 15:04", data.ExpiresAt)
+		if err != nil || endTime.Before(time.Now()) {
+			NewErrorResponse(c, http.StatusBadRequest, "Incorrect expiration time.")
+			return
+		}
+		expiresAt = &endTime
+	}
+
+	// Generating a new token, only its hash is stored
+	token, err := common.NewPersonalToken(models.PERSONAL_TOKEN_PREFIX)
+	if err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	tokenData := models.PersonalTokens{
+		UserId:    c.GetInt(userIdKey),
+		Name:      data.Name,
+		TokenHash: common.HashToken(token),
+		Scopes:    data.Scopes,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}
+
+	tokenData.Id, err = h.PostgresDB.CreatePersonalToken(tokenData)
+	if err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiPersonalToken{
+		Token:             token,
+		PersonalTokenInfo: personalTokenInfo(tokenData),
+	})
+}
+
+// @Summary   Shows all personal access tokens of the user
+// @Tags      Personal access tokens
+// @Accept    json
+// @Produce   json
+// @Success   200  {object}  models.ApiShowPersonalTokens
+// @Failure   401  {object}  models.ApiError
+// @Failure   403  {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /user/tokens [get]
+func (h *Handler) ShowPersonalTokens(c *gin.Context) {
+	// Get data from the db
+	tokens := h.PostgresDB.GetAllUserPersonalTokens(c.GetInt(userIdKey))
+
+	// Generating tokens output data
+	tokensData := make([]models.PersonalTokenInfo, 0, len(tokens))
+	for _, token := range tokens {
+		tokensData = append(tokensData, personalTokenInfo(token))
+	}
+
+	c.JSON(http.StatusOK, models.ApiShowPersonalTokens{
+		Tokens: tokensData,
+	})
+}
+
+// @Summary   Revoke personal access token
+// @Tags      Personal access tokens
+// @Accept    json
+// @Produce   json
+// @Param     id   path      int  true  "The id of the token to be revoked"
+// @Success   200  {object}  models.ApiMessage
+// @Failure   401  {object}  models.ApiError
+// @Failure   403  {object}  models.ApiError
+// @Failure   404  {object}  models.ApiError
+// @Failure   500  {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /user/tokens/{id} [delete]
+func (h *Handler) DeletePersonalToken(c *gin.Context) {
+	tokenId, err := strconv.Atoi(c.Param("id"))
+
+	// Input data check
+	switch {
+	case err != nil:
+		NewErrorResponse(c, http.StatusInternalServerError, "Error when converting id.")
+	case h.PostgresDB.GetPersonalTokenByIdAndUserId(tokenId, c.GetInt(userIdKey)).Id == 0:
+		NewErrorResponse(c, http.StatusNotFound, "This token not found.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	// Delete token
+	if err := h.PostgresDB.DeletePersonalToken(tokenId); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The token has been revoked.",
+	})
+}
+
+func personalTokenInfo(token models.PersonalTokens) models.PersonalTokenInfo {
+	tokenInfo := models.PersonalTokenInfo{
+		Id:        token.Id,
+		Name:      token.Name,
+		Scopes:    token.Scopes,
+		CreatedAt: token.CreatedAt.Format("2006-01-02 15:04"),
+	}
+	if token.ExpiresAt != nil {
+		tokenInfo.ExpiresAt = token.ExpiresAt.Format("2006-01-02 15:04")
+	}
+
+	return tokenInfo
+}
@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/NKTKLN/todo-api/models"
+)
+
+// @Summary   Create task reminder
+// @Tags      Working with reminders
+// @Accept    json
+// @Produce   json
+// @Param     ReminderData  body      models.ApiReminderData  true  "Reminder data, either before in minutes or remind_at"
+// @Success   200           {object}  models.ApiMessage
+// @Failure   400           {object}  models.ApiError
+// @Failure   401           {object}  models.ApiError
+// @Failure   404           {object}  models.ApiError
+// @Failure   500           {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/reminder/add [post]
+func (h *Handler) AddReminder(c *gin.Context) {
+	/*
+		Example of JSON received
+
+		{
+		  "before": 30,
+		  "task_id": 1023456789
+		}
+	*/
+
+	var data models.ApiReminderData
+	userId := c.GetInt(userIdKey)
+
+	// Input data check
+	switch {
+	case c.ShouldBindJSON(&data) != nil:
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+	case h.PostgresDB.GetListIdWhereTask(userId, h.PostgresDB.GetRootTaskId(data.TaskId)) == 0:
+		NewErrorResponse(c, http.StatusNotFound, "This task not found.")
+	case (data.Before == nil) == (data.RemindAt == ""):
+		NewErrorResponse(c, http.StatusBadRequest, "Specify either before or remind_at.")
+	case data.Before != nil && *data.Before < 0:
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect before.")
+	case len(h.PostgresDB.GetTaskReminders(data.TaskId)) >= models.MAX_TASK_REMINDERS:
+		NewErrorResponse(c, http.StatusBadRequest, "Too many reminders for the task.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	// Reminders relative to the end time move together with it
+	reminder := models.Reminders{TaskId: data.TaskId, UserId: userId, Before: data.Before}
+	if data.Before != nil {
+		endTime := h.PostgresDB.GetTaskById(data.TaskId).EndTime
+		if endTime.IsZero() {
+			NewErrorResponse(c, http.StatusBadRequest, "The task has no end time.")
+			return
+		}
+		reminder.RemindAt = reminderTime(endTime, *data.Before)
+	} else {
+		remindAt, err := time.ParseInLocation("2006-01-02 15:04", data.RemindAt, h.userLocation(userId))
+		if err != nil {
+			NewErrorResponse(c, http.StatusBadRequest, "Incorrect time format.")
+			return
+		}
+		reminder.RemindAt = remindAt
+	}
+	if reminder.RemindAt.Before(time.Now()) {
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect time.")
+		return
+	}
+
+	// Create new reminder and add it to the queue
+	reminderId, err := h.PostgresDB.CreateReminder(reminder)
+	if err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if err = h.RedisClient.ScheduleReminder(c.Request.Context(), reminderId, reminder.RemindAt); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "Reminder added to db.",
+	})
+}
+
+// @Summary   Delete task reminder
+// @Tags      Working with reminders
+// @Accept    json
+// @Produce   json
+// @Param     reminder_id  query     int  true  "The id of the reminder to be deleted"
+// @Success   200          {object}  models.ApiMessage
+// @Failure   401          {object}  models.ApiError
+// @Failure   404          {object}  models.ApiError
+// @Failure   500          {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/reminder/delete [delete]
+func (h *Handler) DeleteReminder(c *gin.Context) {
+	reminderId, err := strconv.Atoi(c.Query("reminder_id"))
+
+	// Input data check
+	switch {
+	case err != nil:
+		NewErrorResponse(c, http.StatusInternalServerError, "Error when converting reminder_id.")
+	case h.PostgresDB.GetReminderByIdAndUserId(reminderId, c.GetInt(userIdKey)).Id == 0:
+		NewErrorResponse(c, http.StatusNotFound, "This reminder not found.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	// Delete reminder
+	if err := h.PostgresDB.DeleteReminder(reminderId); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if err := h.RedisClient.UnscheduleReminder(c.Request.Context(), reminderId); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The reminder has been deleted.",
+	})
+}
+
+// @Summary   Shows all reminders of the task
+// @Tags      Working with reminders
+// @Accept    json
+// @Produce   json
+// @Param     task_id  query     int  true  "Task id with reminders"
+// @Success   200      {object}  models.ApiShowReminders
+// @Failure   401      {object}  models.ApiError
+// @Failure   404      {object}  models.ApiError
+// @Failure   500      {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/reminder/show [get]
+func (h *Handler) ShowReminders(c *gin.Context) {
+	taskId, err := strconv.Atoi(c.Query("task_id"))
+	userId := c.GetInt(userIdKey)
+
+	// Input data check
+	switch {
+	case err != nil:
+		NewErrorResponse(c, http.StatusInternalServerError, "Error when converting task_id.")
+	case h.PostgresDB.GetListIdWhereTask(userId, h.PostgresDB.GetRootTaskId(taskId)) == 0:
+		NewErrorResponse(c, http.StatusNotFound, "This task not found.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	// Generating reminders output data
+	reminders := h.PostgresDB.GetTaskReminders(taskId)
+	location := h.userLocation(userId)
+	remindersData := make([]models.ReminderData, 0, len(reminders))
+	for _, reminder := range reminders {
+		remindersData = append(remindersData, models.ReminderData{
+			Id:       reminder.Id,
+			Before:   reminder.Before,
+			RemindAt: reminder.RemindAt.In(location).Format("2006-01-02 15:04"),
+			Sent:     reminder.Sent,
+		})
+	}
+
+	c.JSON(http.StatusOK, models.ApiShowReminders{
+		Reminders: remindersData,
+	})
+}
+
+// rescheduleReminders moves the reminders relative to the end time of the task
+func (h *Handler) rescheduleReminders(c *gin.Context, taskId int, endTime time.Time) bool {
+	for _, reminder := range h.PostgresDB.GetTaskReminders(taskId) {
+		if reminder.Before == nil {
+			continue
+		}
+
+		remindAt := reminderTime(endTime, *reminder.Before)
+		if err := h.PostgresDB.UpdateReminderTime(reminder.Id, remindAt); err != nil {
+			NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+			return false
+		}
+		if err := h.RedisClient.ScheduleReminder(c.Request.Context(), reminder.Id, remindAt); err != nil {
+			NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+			return false
+		}
+	}
+
+	return true
+}
+
+// unscheduleReminders removes the reminders of the deleted tasks from the queue
+func (h *Handler) unscheduleReminders(c *gin.Context, reminders []models.Reminders) bool {
+	for _, reminder := range reminders {
+		if err := h.RedisClient.UnscheduleReminder(c.Request.Context(), reminder.Id); err != nil {
+			NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+			return false
+		}
+	}
+
+	return true
+}
+
+func reminderTime(endTime time.Time, before int) time.Time {
+	return endTime.Add(-time.Duration(before) * time.Minute)
+}
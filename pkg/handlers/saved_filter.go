@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/NKTKLN/todo-api/models"
+	"github.com/NKTKLN/todo-api/pkg/common"
+)
+
+// @Summary   Create saved filter
+// @Tags      Working with saved filters
+// @Accept    json
+// @Produce   json
+// @Param     FilterData  body      models.ApiSavedFilterData  true  "Filter data"
+// @Success   200         {object}  models.ApiMessage
+// @Failure   400         {object}  models.ApiError
+// @Failure   401         {object}  models.ApiError
+// @Failure   500         {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/filter/add [post]
+func (h *Handler) AddSavedFilter(c *gin.Context) {
+	/*
+		Example of JSON received
+
+		{
+		  "name": "Work this week",
+		  "query": "category contains Work AND not done AND due within 7 days"
+		}
+	*/
+
+	var data models.ApiSavedFilterData
+	userId := c.GetInt(userIdKey)
+
+	// Input data check
+	if c.ShouldBindJSON(&data) != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+		return
+	}
+	if !checkSavedFilter(c, data.Name, data.Query) {
+		return
+	}
+	if filters, _ := h.PostgresDB.GetUserSavedFilters(userId, "", nil, 0); len(filters) >= models.MAX_SAVED_FILTERS {
+		NewErrorResponse(c, http.StatusBadRequest, "Too many saved filters.")
+		return
+	}
+
+	// Create new filter
+	if err := h.PostgresDB.CreateSavedFilter(models.SavedFilters{UserId: userId, Name: data.Name, Query: data.Query}); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "Filter added to db.",
+	})
+}
+
+// @Summary   Delete saved filter
+// @Tags      Working with saved filters
+// @Accept    json
+// @Produce   json
+// @Param     filter_id  query     int  true  "The id of the filter to be deleted"
+// @Success   200        {object}  models.ApiMessage
+// @Failure   401        {object}  models.ApiError
+// @Failure   404        {object}  models.ApiError
+// @Failure   500        {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/filter/delete [delete]
+func (h *Handler) DeleteSavedFilter(c *gin.Context) {
+	filterData, ok := h.savedFilter(c)
+	if !ok {
+		return
+	}
+
+	if err := h.PostgresDB.DeleteSavedFilter(filterData.Id); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The filter has been deleted.",
+	})
+}
+
+// @Summary   Edit saved filter
+// @Tags      Working with saved filters
+// @Accept    json
+// @Produce   json
+// @Param     FilterData  body      models.SavedFilterEditData  true  "Filter data"
+// @Success   200         {object}  models.ApiMessage
+// @Failure   400         {object}  models.ApiError
+// @Failure   401         {object}  models.ApiError
+// @Failure   404         {object}  models.ApiError
+// @Failure   500         {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/filter/edit [put]
+func (h *Handler) EditSavedFilter(c *gin.Context) {
+	/*
+		Example of JSON received
+
+		{
+		  "id": 1023456789,
+		  "name": "Work this month",
+		  "query": "category contains Work AND not done AND due within 30 days"
+		}
+	*/
+
+	var data models.SavedFilterEditData
+	userId := c.GetInt(userIdKey)
+
+	// Input data check
+	switch {
+	case c.ShouldBindJSON(&data) != nil:
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+	case h.PostgresDB.GetSavedFilterByIdAndUserId(data.Id, userId).Id == 0:
+		NewErrorResponse(c, http.StatusNotFound, "This filter not found.")
+	}
+	if c.IsAborted() || !checkSavedFilter(c, data.Name, data.Query) {
+		return
+	}
+
+	// Updating the filter data
+	if err := h.PostgresDB.UpdateSavedFilter(models.SavedFilters{Id: data.Id, Name: data.Name, Query: data.Query}); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "Updating the filter data was successful.",
+	})
+}
+
+// @Summary   Shows tasks from all lists matching the saved filter
+// @Tags      Working with saved filters
+// @Accept    json
+// @Produce   json
+// @Param     filter_id  query     int     true   "Filter id"
+// @Param     limit      query     int     false  "Number of tasks on the page, all tasks without the limit and the cursor"
+// @Param     cursor     query     string  false  "Cursor of the page from next_cursor"
+// @Success   200        {object}  models.ApiShowViewTasks
+// @Failure   400        {object}  models.ApiError
+// @Failure   401        {object}  models.ApiError
+// @Failure   404        {object}  models.ApiError
+// @Failure   500        {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/filter/show [get]
+func (h *Handler) ShowSavedFilterTasks(c *gin.Context) {
+	filterData, ok := h.savedFilter(c)
+	if !ok {
+		return
+	}
+
+	// Saved filters are checked when they are saved
+	filter, err := common.ParseFilter(filterData.Query)
+	if err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	location := h.userLocation(c.GetInt(userIdKey))
+	h.showViewTasks(c, filter.TasksFilter(time.Now().In(location)), location)
+}
+
+// savedFilter returns the user filter from the filter_id parameter
+func (h *Handler) savedFilter(c *gin.Context) (filterData models.SavedFilters, ok bool) {
+	filterId, err := strconv.Atoi(c.Query("filter_id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Error when converting filter_id.")
+		return
+	}
+
+	filterData = h.PostgresDB.GetSavedFilterByIdAndUserId(filterId, c.GetInt(userIdKey))
+	if filterData.Id == 0 {
+		NewErrorResponse(c, http.StatusNotFound, "This filter not found.")
+		return
+	}
+	return filterData, true
+}
+
+func checkSavedFilter(c *gin.Context, name, query string) bool {
+	switch {
+	case name == "":
+		NewErrorResponse(c, http.StatusBadRequest, "Empty name.")
+	case len(name) > 32:
+		NewErrorResponse(c, http.StatusBadRequest, "A name longer than 32 characters.")
+	}
+	if c.IsAborted() {
+		return false
+	}
+
+	if _, err := common.ParseFilter(query); err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect filter: "+err.Error()+".")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/NKTKLN/todo-api/models"
+	"github.com/NKTKLN/todo-api/pkg/common"
+)
+
+// @Summary   Full-text search in the names and comments of lists, tasks and subtasks
+// @Tags      Search
+// @Accept    json
+// @Produce   json
+// @Param     q       query     string  true   "Searched words, every word is matched as a prefix"
+// @Param     limit   query     int     false  "Number of results on the page, all results without the limit and the cursor"
+// @Param     cursor  query     string  false  "Cursor of the page from next_cursor"
+// @Success   200     {object}  models.ApiSearchResults
+// @Failure   400     {object}  models.ApiError
+// @Failure   401     {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/search [get]
+func (h *Handler) Search(c *gin.Context) {
+	query := common.PrefixSearchQuery(c.Query("q"))
+	if query == "" {
+		NewErrorResponse(c, http.StatusBadRequest, "Empty search query.")
+		return
+	}
+
+	after, limit, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	// Get data from the db
+	results, next := h.PostgresDB.Search(c.GetInt(userIdKey), query, after, limit)
+	c.JSON(http.StatusOK, models.ApiSearchResults{
+		Results:    results,
+		NextCursor: common.EncodeCursor(next),
+	})
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/NKTKLN/todo-api/models"
+)
+
+// @Summary   Shows all active sessions of the user
+// @Tags      User sessions
+// @Accept    json
+// @Produce   json
+// @Success   200  {object}  models.ApiShowSessions
+// @Failure   401  {object}  models.ApiError
+// @Failure   500  {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /user/sessions [get]
+func (h *Handler) ShowSessions(c *gin.Context) {
+	// Get data from the db
+	sessions, err := h.RedisClient.GetUserSessions(c.Request.Context(), c.GetInt(userIdKey))
+	if err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// Generating sessions output data
+	sessionsData := make([]models.SessionData, 0, len(sessions))
+	for _, session := range sessions {
+		sessionsData = append(sessionsData, models.SessionData{
+			Id:         session.Id,
+			Device:     session.Device,
+			UserAgent:  session.UserAgent,
+			CreatedAt:  session.CreatedAt.Format("2006-01-02 15:04"),
+			LastActive: session.LastActive.Format("2006-01-02 15:04"),
+			Current:    session.Id == c.GetString(sessionIdKey),
+		})
+	}
+
+	c.JSON(http.StatusOK, models.ApiShowSessions{
+		Sessions: sessionsData,
+	})
+}
+
+// @Summary   Delete session
+// @Tags      User sessions
+// @Accept    json
+// @Produce   json
+// @Param     id   path      string  true  "The id of the session to be deleted"
+// @Success   200  {object}  models.ApiMessage
+// @Failure   401  {object}  models.ApiError
+// @Failure   404  {object}  models.ApiError
+// @Failure   500  {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /user/sessions/{id} [delete]
+func (h *Handler) DeleteSession(c *gin.Context) {
+	userId := c.GetInt(userIdKey)
+	session, err := h.RedisClient.GetSession(c.Request.Context(), c.Param("id"))
+
+	// Input data check
+	if err != nil || session.UserId != userId {
+		NewErrorResponse(c, http.StatusNotFound, "This session not found.")
+		return
+	}
+
+	// Delete session
+	if err := h.RedisClient.DeleteSession(c.Request.Context(), userId, session.Id); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The session has been deleted.",
+	})
+}
+
+// revokeSessions signs the user out of all sessions
+func (h *Handler) revokeSessions(c *gin.Context, userId int) bool {
+	if err := h.RedisClient.DeleteAccessTokensData(c.Request.Context(), userId); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return false
+	}
+
+	if err := h.RedisClient.DeleteRefreshTokensData(c.Request.Context(), userId); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return false
+	}
+
+	return true
+}
+
+// revokeCredentials signs the user out of all sessions and deletes the personal access tokens after the password is
+// reset, the tokens could be created by someone who knew the old password
+func (h *Handler) revokeCredentials(c *gin.Context, userId int) bool {
+	if !h.revokeSessions(c, userId) {
+		return false
+	}
+
+	if err := h.PostgresDB.DeleteAllUserPersonalTokens(userId); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return false
+	}
+
+	return true
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/NKTKLN/todo-api/models"
+	"github.com/NKTKLN/todo-api/pkg/common"
 )
 
 // @Summary   Create subtask
@@ -17,9 +18,12 @@ import (
 // @Param     SubtaskData  body      models.ApiSubtaskData  true  "Subtask data"
 // @Success   200          {object}  models.ApiMessage
 // @Failure   400          {object}  models.ApiError
+// @Failure   401          {object}  models.ApiError
+// @Failure   403          {object}  models.ApiError
 // @Failure   404          {object}  models.ApiError
 // @Failure   500          {object}  models.ApiError
 // @Security  token
+// @Security  bearer
 // @Router    /todo/subtask/add [post]
 func (h *Handler) AddSubtask(c *gin.Context) {
 	/*
@@ -33,16 +37,20 @@ func (h *Handler) AddSubtask(c *gin.Context) {
 	*/
 
 	var data models.ApiSubtaskData
-	userId := h.RedisClient.VerifyToken(c.Request.Context(), c.GetHeader("token"))
+	userId := c.GetInt(userIdKey)
+
+	if c.ShouldBindJSON(&data) != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+		return
+	}
+	listId := h.PostgresDB.GetListIdWhereTask(userId, h.PostgresDB.GetRootTaskId(data.TaskId))
 
 	// Input data check
 	switch {
-	case c.ShouldBindJSON(&data) != nil:
-		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
-	case userId == 0:
-		NewErrorResponse(c, http.StatusNotFound, "Inactive user.")
-	case h.PostgresDB.GetListIdWhereTask(userId, data.TaskId) == 0:
+	case listId == 0:
 		NewErrorResponse(c, http.StatusNotFound, "This task not found.")
+	case !h.checkListRole(listId, userId, models.LIST_EDITOR_ROLE):
+		NewErrorResponse(c, http.StatusForbidden, "Not enough rights.")
 	case data.Name == "":
 		NewErrorResponse(c, http.StatusBadRequest, "Empty name.")
 	case len(data.Name) > 32: 
@@ -69,24 +77,27 @@ func (h *Handler) AddSubtask(c *gin.Context) {
 // @Produce   json
 // @Param     subtask_id  query     int  true  "The id of the subtask to be deleted"
 // @Success   200         {object}  models.ApiMessage
+// @Failure   401         {object}  models.ApiError
+// @Failure   403         {object}  models.ApiError
 // @Failure   404         {object}  models.ApiError
 // @Failure   500         {object}  models.ApiError
 // @Security  token
+// @Security  bearer
 // @Router    /todo/subtask/delete [delete]
 func (h *Handler) DeleteSubtask(c *gin.Context) {
 	subtaskId, err := strconv.Atoi(c.Query("subtask_id"))
-	userId := h.RedisClient.VerifyToken(c.Request.Context(), c.GetHeader("token"))
+	userId := c.GetInt(userIdKey)
 	taskId := h.PostgresDB.GetTaskIdWhereSubtask(subtaskId)
-	listId := h.PostgresDB.GetListIdWhereTask(userId, taskId)
+	listId := h.PostgresDB.GetListIdWhereTask(userId, h.PostgresDB.GetRootTaskId(taskId))
 
 	// Input data check
 	switch {
 	case err != nil:
 		NewErrorResponse(c, http.StatusInternalServerError, "Error when converting subtask_id.")
-	case userId == 0:
-		NewErrorResponse(c, http.StatusNotFound, "Inactive user.")
 	case listId == 0:
 		NewErrorResponse(c, http.StatusNotFound, "This subtask not found.")
+	case !h.checkListRole(listId, userId, models.LIST_EDITOR_ROLE):
+		NewErrorResponse(c, http.StatusForbidden, "Not enough rights.")
 	}
 	if c.IsAborted() {
 		return
@@ -103,10 +114,14 @@ func (h *Handler) DeleteSubtask(c *gin.Context) {
 	}
 
 	// Delete subtask
+	reminders := h.PostgresDB.GetTaskTreeReminders(subtaskId)
 	if err := h.PostgresDB.DeleteSubtask(subtaskId); err != nil {
 		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
+	if !h.unscheduleReminders(c, reminders) {
+		return
+	}
 
 	c.JSON(http.StatusOK, models.ApiMessage{
 		Message: "The subtask has been deleted.",
@@ -120,9 +135,12 @@ func (h *Handler) DeleteSubtask(c *gin.Context) {
 // @Param     SubtaskData  body      models.SubtaskEditData  true  "Subtask data"
 // @Success   200          {object}  models.ApiMessage
 // @Failure   400          {object}  models.ApiError
+// @Failure   401          {object}  models.ApiError
+// @Failure   403          {object}  models.ApiError
 // @Failure   404          {object}  models.ApiError
 // @Failure   500          {object}  models.ApiError
 // @Security  token
+// @Security  bearer
 // @Router    /todo/subtask/edit [put]
 func (h *Handler) EditSubtask(c *gin.Context) {
 	/*
@@ -139,6 +157,7 @@ func (h *Handler) EditSubtask(c *gin.Context) {
 		  "id": 1023456789,
 		  "index": 0,
 		  "name": "Coca-Cola",
+		  "priority": "low",
 		  "special": true
 		}
 	*/
@@ -148,17 +167,17 @@ func (h *Handler) EditSubtask(c *gin.Context) {
 		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
 		return
 	}
-	endTime, err := time.Parse("2006-01-02 15:04", data.EndTime)
-	userId := h.RedisClient.VerifyToken(c.Request.Context(), c.GetHeader("token"))
+	userId := c.GetInt(userIdKey)
+	endTime, err := time.ParseInLocation("2006-01-02 15:04", data.EndTime, h.userLocation(userId))
 	taskId := h.PostgresDB.GetTaskIdWhereSubtask(data.Id)
-	listId := h.PostgresDB.GetListIdWhereTask(userId, taskId)
+	listId := h.PostgresDB.GetListIdWhereTask(userId, h.PostgresDB.GetRootTaskId(taskId))
 
 	// Input data check
 	switch {
-	case userId == 0:
-		NewErrorResponse(c, http.StatusNotFound, "Inactive user.")
 	case listId == 0:
 		NewErrorResponse(c, http.StatusNotFound, "This subtask not found.")
+	case !h.checkListRole(listId, userId, models.LIST_EDITOR_ROLE):
+		NewErrorResponse(c, http.StatusForbidden, "Not enough rights.")
 	case err != nil:
 		NewErrorResponse(c, http.StatusBadRequest, "Incorrect time format.")
 	case endTime.Before(time.Now()):
@@ -167,6 +186,8 @@ func (h *Handler) EditSubtask(c *gin.Context) {
 		NewErrorResponse(c, http.StatusBadRequest, "Empty name.")
 	case len(data.Name) > 32: 
 		NewErrorResponse(c, http.StatusBadRequest, "A name longer than 32 characters.")
+	case !checkPriority(&data.Priority):
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect priority.")
 	case data.Index < 0 || data.Index > h.PostgresDB.GetSubtaskMaxIndex(taskId):
 		NewErrorResponse(c, http.StatusBadRequest, "Incorrect index.")
 	}
@@ -175,15 +196,20 @@ func (h *Handler) EditSubtask(c *gin.Context) {
 	}
 
 	// Updating subtask data
-	err = h.PostgresDB.UpdateTaskData(models.Tasks{Id: data.Id, Name: data.Name, Comment: data.Comment, Categories: data.Categories, EndTime: endTime, Done: data.Done, Special: data.Special})
+	subtask := h.PostgresDB.GetTaskById(data.Id)
+	err = h.PostgresDB.UpdateTaskData(models.Tasks{Id: data.Id, Name: data.Name, Comment: data.Comment, Categories: data.Categories, EndTime: endTime, Done: data.Done, Special: data.Special, Priority: data.Priority})
 	if err != nil {
 		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	// Updating subtask reminders
+	if !endTime.Equal(subtask.EndTime) && !h.rescheduleReminders(c, data.Id, endTime) {
+		return
+	}
+
 	// Updating subtask index
-	taskIndex := h.PostgresDB.GetTaskById(data.Id).Index
-	if taskIndex != data.Index {
+	if subtask.Index != data.Index {
 		err := h.PostgresDB.UpdateSubtasksIndexes(models.Tasks{Id: data.Id, TaskId: taskId, Index: data.Index})
 		if err != nil {
 			NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
@@ -191,39 +217,134 @@ func (h *Handler) EditSubtask(c *gin.Context) {
 		}
 	}
 
+	// Completing the parent tasks if the user has enabled it
+	if data.Done && h.PostgresDB.GetUserById(userId).CompletionRollup {
+		if err := h.PostgresDB.RollupTaskCompletion(data.Id); err != nil {
+			NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
 	c.JSON(http.StatusOK, models.ApiMessage{
 		Message: "Updating the task data was successful.",
 	})
 }
 
+// @Summary   Turn subtask into the task of the list
+// @Tags      Working with subtasks
+// @Accept    json
+// @Produce   json
+// @Param     SubtaskData  body      models.SubtaskPromoteData  true  "Subtask data"
+// @Success   200          {object}  models.ApiMessage
+// @Failure   400          {object}  models.ApiError
+// @Failure   401          {object}  models.ApiError
+// @Failure   403          {object}  models.ApiError
+// @Failure   404          {object}  models.ApiError
+// @Failure   500          {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/subtask/promote [put]
+func (h *Handler) PromoteSubtask(c *gin.Context) {
+	/*
+		Example of JSON received
+
+		{
+		  "id": 1023456789,
+		  "index": 0,
+		  "list_id": 1023456789
+		}
+	*/
+
+	var data models.SubtaskPromoteData
+	userId := c.GetInt(userIdKey)
+
+	if c.ShouldBindJSON(&data) != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+		return
+	}
+	listId := h.PostgresDB.GetListIdWhereTask(userId, h.PostgresDB.GetRootTaskId(h.PostgresDB.GetTaskIdWhereSubtask(data.Id)))
+
+	// Input data check
+	switch {
+	case listId == 0:
+		NewErrorResponse(c, http.StatusNotFound, "This subtask not found.")
+	case h.PostgresDB.GetListByIdAndUserId(data.ListId, userId).Id == 0:
+		NewErrorResponse(c, http.StatusNotFound, "This list not found.")
+	case !h.checkListRole(listId, userId, models.LIST_EDITOR_ROLE):
+		NewErrorResponse(c, http.StatusForbidden, "Not enough rights.")
+	case data.ListId != listId && !h.checkListRole(data.ListId, userId, models.LIST_EDITOR_ROLE):
+		NewErrorResponse(c, http.StatusForbidden, "Not enough rights.")
+	case !checkMoveIndex(data.Index, len(h.PostgresDB.GetAllTasks(data.ListId)), false):
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect index.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	// Moving the subtask to the list
+	if err := h.PostgresDB.MoveTask(models.Tasks{Id: data.Id, ListId: data.ListId, Index: data.Index}); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The subtask has become a task.",
+	})
+}
+
 // @Summary   Shows all subtasks in the task
 // @Tags      Working with subtasks
 // @Accept    json
 // @Produce   json
-// @Param     task_id  query     int  true  "Task id with subtasks"
-// @Success   200      {object}  models.ApiShowSubtasks
-// @Failure   404      {object}  models.ApiError
-// @Failure   500      {object}  models.ApiError
+// @Param     task_id     query     int     true   "Task id with subtasks"
+// @Param     done        query     bool    false  "Only done or not done subtasks"
+// @Param     special     query     bool    false  "Only special or not special subtasks"
+// @Param     category    query     string  false  "Only subtasks with the category"
+// @Param     due_before  query     string  false  "Only subtasks with the end time before, in the 2006-01-02 15:04 format in the user timezone"
+// @Param     due_after   query     string  false  "Only subtasks with the end time after or at, in the 2006-01-02 15:04 format in the user timezone"
+// @Param     q           query     string  false  "Part of the name or comment"
+// @Param     limit       query     int     false  "Number of subtasks on the page, all subtasks without the limit and the cursor"
+// @Param     cursor      query     string  false  "Cursor of the page from next_cursor"
+// @Success   200         {object}  models.ApiShowSubtasks
+// @Failure   400         {object}  models.ApiError
+// @Failure   401         {object}  models.ApiError
+// @Failure   404         {object}  models.ApiError
+// @Failure   500         {object}  models.ApiError
 // @Security  token
+// @Security  bearer
 // @Router    /todo/subtask/show [get]
 func (h *Handler) ShowSubtasks(c *gin.Context) {
-	userId := h.RedisClient.VerifyToken(c.Request.Context(), c.GetHeader("token"))
+	userId := c.GetInt(userIdKey)
 	taskId, err := strconv.Atoi(c.Query("task_id"))
 
 	// Input data check
 	switch {
 	case err != nil:
 		NewErrorResponse(c, http.StatusInternalServerError, "Error when converting task_id.")
-	case userId == 0:
-		NewErrorResponse(c, http.StatusNotFound, "Inactive user.")
-	case h.PostgresDB.GetListIdWhereTask(userId, taskId) == 0:
+	case h.PostgresDB.GetListIdWhereTask(userId, h.PostgresDB.GetRootTaskId(taskId)) == 0:
 		NewErrorResponse(c, http.StatusNotFound, "This list not found.")
 	}
 	if c.IsAborted() {
 		return
 	}
 
+	location := h.userLocation(userId)
+	filter, ok := tasksFilter(c, location)
+	if !ok {
+		return
+	}
+	after, limit, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	// Get data from the db
+	subtasks, next := h.PostgresDB.GetSubtasks(taskId, filter, after, limit)
+	for index := range subtasks {
+		subtasks[index].EndTime = localTime(subtasks[index].EndTime, location)
+	}
 	c.JSON(http.StatusOK, models.ApiShowSubtasks{
-		Subtasks: h.PostgresDB.GetAllSubtasks(taskId),
+		Subtasks:   subtasks,
+		NextCursor: common.EncodeCursor(next),
 	})
 }
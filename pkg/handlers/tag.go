@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/NKTKLN/todo-api/models"
+)
+
+var tagColorRegexp = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+
+// @Summary   Shows all user tags
+// @Tags      Working with tags
+// @Accept    json
+// @Produce   json
+// @Success   200  {object}  models.ApiShowTags
+// @Failure   401  {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/tags [get]
+func (h *Handler) ShowTags(c *gin.Context) {
+	// Generating tags output data
+	tags := h.PostgresDB.GetUserTags(c.GetInt(userIdKey))
+	tagsData := make([]models.TagsData, 0, len(tags))
+	for _, tag := range tags {
+		tagsData = append(tagsData, models.TagsData{Id: tag.Id, Name: tag.Name, Color: tag.Color})
+	}
+
+	c.JSON(http.StatusOK, models.ApiShowTags{
+		Tags: tagsData,
+	})
+}
+
+// @Summary   Create tag
+// @Tags      Working with tags
+// @Accept    json
+// @Produce   json
+// @Param     TagData  body      models.ApiTagData  true  "Tag data"
+// @Success   200      {object}  models.ApiMessage
+// @Failure   400      {object}  models.ApiError
+// @Failure   401      {object}  models.ApiError
+// @Failure   500      {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/tags [post]
+func (h *Handler) AddTag(c *gin.Context) {
+	/*
+		Example of JSON received
+
+		{
+		  "name": "Shopping",
+		  "color": "#ff8800"
+		}
+	*/
+
+	var data models.ApiTagData
+	userId := c.GetInt(userIdKey)
+
+	// Input data check
+	if c.ShouldBindJSON(&data) != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+		return
+	}
+	if data.Color == "" {
+		data.Color = models.DEFAULT_TAG_COLOR
+	}
+	data.Color = strings.ToLower(data.Color)
+	if !checkTagColor(c, data.Color) || !h.checkTagName(c, userId, data.Name) {
+		return
+	}
+	if len(h.PostgresDB.GetUserTags(userId)) >= models.MAX_TAGS {
+		NewErrorResponse(c, http.StatusBadRequest, "Too many tags.")
+		return
+	}
+
+	// Create new tag
+	if err := h.PostgresDB.CreateTag(models.Tags{UserId: userId, Name: data.Name, Color: data.Color}); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "Tag added to db.",
+	})
+}
+
+// @Summary   Edit tag, the renamed tag is renamed in the categories of all tasks
+// @Tags      Working with tags
+// @Accept    json
+// @Produce   json
+// @Param     TagData  body      models.TagEditData  true  "Tag data, empty fields are not changed"
+// @Success   200      {object}  models.ApiMessage
+// @Failure   400      {object}  models.ApiError
+// @Failure   401      {object}  models.ApiError
+// @Failure   404      {object}  models.ApiError
+// @Failure   500      {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/tags [patch]
+func (h *Handler) EditTag(c *gin.Context) {
+	/*
+		Example of JSON received
+
+		{
+		  "id": 1023456789,
+		  "name": "Groceries",
+		  "color": "#00aa55"
+		}
+	*/
+
+	var data models.TagEditData
+	userId := c.GetInt(userIdKey)
+
+	if c.ShouldBindJSON(&data) != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+		return
+	}
+
+	tagData := h.PostgresDB.GetTagByIdAndUserId(data.Id, userId)
+	if tagData.Id == 0 {
+		NewErrorResponse(c, http.StatusNotFound, "This tag not found.")
+		return
+	}
+
+	// Only the given fields are changed
+	oldName := tagData.Name
+	if data.Name != "" {
+		tagData.Name = data.Name
+	}
+	if data.Color != "" {
+		tagData.Color = strings.ToLower(data.Color)
+	}
+
+	// Input data check
+	if !checkTagColor(c, tagData.Color) || (tagData.Name != oldName && !h.checkTagName(c, userId, tagData.Name)) {
+		return
+	}
+
+	// Updating the tag data
+	if err := h.PostgresDB.UpdateTag(tagData, oldName); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "Updating the tag data was successful.",
+	})
+}
+
+// @Summary   Delete tag, the tag is removed from the categories of all tasks
+// @Tags      Working with tags
+// @Accept    json
+// @Produce   json
+// @Param     tag_id  query     int  true  "The id of the tag to be deleted"
+// @Success   200     {object}  models.ApiMessage
+// @Failure   401     {object}  models.ApiError
+// @Failure   404     {object}  models.ApiError
+// @Failure   500     {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/tags [delete]
+func (h *Handler) DeleteTag(c *gin.Context) {
+	tagId, err := strconv.Atoi(c.Query("tag_id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Error when converting tag_id.")
+		return
+	}
+
+	tagData := h.PostgresDB.GetTagByIdAndUserId(tagId, c.GetInt(userIdKey))
+	if tagData.Id == 0 {
+		NewErrorResponse(c, http.StatusNotFound, "This tag not found.")
+		return
+	}
+
+	if err := h.PostgresDB.DeleteTag(tagData); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The tag has been deleted.",
+	})
+}
+
+// @Summary   Autocomplete tags, the most used tags go first
+// @Tags      Working with tags
+// @Accept    json
+// @Produce   json
+// @Param     q    query     string  false  "Beginning of the tag name"
+// @Success   200  {object}  models.ApiTagsAutocomplete
+// @Failure   401  {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/tags/autocomplete [get]
+func (h *Handler) AutocompleteTags(c *gin.Context) {
+	c.JSON(http.StatusOK, models.ApiTagsAutocomplete{
+		Tags: h.PostgresDB.GetUserTagsUsage(c.GetInt(userIdKey), c.Query("q"), models.TAGS_AUTOCOMPLETE),
+	})
+}
+
+func (h *Handler) checkTagName(c *gin.Context, userId int, name string) bool {
+	switch {
+	case name == "":
+		NewErrorResponse(c, http.StatusBadRequest, "Empty name.")
+	case len(name) > 32:
+		NewErrorResponse(c, http.StatusBadRequest, "A name longer than 32 characters.")
+	case h.PostgresDB.GetTagByNameAndUserId(name, userId).Id != 0:
+		NewErrorResponse(c, http.StatusBadRequest, "This tag already exists.")
+	}
+	return !c.IsAborted()
+}
+
+func checkTagColor(c *gin.Context, color string) bool {
+	if !tagColorRegexp.MatchString(color) {
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect color.")
+		return false
+	}
+	return true
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/NKTKLN/todo-api/models"
+	"github.com/NKTKLN/todo-api/pkg/common"
 )
 
 // @Summary   Create task
@@ -17,9 +18,12 @@ import (
 // @Param     TaskData  body      models.ApiTaskData  true  "Task data"
 // @Success   200       {object}  models.ApiMessage
 // @Failure   400       {object}  models.ApiError
+// @Failure   401       {object}  models.ApiError
+// @Failure   403       {object}  models.ApiError
 // @Failure   404       {object}  models.ApiError
 // @Failure   500       {object}  models.ApiError
 // @Security  token
+// @Security  bearer
 // @Router    /todo/task/add [post]
 func (h *Handler) AddTask(c *gin.Context) {
 	/*
@@ -33,16 +37,16 @@ func (h *Handler) AddTask(c *gin.Context) {
 	*/
 
 	var data models.ApiTaskData
-	userId := h.RedisClient.VerifyToken(c.Request.Context(), c.GetHeader("token"))
+	userId := c.GetInt(userIdKey)
 
 	// Input data check
 	switch {
 	case c.ShouldBindJSON(&data) != nil:
 		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
-	case userId == 0:
-		NewErrorResponse(c, http.StatusNotFound, "Inactive user.")
 	case h.PostgresDB.GetListByIdAndUserId(data.ListId, userId).Id == 0:
 		NewErrorResponse(c, http.StatusNotFound, "This list not found.")
+	case !h.checkListRole(data.ListId, userId, models.LIST_EDITOR_ROLE):
+		NewErrorResponse(c, http.StatusForbidden, "Not enough rights.")
 	case data.Name == "":
 		NewErrorResponse(c, http.StatusBadRequest, "Empty name.")
 	case len(data.Name) > 32: 
@@ -69,23 +73,26 @@ func (h *Handler) AddTask(c *gin.Context) {
 // @Produce   json
 // @Param     task_id  query     int  true  "The id of the task to be deleted"
 // @Success   200      {object}  models.ApiMessage
+// @Failure   401      {object}  models.ApiError
+// @Failure   403      {object}  models.ApiError
 // @Failure   404      {object}  models.ApiError
 // @Failure   500      {object}  models.ApiError
 // @Security  token
+// @Security  bearer
 // @Router    /todo/task/delete [delete]
 func (h *Handler) DeleteTask(c *gin.Context) {
 	taskId, err := strconv.Atoi(c.Query("task_id"))
-	userId := h.RedisClient.VerifyToken(c.Request.Context(), c.GetHeader("token"))
+	userId := c.GetInt(userIdKey)
 	listId := h.PostgresDB.GetListIdWhereTask(userId, taskId)
 
 	// Input data check
 	switch {
 	case err != nil:
 		NewErrorResponse(c, http.StatusInternalServerError, "Error when converting task_id.")
-	case userId == 0:
-		NewErrorResponse(c, http.StatusNotFound, "Inactive user.")
 	case listId == 0:
 		NewErrorResponse(c, http.StatusNotFound, "This task not found.")
+	case !h.checkListRole(listId, userId, models.LIST_EDITOR_ROLE):
+		NewErrorResponse(c, http.StatusForbidden, "Not enough rights.")
 	}
 	if c.IsAborted() {
 		return
@@ -102,10 +109,14 @@ func (h *Handler) DeleteTask(c *gin.Context) {
 	}
 
 	// Delete task
+	reminders := h.PostgresDB.GetTaskTreeReminders(taskId)
 	if err := h.PostgresDB.DeleteTask(taskId); err != nil {
 		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
+	if !h.unscheduleReminders(c, reminders) {
+		return
+	}
 
 	c.JSON(http.StatusOK, models.ApiMessage{
 		Message: "The task has been deleted.",
@@ -119,9 +130,12 @@ func (h *Handler) DeleteTask(c *gin.Context) {
 // @Param     TaskData  body      models.TaskEditData  true  "Task data"
 // @Success   200       {object}  models.ApiMessage
 // @Failure   400       {object}  models.ApiError
+// @Failure   401       {object}  models.ApiError
+// @Failure   403       {object}  models.ApiError
 // @Failure   404       {object}  models.ApiError
 // @Failure   500       {object}  models.ApiError
 // @Security  token
+// @Security  bearer
 // @Router    /todo/task/edit [put]
 func (h *Handler) EditTask(c *gin.Context) {
 	/*
@@ -138,6 +152,8 @@ func (h *Handler) EditTask(c *gin.Context) {
 		  "id": 1023456789,
 		  "index": 0,
 		  "name": "Buy drinks",
+		  "priority": "high",
+		  "recurrence": "FREQ=WEEKLY;BYDAY=MO,TH;COUNT=10",
 		  "special": true
 		}
 	*/
@@ -147,16 +163,22 @@ func (h *Handler) EditTask(c *gin.Context) {
 		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
 		return
 	}
-	endTime, err := time.Parse("2006-01-02 15:04", data.EndTime)
-	userId := h.RedisClient.VerifyToken(c.Request.Context(), c.GetHeader("token"))
+	userId := c.GetInt(userIdKey)
+	endTime, err := time.ParseInLocation("2006-01-02 15:04", data.EndTime, h.userLocation(userId))
 	listId := h.PostgresDB.GetListIdWhereTask(userId, data.Id)
 
+	var rule *common.RRule
+	var ruleErr error
+	if data.Recurrence != "" {
+		rule, ruleErr = common.ParseRRule(data.Recurrence)
+	}
+
 	// Input data check
 	switch {
-	case userId == 0:
-		NewErrorResponse(c, http.StatusNotFound, "Inactive user.")
 	case listId == 0:
 		NewErrorResponse(c, http.StatusNotFound, "This task not found.")
+	case !h.checkListRole(listId, userId, models.LIST_EDITOR_ROLE):
+		NewErrorResponse(c, http.StatusForbidden, "Not enough rights.")
 	case err != nil:
 		NewErrorResponse(c, http.StatusBadRequest, "Incorrect time format.")
 	case endTime.Before(time.Now()):
@@ -165,6 +187,10 @@ func (h *Handler) EditTask(c *gin.Context) {
 		NewErrorResponse(c, http.StatusBadRequest, "Empty name.")
 	case len(data.Name) > 32: 
 		NewErrorResponse(c, http.StatusBadRequest, "A name longer than 32 characters.")
+	case !checkPriority(&data.Priority):
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect priority.")
+	case ruleErr != nil:
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect recurrence rule.")
 	case data.Index < 0 || data.Index > h.PostgresDB.GetTaskMaxIndex(listId):
 		NewErrorResponse(c, http.StatusBadRequest, "Incorrect index.")
 	}
@@ -173,15 +199,49 @@ func (h *Handler) EditTask(c *gin.Context) {
 	}
 
 	// Updating task data
-	err = h.PostgresDB.UpdateTaskData(models.Tasks{Id: data.Id, Name: data.Name, Comment: data.Comment, Categories: data.Categories, EndTime: endTime, Done: data.Done, Special: data.Special})
+	task := h.PostgresDB.GetTaskById(data.Id)
+	err = h.PostgresDB.UpdateTaskData(models.Tasks{Id: data.Id, Name: data.Name, Comment: data.Comment, Categories: data.Categories, EndTime: endTime, Done: data.Done, Special: data.Special, Priority: data.Priority})
+	if err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// Updating task recurrence, a changed rule starts a new series
+	recurrence := ""
+	if rule != nil {
+		recurrence = rule.String()
+	}
+	occurrence := task.Occurrence
+	if recurrence != task.Recurrence {
+		occurrence = 0
+	}
+
+	switch {
+	case rule != nil && data.Done && !task.Done:
+		// The series continues in the next occurrence, the completed one stays as a regular task
+		if next, ok := rule.Next(endTime, occurrence); ok {
+			nextTask := models.Tasks{Id: data.Id, ListId: listId, Name: data.Name, Comment: data.Comment, Categories: data.Categories, EndTime: endTime, Special: data.Special, Priority: data.Priority, Recurrence: recurrence, Occurrence: occurrence}
+			if err := h.PostgresDB.CreateTaskOccurrence(nextTask, next); err != nil {
+				NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+				return
+			}
+		}
+		err = h.PostgresDB.UpdateTaskRecurrence(models.Tasks{Id: data.Id, EndTime: endTime, Occurrence: occurrence})
+	case recurrence != task.Recurrence:
+		err = h.PostgresDB.UpdateTaskRecurrence(models.Tasks{Id: data.Id, EndTime: endTime, Recurrence: recurrence, Occurrence: occurrence})
+	}
 	if err != nil {
 		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	// Updating task reminders
+	if !endTime.Equal(task.EndTime) && !h.rescheduleReminders(c, data.Id, endTime) {
+		return
+	}
+
 	// Updating task index
-	taskIndex := h.PostgresDB.GetTaskById(data.Id).Index
-	if taskIndex != data.Index {
+	if task.Index != data.Index {
 		err := h.PostgresDB.UpdateTasksIndexes(models.Tasks{Id: data.Id, ListId: listId, Index: data.Index})
 		if err != nil {
 			NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
@@ -194,26 +254,325 @@ func (h *Handler) EditTask(c *gin.Context) {
 	})
 }
 
-// @Summary   Shows all tasks in the list
+// @Summary   Move task with its subtasks to the list
+// @Tags      Working with tasks
+// @Accept    json
+// @Produce   json
+// @Param     TaskData  body      models.TaskMoveData  true  "Task data"
+// @Success   200       {object}  models.ApiMessage
+// @Failure   400       {object}  models.ApiError
+// @Failure   401       {object}  models.ApiError
+// @Failure   403       {object}  models.ApiError
+// @Failure   404       {object}  models.ApiError
+// @Failure   500       {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/task/move [put]
+func (h *Handler) MoveTask(c *gin.Context) {
+	/*
+		Example of JSON received
+
+		{
+		  "id": 1023456789,
+		  "index": 0,
+		  "list_id": 1023456789
+		}
+	*/
+
+	var data models.TaskMoveData
+	userId := c.GetInt(userIdKey)
+
+	if c.ShouldBindJSON(&data) != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+		return
+	}
+	listId := h.PostgresDB.GetListIdWhereTask(userId, data.Id)
+
+	// Input data check
+	switch {
+	case listId == 0:
+		NewErrorResponse(c, http.StatusNotFound, "This task not found.")
+	case h.PostgresDB.GetListByIdAndUserId(data.ListId, userId).Id == 0:
+		NewErrorResponse(c, http.StatusNotFound, "This list not found.")
+	case !h.checkListRole(listId, userId, models.LIST_EDITOR_ROLE):
+		NewErrorResponse(c, http.StatusForbidden, "Not enough rights.")
+	case data.ListId != listId && !h.checkListRole(data.ListId, userId, models.LIST_EDITOR_ROLE):
+		NewErrorResponse(c, http.StatusForbidden, "Not enough rights.")
+	case !checkMoveIndex(data.Index, len(h.PostgresDB.GetAllTasks(data.ListId)), listId == data.ListId):
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect index.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	// Moving the task
+	if err := h.PostgresDB.MoveTask(models.Tasks{Id: data.Id, ListId: data.ListId, Index: data.Index}); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The task has been moved.",
+	})
+}
+
+// @Summary   Turn task with its subtasks into the subtask of another task or subtask
+// @Tags      Working with tasks
+// @Accept    json
+// @Produce   json
+// @Param     TaskData  body      models.TaskDemoteData  true  "Task data"
+// @Success   200       {object}  models.ApiMessage
+// @Failure   400       {object}  models.ApiError
+// @Failure   401       {object}  models.ApiError
+// @Failure   403       {object}  models.ApiError
+// @Failure   404       {object}  models.ApiError
+// @Failure   500       {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/task/demote [put]
+func (h *Handler) DemoteTask(c *gin.Context) {
+	/*
+		Example of JSON received
+
+		{
+		  "id": 1023456789,
+		  "index": 0,
+		  "task_id": 1023456789
+		}
+	*/
+
+	var data models.TaskDemoteData
+	userId := c.GetInt(userIdKey)
+
+	if c.ShouldBindJSON(&data) != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+		return
+	}
+	parentRootId := h.PostgresDB.GetRootTaskId(data.TaskId)
+	listId := h.PostgresDB.GetListIdWhereTask(userId, data.Id)
+	parentListId := h.PostgresDB.GetListIdWhereTask(userId, parentRootId)
+
+	// Input data check, the task is moved with its subtasks, so the parent can't be one of them
+	switch {
+	case listId == 0:
+		NewErrorResponse(c, http.StatusNotFound, "This task not found.")
+	case parentListId == 0:
+		NewErrorResponse(c, http.StatusNotFound, "Parent task not found.")
+	case !h.checkListRole(listId, userId, models.LIST_EDITOR_ROLE):
+		NewErrorResponse(c, http.StatusForbidden, "Not enough rights.")
+	case parentListId != listId && !h.checkListRole(parentListId, userId, models.LIST_EDITOR_ROLE):
+		NewErrorResponse(c, http.StatusForbidden, "Not enough rights.")
+	case parentRootId == data.Id:
+		NewErrorResponse(c, http.StatusBadRequest, "A task can't be a subtask of itself or its subtasks.")
+	case !checkMoveIndex(data.Index, len(h.PostgresDB.GetAllSubtasks(data.TaskId)), false):
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect index.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	// Moving the task under the parent task
+	if err := h.PostgresDB.MoveTask(models.Tasks{Id: data.Id, TaskId: data.TaskId, Index: data.Index}); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The task has become a subtask.",
+	})
+}
+
+// @Summary   Shows all tasks in the list with all levels of their subtasks
 // @Tags      Working with tasks
 // @Accept    json
 // @Produce   json
 // @Param     list_id  query     int  true  "List id with tasks"
-// @Success   200      {object}  models.ApiShowTasks
+// @Success   200      {object}  models.ApiShowTaskTree
+// @Failure   401      {object}  models.ApiError
+// @Failure   404      {object}  models.ApiError
+// @Failure   500      {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/task/tree [get]
+func (h *Handler) ShowTaskTree(c *gin.Context) {
+	listId, err := strconv.Atoi(c.Query("list_id"))
+
+	// Input data check
+	switch {
+	case err != nil:
+		NewErrorResponse(c, http.StatusInternalServerError, "Error when converting list_id.")
+	case h.PostgresDB.GetListByIdAndUserId(listId, c.GetInt(userIdKey)).Id == 0:
+		NewErrorResponse(c, http.StatusNotFound, "This list not found.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	tasks := h.PostgresDB.GetTaskTree(listId)
+	localTreeEndTimes(tasks, h.userLocation(c.GetInt(userIdKey)))
+	c.JSON(http.StatusOK, models.ApiShowTaskTree{
+		Tasks: tasks,
+	})
+}
+
+// localTreeEndTimes shows the end times of the tasks and all levels of their subtasks in the location
+func localTreeEndTimes(tasks []models.TaskTreeData, location *time.Location) {
+	for index := range tasks {
+		tasks[index].EndTime = localTime(tasks[index].EndTime, location)
+		localTreeEndTimes(tasks[index].Subtasks, location)
+	}
+}
+
+// checkMoveIndex checks the new index among the count of tasks, a task moved inside its place can't be after the
+// last one
+func checkMoveIndex(index, count int, samePlace bool) bool {
+	if samePlace {
+		count--
+	}
+	return index >= 0 && index <= count
+}
+
+// @Summary   Skip the occurrence of the recurring task
+// @Tags      Working with tasks
+// @Accept    json
+// @Produce   json
+// @Param     task_id  query     int  true  "The id of the recurring task"
+// @Success   200      {object}  models.ApiMessage
+// @Failure   400      {object}  models.ApiError
+// @Failure   401      {object}  models.ApiError
+// @Failure   403      {object}  models.ApiError
+// @Failure   404      {object}  models.ApiError
+// @Failure   500      {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/task/skip [put]
+func (h *Handler) SkipTaskOccurrence(c *gin.Context) {
+	task, rule := h.recurringTask(c)
+	if c.IsAborted() {
+		return
+	}
+
+	// Occurrences follow the days of the user timezone
+	next, ok := rule.Next(task.EndTime.In(h.userLocation(c.GetInt(userIdKey))), task.Occurrence)
+	if !ok {
+		NewErrorResponse(c, http.StatusBadRequest, "This is the last occurrence of the task.")
+		return
+	}
+
+	// Moving the task to the next occurrence
+	err := h.PostgresDB.UpdateTaskRecurrence(models.Tasks{Id: task.Id, EndTime: next, Recurrence: task.Recurrence, Occurrence: task.Occurrence + 1})
+	if err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if !h.rescheduleReminders(c, task.Id, next) {
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The occurrence has been skipped.",
+	})
+}
+
+// @Summary   End the series of the recurring task
+// @Tags      Working with tasks
+// @Accept    json
+// @Produce   json
+// @Param     task_id  query     int  true  "The id of the recurring task"
+// @Success   200      {object}  models.ApiMessage
+// @Failure   400      {object}  models.ApiError
+// @Failure   401      {object}  models.ApiError
+// @Failure   403      {object}  models.ApiError
 // @Failure   404      {object}  models.ApiError
 // @Failure   500      {object}  models.ApiError
 // @Security  token
+// @Security  bearer
+// @Router    /todo/task/end-series [put]
+func (h *Handler) EndTaskSeries(c *gin.Context) {
+	task, _ := h.recurringTask(c)
+	if c.IsAborted() {
+		return
+	}
+
+	// The current occurrence stays as a regular task
+	err := h.PostgresDB.UpdateTaskRecurrence(models.Tasks{Id: task.Id, EndTime: task.EndTime, Occurrence: task.Occurrence})
+	if err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The series has been ended.",
+	})
+}
+
+// recurringTask returns the recurring task from the task_id query of the user
+func (h *Handler) recurringTask(c *gin.Context) (task models.Tasks, rule *common.RRule) {
+	taskId, err := strconv.Atoi(c.Query("task_id"))
+	userId := c.GetInt(userIdKey)
+	listId := h.PostgresDB.GetListIdWhereTask(userId, taskId)
+
+	// Input data check
+	switch {
+	case err != nil:
+		NewErrorResponse(c, http.StatusInternalServerError, "Error when converting task_id.")
+	case listId == 0:
+		NewErrorResponse(c, http.StatusNotFound, "This task not found.")
+	case !h.checkListRole(listId, userId, models.LIST_EDITOR_ROLE):
+		NewErrorResponse(c, http.StatusForbidden, "Not enough rights.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	task = h.PostgresDB.GetTaskById(taskId)
+	if task.Recurrence != "" {
+		rule, err = common.ParseRRule(task.Recurrence)
+	}
+	if task.Recurrence == "" || err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, "This task is not recurring.")
+	}
+	return
+}
+
+// @Summary   Shows all tasks in the list
+// @Tags      Working with tasks
+// @Accept    json
+// @Produce   json
+// @Param     list_id     query     int     true   "List id with tasks"
+// @Param     sort        query     string  false  "Sort mode, manual by default"                                            Enums(manual, priority, due, created, name)
+// @Param     order       query     string  false  "Order direction, desc by default for the priority and asc for the rest"  Enums(asc, desc)
+// @Param     done        query     bool    false  "Only done or not done tasks"
+// @Param     special     query     bool    false  "Only special or not special tasks"
+// @Param     category    query     string  false  "Only tasks with the category"
+// @Param     due_before  query     string  false  "Only tasks with the end time before, in the 2006-01-02 15:04 format in the user timezone"
+// @Param     due_after   query     string  false  "Only tasks with the end time after or at, in the 2006-01-02 15:04 format in the user timezone"
+// @Param     q           query     string  false  "Part of the name or comment"
+// @Param     limit       query     int     false  "Number of tasks on the page, all tasks without the limit and the cursor"
+// @Param     cursor      query     string  false  "Cursor of the page from next_cursor"
+// @Success   200         {object}  models.ApiShowTasks
+// @Failure   400         {object}  models.ApiError
+// @Failure   401         {object}  models.ApiError
+// @Failure   404         {object}  models.ApiError
+// @Failure   500         {object}  models.ApiError
+// @Security  token
+// @Security  bearer
 // @Router    /todo/task/show [get]
 func (h *Handler) ShowTasks(c *gin.Context) {
-	userId := h.RedisClient.VerifyToken(c.Request.Context(), c.GetHeader("token"))
+	userId := c.GetInt(userIdKey)
 	listId, err := strconv.Atoi(c.Query("list_id"))
+	sort := c.DefaultQuery("sort", models.MANUAL_SORT)
+	defaultOrder, sortFound := models.TASK_SORTS[sort]
+	order := c.DefaultQuery("order", defaultOrder)
 
 	// Input data check
 	switch {
 	case err != nil:
 		NewErrorResponse(c, http.StatusInternalServerError, "Error when converting list_id.")
-	case userId == 0:
-		NewErrorResponse(c, http.StatusNotFound, "Inactive user.")
+	case !sortFound:
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect sort.")
+	case order != "asc" && order != "desc":
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect order.")
 	case h.PostgresDB.GetListByIdAndUserId(listId, userId).Id == 0:
 		NewErrorResponse(c, http.StatusNotFound, "This list not found.")
 	}
@@ -221,7 +580,38 @@ func (h *Handler) ShowTasks(c *gin.Context) {
 		return
 	}
 
+	location := h.userLocation(userId)
+	filter, ok := tasksFilter(c, location)
+	if !ok {
+		return
+	}
+	after, limit, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	// Get data from the db
+	tasks, next := h.PostgresDB.GetTasks(listId, filter, sort, order, after, limit)
+	for index := range tasks {
+		tasks[index].EndTime = localTime(tasks[index].EndTime, location)
+	}
 	c.JSON(http.StatusOK, models.ApiShowTasks{
-		Tasks: h.PostgresDB.GetAllTasks(listId),
+		Tasks:      tasks,
+		NextCursor: common.EncodeCursor(next),
 	})
 }
+
+// checkPriority checks the task priority, an empty priority is replaced with the lowest one
+func checkPriority(priority *string) bool {
+	if *priority == "" {
+		*priority = models.TASK_PRIORITIES[0]
+		return true
+	}
+
+	for _, taskPriority := range models.TASK_PRIORITIES {
+		if *priority == taskPriority {
+			return true
+		}
+	}
+	return false
+}
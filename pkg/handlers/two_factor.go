@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/NKTKLN/todo-api/models"
+	"github.com/NKTKLN/todo-api/pkg/common"
+	"github.com/NKTKLN/todo-api/pkg/db"
+)
+
+// @Summary   Start enabling two-factor authentication
+// @Tags      Two-factor authentication
+// @Accept    json
+// @Produce   json
+// @Success   200  {object}  models.ApiTwoFactorSetup
+// @Failure   400  {object}  models.ApiError
+// @Failure   401  {object}  models.ApiError
+// @Failure   404  {object}  models.ApiError
+// @Failure   500  {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /user/2fa/enable [post]
+func (h *Handler) EnableTwoFactor(c *gin.Context) {
+	userData := h.PostgresDB.GetUserById(c.GetInt(userIdKey))
+
+	// Input data check
+	switch {
+	case userData.Id == 0:
+		NewErrorResponse(c, http.StatusNotFound, "User not found.")
+	case userData.TotpEnabled:
+		NewErrorResponse(c, http.StatusBadRequest, "Two-factor authentication is already enabled.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	// Generating a new secret, it will be used only after confirmation
+	secret, err := common.NewTOTPSecret()
+	if err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err := h.PostgresDB.UpdateUserTOTPSecret(userData.Id, secret); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiTwoFactorSetup{
+		Secret: secret,
+		URI:    common.TOTPURI(secret, models.TOTP_ISSUER, userData.Email),
+	})
+}
+
+// @Summary   Confirm enabling two-factor authentication
+// @Tags      Two-factor authentication
+// @Accept    json
+// @Produce   json
+// @Param     Code  body      models.TwoFactorCode  true  "TOTP code"
+// @Success   200   {object}  models.ApiRecoveryCodes
+// @Failure   400   {object}  models.ApiError
+// @Failure   401   {object}  models.ApiError
+// @Failure   404   {object}  models.ApiError
+// @Failure   500   {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /user/2fa/confirm [post]
+func (h *Handler) ConfirmTwoFactor(c *gin.Context) {
+	/*
+		Example of JSON received
+
+		{
+		  "code": "123456"
+		}
+	*/
+
+	var data models.TwoFactorCode
+	userData := h.PostgresDB.GetUserById(c.GetInt(userIdKey))
+
+	// Input data check
+	switch {
+	case c.ShouldBindJSON(&data) != nil:
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+	case userData.Id == 0:
+		NewErrorResponse(c, http.StatusNotFound, "User not found.")
+	case userData.TotpEnabled:
+		NewErrorResponse(c, http.StatusBadRequest, "Two-factor authentication is already enabled.")
+	case userData.TotpSecret == "":
+		NewErrorResponse(c, http.StatusBadRequest, "Two-factor authentication setup is not started.")
+	case !h.useTOTPCode(userData, data.Code):
+		NewErrorResponse(c, http.StatusBadRequest, "Wrong two-factor authentication code.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	// Generating recovery codes, only their hashes are stored
+	recoveryCodes, err := common.NewRecoveryCodes(models.RECOVERY_CODES_COUNT)
+	if err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	hashedCodes := make([]string, 0, len(recoveryCodes))
+	for _, code := range recoveryCodes {
+		hashedCodes = append(hashedCodes, common.HashRecoveryCode(code))
+	}
+
+	if err := h.PostgresDB.EnableUserTOTP(userData.Id, hashedCodes); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiRecoveryCodes{
+		RecoveryCodes: recoveryCodes,
+	})
+}
+
+// @Summary   Disable two-factor authentication
+// @Tags      Two-factor authentication
+// @Accept    json
+// @Produce   json
+// @Param     DisableData  body      models.TwoFactorDisable  true  "User password and TOTP or recovery code"
+// @Success   200          {object}  models.ApiMessage
+// @Failure   400          {object}  models.ApiError
+// @Failure   401          {object}  models.ApiError
+// @Failure   500          {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /user/2fa/disable [post]
+func (h *Handler) DisableTwoFactor(c *gin.Context) {
+	/*
+		Example of JSON received
+
+		{
+		  "code": "123456",
+		  "password": "StRon9Pa$$w0rd"
+		}
+	*/
+
+	var data models.TwoFactorDisable
+	userData := h.PostgresDB.GetUserById(c.GetInt(userIdKey))
+
+	// Input data check
+	switch {
+	case c.ShouldBindJSON(&data) != nil:
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+	case !userData.TotpEnabled:
+		NewErrorResponse(c, http.StatusBadRequest, "Two-factor authentication is not enabled.")
+	case bcrypt.CompareHashAndPassword([]byte(userData.Password), []byte(data.Password)) != nil:
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect password.")
+	case !h.checkTwoFactorCode(userData, data.Code):
+		NewErrorResponse(c, http.StatusBadRequest, "Wrong two-factor authentication code.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	if err := h.PostgresDB.DisableUserTOTP(userData.Id); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "Two-factor authentication has been disabled.",
+	})
+}
+
+// @Summary  Complete sign in with two-factor authentication
+// @Tags     Authorization
+// @Accept   json
+// @Produce  json
+// @Param    SignInData  body      models.TwoFactorSignIn  true  "Challenge and TOTP or recovery code"
+// @Success  200         {object}  models.UserTokens
+// @Failure  400         {object}  models.ApiError
+// @Failure  403         {object}  models.ApiError
+// @Failure  429         {object}  models.ApiError
+// @Failure  500         {object}  models.ApiError
+// @Router   /auth/sign-in/2fa [post]
+func (h *Handler) SignInTwoFactor(c *gin.Context) {
+	/*
+		Example of JSON received
+
+		{
+		  "challenge": "4b0a6bd2-4c5e-4d1f-9a0e-6f7e2b4c1d3a",
+		  "code": "123456"
+		}
+	*/
+
+	var data models.TwoFactorSignIn
+
+	// Input data check
+	if c.ShouldBindJSON(&data) != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+		return
+	}
+
+	// Checking that the challenge is in working order
+	challenge, err := h.RedisClient.GetChallenge(c.Request.Context(), data.Challenge)
+	switch {
+	case errors.Is(err, db.ErrTooManyAttempts):
+		NewErrorResponse(c, http.StatusTooManyRequests, "Too many attempts, sign in again.")
+	case err != nil:
+		NewErrorResponse(c, http.StatusBadRequest, "Time has expired, your challenge is not valid.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	// The codes are limited together with the passwords of the account
+	userData := h.PostgresDB.GetUserById(challenge.UserId)
+	emailKey, ipKey := signInLimitKey(userData.Email), signInIpLimitKey(c)
+	if !h.checkLimits(c, emailKey, ipKey) {
+		return
+	}
+
+	switch {
+	case userData.Disabled:
+		NewErrorResponse(c, http.StatusForbidden, "Account is disabled.")
+	case !h.checkTwoFactorCode(userData, data.Code):
+		h.signInFailed(c, userData, emailKey, ipKey, "Wrong two-factor authentication code.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	if err := h.RedisClient.ResetLimit(c.Request.Context(), emailKey); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// The challenge can only be passed once
+	if err := h.RedisClient.DeleteChallenge(c.Request.Context(), challenge.Id); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// Creating new session for user
+	accessToken, refreshToken, err := h.RedisClient.CreateSession(c.Request.Context(), models.Sessions{
+		UserId:    userData.Id,
+		Device:    challenge.Device,
+		UserAgent: challenge.UserAgent,
+	})
+	if err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.UserTokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	})
+}
+
+// checkTwoFactorCode accepts either a TOTP code or an unused recovery code
+func (h *Handler) checkTwoFactorCode(userData models.Users, code string) bool {
+	if !userData.TotpEnabled || code == "" {
+		return false
+	}
+
+	if h.useTOTPCode(userData, code) {
+		return true
+	}
+
+	return h.PostgresDB.UseUserRecoveryCode(userData.Id, common.HashRecoveryCode(code))
+}
+
+// useTOTPCode accepts the TOTP code only once, the codes of earlier time steps are rejected too
+func (h *Handler) useTOTPCode(userData models.Users, code string) bool {
+	step, ok := common.VerifyTOTP(userData.TotpSecret, code, userData.TotpLastStep)
+	return ok && h.PostgresDB.UseUserTOTPStep(userData.Id, step)
+}
@@ -103,12 +103,14 @@ func (h *Handler) GetUserData(c *gin.Context) {
 // @Accept   json
 // @Produce  json
 // @Success  200  {object}  models.ShowUserData
+// @Failure  401  {object}  models.ApiError
 // @Failure  404  {object}  models.ApiError
 // @Failure  500  {object}  models.ApiError
 // @Security token
+// @Security bearer
 // @Router   /user/show/data-by-token [get]
 func (h *Handler) GetUserDataByToken(c *gin.Context) {
-	userId := h.RedisClient.VerifyToken(c.Request.Context(), c.GetHeader("token"))
+	userId := c.GetInt(userIdKey)
 	userData := h.PostgresDB.GetUserById(userId)
 
 	// Input data check
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"net/http"
 	"regexp"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"golang.org/x/crypto/bcrypt"
@@ -17,9 +18,10 @@ import (
 // @Produce   json
 // @Param     NewUserName  body      models.UserName  true  "User name"
 // @Success   200          {object}  models.ApiMessage
-// @Failure   404          {object}  models.ApiError
+// @Failure   401          {object}  models.ApiError
 // @Failure   500          {object}  models.ApiError
 // @Security  token
+// @Security  bearer
 // @Router    /user/settings/update/name [patch]
 func (h *Handler) EditUserName(c *gin.Context) {
 	/*
@@ -31,16 +33,11 @@ func (h *Handler) EditUserName(c *gin.Context) {
 	*/
 
 	var data models.UserName
-	userId := h.RedisClient.VerifyToken(c.Request.Context(), c.GetHeader("token"))
+	userId := c.GetInt(userIdKey)
 
 	// Input data check
-	switch {
-	case c.ShouldBindJSON(&data) != nil:
+	if c.ShouldBindJSON(&data) != nil {
 		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
-	case userId == 0:
-		NewErrorResponse(c, http.StatusNotFound, "Inactive user.")
-	}
-	if c.IsAborted() {
 		return
 	}
 
@@ -67,9 +64,10 @@ func (h *Handler) EditUserName(c *gin.Context) {
 // @Produce   json
 // @Param     NewUsername  body      models.UserUsername  true  "Username"
 // @Success   200          {object}  models.ApiMessage
-// @Failure   404          {object}  models.ApiError
+// @Failure   401          {object}  models.ApiError
 // @Failure   500          {object}  models.ApiError
 // @Security  token
+// @Security  bearer
 // @Router    /user/settings/update/username [patch]
 func (h *Handler) EditUserUsername(c *gin.Context) {
 	/*
@@ -81,14 +79,12 @@ func (h *Handler) EditUserUsername(c *gin.Context) {
 	*/
 
 	var data models.UserUsername
-	userId := h.RedisClient.VerifyToken(c.Request.Context(), c.GetHeader("token"))
+	userId := c.GetInt(userIdKey)
 
 	// Input data check
 	switch {
 	case c.ShouldBindJSON(&data) != nil:
 		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
-	case userId == 0:
-		NewErrorResponse(c, http.StatusNotFound, "Inactive user.")
 	case !h.PostgresDB.CheckUserUsername(data.Username):
 		NewErrorResponse(c, http.StatusBadRequest, "This username is already in use.")
 	}
@@ -113,6 +109,90 @@ func (h *Handler) EditUserUsername(c *gin.Context) {
 	})
 }
 
+// @Summary   Change user timezone
+// @Tags      User settings
+// @Accept    json
+// @Produce   json
+// @Param     NewUserTimezone  body      models.UserTimezone  true  "IANA time zone name"
+// @Success   200              {object}  models.ApiMessage
+// @Failure   400              {object}  models.ApiError
+// @Failure   401              {object}  models.ApiError
+// @Failure   500              {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /user/settings/update/timezone [patch]
+func (h *Handler) EditUserTimezone(c *gin.Context) {
+	/*
+		Example of JSON received
+
+		{
+		  "timezone": "Europe/Moscow"
+		}
+	*/
+
+	var data models.UserTimezone
+	userId := c.GetInt(userIdKey)
+
+	// Input data check
+	if c.ShouldBindJSON(&data) != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+		return
+	}
+
+	// The empty name and "Local" are accepted by the time package, but they are not time zones of the user
+	if _, err := time.LoadLocation(data.Timezone); err != nil || data.Timezone == "" || data.Timezone == "Local" {
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect timezone.")
+		return
+	}
+
+	// Updating a user's timezone
+	if err := h.PostgresDB.UpdateUser(models.Users{Id: userId}, models.Users{Timezone: data.Timezone}); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "Timezone updated successfully.",
+	})
+}
+
+// @Summary   Change completion rollup, with it the tasks are completed when all their subtasks are done
+// @Tags      User settings
+// @Accept    json
+// @Produce   json
+// @Param     CompletionRollup  body      models.UserCompletionRollup  true  "Is the rollup enabled"
+// @Success   200               {object}  models.ApiMessage
+// @Failure   401               {object}  models.ApiError
+// @Failure   500               {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /user/settings/update/rollup [patch]
+func (h *Handler) EditUserCompletionRollup(c *gin.Context) {
+	/*
+		Example of JSON received
+
+		{
+		  "rollup": true
+		}
+	*/
+
+	var data models.UserCompletionRollup
+	if c.ShouldBindJSON(&data) != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+		return
+	}
+
+	// Updating a user's completion rollup
+	if err := h.PostgresDB.UpdateUserCompletionRollup(c.GetInt(userIdKey), data.Rollup); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "Completion rollup updated successfully.",
+	})
+}
+
 // @Summary   Reset user email
 // @Tags      User settings
 // @Accept    json
@@ -120,8 +200,12 @@ func (h *Handler) EditUserUsername(c *gin.Context) {
 // @Param     NewUserEmail  body      models.UserEmail   true  "New user email"
 // @Success   200           {object}  models.ApiMessage
 // @Failure   400           {object}  models.ApiError
+// @Failure   401           {object}  models.ApiError
+// @Failure   403           {object}  models.ApiError
+// @Failure   429           {object}  models.ApiError
 // @Failure   500           {object}  models.ApiError
 // @Security  token
+// @Security  bearer
 // @Router    /user/settings/reset/email [post]
 func (h *Handler) ResetUserEmail(c *gin.Context) {
 	/*
@@ -133,14 +217,12 @@ func (h *Handler) ResetUserEmail(c *gin.Context) {
 	*/
 
 	var data models.UserEmail
-	userId := h.RedisClient.VerifyToken(c.Request.Context(), c.GetHeader("token"))
+	userId := c.GetInt(userIdKey)
 
 	// Input data check
 	switch {
 	case c.ShouldBindJSON(&data) != nil:
 		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
-	case userId == 0:
-		NewErrorResponse(c, http.StatusNotFound, "Inactive user.")
 	case h.PostgresDB.CheckUserEmail(data.Email):
 		NewErrorResponse(c, http.StatusBadRequest, "Wrong email.")
 	}
@@ -148,6 +230,10 @@ func (h *Handler) ResetUserEmail(c *gin.Context) {
 		return
 	}
 
+	if !h.limitEmailSending(c, data.Email) {
+		return
+	}
+
 	// Create a temporary access key to verify new email fidelity and then send it
 	if err := h.EmailAuthData.UserEmailReset(c.Request.Context(), h.RedisClient, data.Email, userId); err != nil {
 		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
@@ -170,9 +256,16 @@ func (h *Handler) ResetUserEmail(c *gin.Context) {
 // @Router   /user/settings/update/email [patch]
 func (h *Handler) UpdateUserEmail(c *gin.Context) {
 	// Checking that the key is in working order
-	userParam, err := h.RedisClient.GetUserData(c.Request.Context(), c.Query("key"))
-	if err != nil {
+	userParam, err := h.RedisClient.GetUserData(c.Request.Context(), models.EMAIL_CHANGE_FLOW, c.Query("key"))
+
+	// Input data check
+	switch {
+	case err != nil:
 		NewErrorResponse(c, http.StatusBadRequest, "Time has expired, your key is not valid.")
+	case !h.PostgresDB.CheckUserEmail(userParam.Email):
+		NewErrorResponse(c, http.StatusBadRequest, "Mail is already in use.")
+	}
+	if c.IsAborted() {
 		return
 	}
 
@@ -182,6 +275,11 @@ func (h *Handler) UpdateUserEmail(c *gin.Context) {
 		return
 	}
 
+	// Revoking the tokens of all user sessions
+	if !h.revokeSessions(c, userParam.Id) {
+		return
+	}
+
 	c.JSON(http.StatusOK, models.ApiMessage{
 		Message: "Email successfully updated.",
 	})
@@ -194,6 +292,7 @@ func (h *Handler) UpdateUserEmail(c *gin.Context) {
 // @Param    UserEmail  body      models.UserEmail  true  "User data"
 // @Success  200        {object}  models.UserTokens
 // @Failure  400        {object}  models.ApiError
+// @Failure  429        {object}  models.ApiError
 // @Failure  500        {object}  models.ApiError
 // @Router   /user/settings/reset/password [post]
 func (h *Handler) ResetUserPassword(c *gin.Context) {
@@ -218,6 +317,10 @@ func (h *Handler) ResetUserPassword(c *gin.Context) {
 		return
 	}
 
+	if !h.limitEmailSending(c, data.Email) {
+		return
+	}
+
 	// Create a temporary access key to verify email fidelity and then send it
 	if err := h.EmailAuthData.UserPasswordReset(c.Request.Context(), h.RedisClient, data.Email); err != nil {
 		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
@@ -262,7 +365,7 @@ func (h *Handler) UpdateUserPassword(c *gin.Context) {
 	}
 
 	// Checking that the key is in working order
-	val, err := h.RedisClient.GetEmailData(c.Request.Context(), c.Query("key"))
+	val, err := h.RedisClient.GetEmailData(c.Request.Context(), models.PASSWORD_RESET_FLOW, c.Query("key"))
 	if err != nil {
 		NewErrorResponse(c, http.StatusBadRequest, "Time has expired, your key is not valid.")
 		return
@@ -274,6 +377,17 @@ func (h *Handler) UpdateUserPassword(c *gin.Context) {
 		return
 	}
 
+	// Revoking the tokens of all user sessions and the personal access tokens
+	if !h.revokeCredentials(c, h.PostgresDB.GetUserByEmail(val).Id) {
+		return
+	}
+
+	// The new password unlocks the sign in
+	if err := h.RedisClient.ResetLimit(c.Request.Context(), signInLimitKey(val)); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	c.JSON(http.StatusOK, models.ApiMessage{
 		Message: "Password successfully updated.",
 	})
@@ -285,18 +399,20 @@ func (h *Handler) UpdateUserPassword(c *gin.Context) {
 // @Produce  json
 // @Param    refresh_token  header    string  true  "Refresh token"
 // @Success  200            {object}  models.UserTokens
+// @Failure  404            {object}  models.ApiError
+// @Failure  500            {object}  models.ApiError
 // @Router   /user/settings/update/token [put]
 func (h *Handler) UpdateUserToken(c *gin.Context) {
-	userId := h.RedisClient.VerifyRefreshToken(c.Request.Context(), c.GetHeader("refresh_token"))
+	session := h.RedisClient.VerifyRefreshToken(c.Request.Context(), c.GetHeader("refresh_token"))
 
 	// Input data check
-	if userId == 0 {
+	if session.UserId == 0 {
 		NewErrorResponse(c, http.StatusNotFound, "Inactive user.")
 		return
 	}
 
-	// Create a new token for the user
-	accessToken, refreshToken, err := h.RedisClient.CreateTokens(c.Request.Context(), userId)
+	// Create a new token for the user session
+	accessToken, refreshToken, err := h.RedisClient.CreateTokens(c.Request.Context(), session)
 	if err != nil {
 		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
@@ -315,14 +431,16 @@ func (h *Handler) UpdateUserToken(c *gin.Context) {
 // @Param     icon  formData  file  true  "User icon"
 // @Success   200   {object}  models.ApiMessage
 // @Failure   400   {object}  models.ApiError
+// @Failure   401   {object}  models.ApiError
 // @Failure   500   {object}  models.ApiError
 // @Security  token
+// @Security  bearer
 // @Router    /user/settings/update/icon [put]
 func (h *Handler) UpdateUserIcon(c *gin.Context) {
 	// Setting a limit for the size of the user's icon
 	// c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, models.MAX_ICON_UPLOAD_SIZE)
 
-	userId := h.RedisClient.VerifyToken(c.Request.Context(), c.GetHeader("token"))
+	userId := c.GetInt(userIdKey)
 	file, fileHeader, err := c.Request.FormFile("icon")
 
 	// Input data check
@@ -331,8 +449,6 @@ func (h *Handler) UpdateUserIcon(c *gin.Context) {
 		NewErrorResponse(c, http.StatusBadRequest, "No such file.")
 	case fileHeader.Size > models.MAX_ICON_UPLOAD_SIZE:
 		NewErrorResponse(c, http.StatusBadRequest, "Icon is too large.")
-	case userId == 0:
-		NewErrorResponse(c, http.StatusNotFound, "Inactive user.")
 	}
 	if c.IsAborted() {
 		return
@@ -382,12 +498,14 @@ func (h *Handler) UpdateUserIcon(c *gin.Context) {
 // @Produce   json
 // @Success   200  {object}  models.ApiMessage
 // @Failure   400  {object}  models.ApiError
+// @Failure   401  {object}  models.ApiError
 // @Failure   404  {object}  models.ApiError
 // @Failure   500  {object}  models.ApiError
 // @Security  token
+// @Security  bearer
 // @Router    /user/delete/icon [delete]
 func (h *Handler) DeleteUserIcon(c *gin.Context) {
-	userId := h.RedisClient.VerifyToken(c.Request.Context(), c.GetHeader("token"))
+	userId := c.GetInt(userIdKey)
 	userData := h.PostgresDB.GetUserById(userId)
 
 	// Input data check
@@ -424,22 +542,19 @@ func (h *Handler) DeleteUserIcon(c *gin.Context) {
 // @Param     password  query     string  true  "User password"
 // @Success   200       {object}  models.ApiMessage
 // @Failure   400       {object}  models.ApiError
+// @Failure   401       {object}  models.ApiError
 // @Failure   404       {object}  models.ApiError
 // @Failure   500       {object}  models.ApiError
 // @Security  token
+// @Security  bearer
 // @Router    /user/delete/account [delete]
 func (h *Handler) DeleteUser(c *gin.Context) {
-	userId := h.RedisClient.VerifyToken(c.Request.Context(), c.GetHeader("token"))
+	userId := c.GetInt(userIdKey)
 	userData := h.PostgresDB.GetUserById(userId)
 
 	// Input data check
-	switch {
-	case userId == 0:
-		NewErrorResponse(c, http.StatusNotFound, "Inactive user.")
-	case bcrypt.CompareHashAndPassword([]byte(userData.Password), []byte(c.Query("password"))) != nil:
+	if bcrypt.CompareHashAndPassword([]byte(userData.Password), []byte(c.Query("password"))) != nil {
 		NewErrorResponse(c, http.StatusBadRequest, "Incorrect password.")
-	}
-	if c.IsAborted() {
 		return
 	}
 
@@ -449,13 +564,7 @@ func (h *Handler) DeleteUser(c *gin.Context) {
 		return
 	}
 
-	if err := h.RedisClient.DeleteAccessTokensData(c.Request.Context(), userData.Id); err != nil {
-		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
-		return
-	}
-
-	if err := h.RedisClient.DeleteRefreshTokensData(c.Request.Context(), userData.Id); err != nil {
-		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+	if !h.revokeSessions(c, userData.Id) {
 		return
 	}
 
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/NKTKLN/todo-api/models"
+	"github.com/NKTKLN/todo-api/pkg/common"
+)
+
+// @Summary   Shows not done tasks from all lists due today in the user timezone
+// @Tags      Views
+// @Accept    json
+// @Produce   json
+// @Param     limit   query     int     false  "Number of tasks on the page, all tasks without the limit and the cursor"
+// @Param     cursor  query     string  false  "Cursor of the page from next_cursor"
+// @Success   200     {object}  models.ApiShowViewTasks
+// @Failure   400     {object}  models.ApiError
+// @Failure   401     {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/views/today [get]
+func (h *Handler) ShowTodayTasks(c *gin.Context) {
+	location := h.userLocation(c.GetInt(userIdKey))
+	today := startOfDay(time.Now().In(location))
+
+	h.showViewTasks(c, models.TasksFilter{Done: boolPtr(false), DueAfter: today, DueBefore: today.AddDate(0, 0, 1)}, location)
+}
+
+// @Summary   Shows not done tasks from all lists due in the next days after today in the user timezone
+// @Tags      Views
+// @Accept    json
+// @Produce   json
+// @Param     limit   query     int     false  "Number of tasks on the page, all tasks without the limit and the cursor"
+// @Param     cursor  query     string  false  "Cursor of the page from next_cursor"
+// @Success   200     {object}  models.ApiShowViewTasks
+// @Failure   400     {object}  models.ApiError
+// @Failure   401     {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/views/upcoming [get]
+func (h *Handler) ShowUpcomingTasks(c *gin.Context) {
+	location := h.userLocation(c.GetInt(userIdKey))
+	tomorrow := startOfDay(time.Now().In(location)).AddDate(0, 0, 1)
+
+	h.showViewTasks(c, models.TasksFilter{Done: boolPtr(false), DueAfter: tomorrow, DueBefore: tomorrow.AddDate(0, 0, models.UPCOMING_DAYS)}, location)
+}
+
+// @Summary   Shows not done tasks from all lists whose end time has passed
+// @Tags      Views
+// @Accept    json
+// @Produce   json
+// @Param     limit   query     int     false  "Number of tasks on the page, all tasks without the limit and the cursor"
+// @Param     cursor  query     string  false  "Cursor of the page from next_cursor"
+// @Success   200     {object}  models.ApiShowViewTasks
+// @Failure   400     {object}  models.ApiError
+// @Failure   401     {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/views/overdue [get]
+func (h *Handler) ShowOverdueTasks(c *gin.Context) {
+	h.showViewTasks(c, models.TasksFilter{Done: boolPtr(false), DueBefore: time.Now()}, h.userLocation(c.GetInt(userIdKey)))
+}
+
+// @Summary   Shows not done special tasks from all lists
+// @Tags      Views
+// @Accept    json
+// @Produce   json
+// @Param     limit   query     int     false  "Number of tasks on the page, all tasks without the limit and the cursor"
+// @Param     cursor  query     string  false  "Cursor of the page from next_cursor"
+// @Success   200     {object}  models.ApiShowViewTasks
+// @Failure   400     {object}  models.ApiError
+// @Failure   401     {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/views/starred [get]
+func (h *Handler) ShowStarredTasks(c *gin.Context) {
+	h.showViewTasks(c, models.TasksFilter{Done: boolPtr(false), Special: boolPtr(true)}, h.userLocation(c.GetInt(userIdKey)))
+}
+
+// @Summary   Shows not done tasks and subtasks from all lists assigned to the user
+// @Tags      Views
+// @Accept    json
+// @Produce   json
+// @Param     limit   query     int     false  "Number of tasks on the page, all tasks without the limit and the cursor"
+// @Param     cursor  query     string  false  "Cursor of the page from next_cursor"
+// @Success   200     {object}  models.ApiShowViewTasks
+// @Failure   400     {object}  models.ApiError
+// @Failure   401     {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/views/assigned-to-me [get]
+func (h *Handler) ShowAssignedTasks(c *gin.Context) {
+	after, limit, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	// Get data from the db
+	filter := models.TasksFilter{Done: boolPtr(false)}
+	tasks, next := h.PostgresDB.GetAssignedTasks(c.GetInt(userIdKey), filter, after, limit)
+	localViewEndTimes(tasks, h.userLocation(c.GetInt(userIdKey)))
+	c.JSON(http.StatusOK, models.ApiShowViewTasks{
+		Tasks:      tasks,
+		NextCursor: common.EncodeCursor(next),
+	})
+}
+
+func (h *Handler) showViewTasks(c *gin.Context, filter models.TasksFilter, location *time.Location) {
+	after, limit, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	// Get data from the db
+	tasks, next := h.PostgresDB.GetUserTasks(c.GetInt(userIdKey), filter, after, limit)
+	localViewEndTimes(tasks, location)
+	c.JSON(http.StatusOK, models.ApiShowViewTasks{
+		Tasks:      tasks,
+		NextCursor: common.EncodeCursor(next),
+	})
+}
+
+// userLocation returns the timezone of the user, UTC is used if the timezone is not set
+func (h *Handler) userLocation(userId int) *time.Location {
+	location, err := time.LoadLocation(h.PostgresDB.GetUserById(userId).Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// localTime shows the UTC time from the database in the location, empty and zero times are kept as is
+func localTime(value string, location *time.Location) string {
+	t, err := time.Parse("2006-01-02 15:04", value)
+	if err != nil || t.IsZero() {
+		return value
+	}
+	return t.In(location).Format("2006-01-02 15:04")
+}
+
+func localViewEndTimes(tasks []models.ViewTasksData, location *time.Location) {
+	for index := range tasks {
+		tasks[index].EndTime = localTime(tasks[index].EndTime, location)
+	}
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func boolPtr(value bool) *bool {
+	return &value
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/NKTKLN/todo-api/models"
+)
+
+// @Summary   Create workspace, the user who created it becomes its admin
+// @Tags      Working with workspaces
+// @Accept    json
+// @Produce   json
+// @Param     WorkspaceData  body      models.ApiWorkspaceData  true  "Workspace data"
+// @Success   200            {object}  models.ApiMessage
+// @Failure   400            {object}  models.ApiError
+// @Failure   401            {object}  models.ApiError
+// @Failure   500            {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/workspace/add [post]
+func (h *Handler) AddWorkspace(c *gin.Context) {
+	/*
+		Example of JSON received
+
+		{
+		  "name": "Company"
+		}
+	*/
+
+	var data models.ApiWorkspaceData
+
+	// Input data check
+	switch {
+	case c.ShouldBindJSON(&data) != nil:
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+	case data.Name == "":
+		NewErrorResponse(c, http.StatusBadRequest, "Empty name.")
+	case len(data.Name) > 32:
+		NewErrorResponse(c, http.StatusBadRequest, "A name longer than 32 characters.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	// Creating new workspace
+	if err := h.PostgresDB.CreateWorkspace(models.Workspaces{Name: data.Name}, c.GetInt(userIdKey)); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The workspace has been created.",
+	})
+}
+
+// @Summary   Delete workspace together with its lists, only admins of the workspace can delete it
+// @Tags      Working with workspaces
+// @Accept    json
+// @Produce   json
+// @Param     workspace_id  query     int  true  "The id of the workspace to be deleted"
+// @Success   200           {object}  models.ApiMessage
+// @Failure   401           {object}  models.ApiError
+// @Failure   403           {object}  models.ApiError
+// @Failure   404           {object}  models.ApiError
+// @Failure   500           {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/workspace/delete [delete]
+func (h *Handler) DeleteWorkspace(c *gin.Context) {
+	workspaceId, err := strconv.Atoi(c.Query("workspace_id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Error when converting workspace_id.")
+		return
+	}
+	if !h.checkWorkspaceRole(c, workspaceId, models.LIST_ADMIN_ROLE) {
+		return
+	}
+
+	// Deleting the workspace
+	if err := h.PostgresDB.DeleteWorkspace(workspaceId); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The workspace has been deleted.",
+	})
+}
+
+// @Summary   Rename workspace, only admins of the workspace can rename it
+// @Tags      Working with workspaces
+// @Accept    json
+// @Produce   json
+// @Param     WorkspaceData  body      models.WorkspaceEditData  true  "Workspace data"
+// @Success   200            {object}  models.ApiMessage
+// @Failure   400            {object}  models.ApiError
+// @Failure   401            {object}  models.ApiError
+// @Failure   403            {object}  models.ApiError
+// @Failure   404            {object}  models.ApiError
+// @Failure   500            {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/workspace/edit [put]
+func (h *Handler) EditWorkspace(c *gin.Context) {
+	/*
+		Example of JSON received
+
+		{
+		  "id": 1023456789,
+		  "name": "New company"
+		}
+	*/
+
+	var data models.WorkspaceEditData
+	if c.ShouldBindJSON(&data) != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+		return
+	}
+	if !h.checkWorkspaceRole(c, data.Id, models.LIST_ADMIN_ROLE) {
+		return
+	}
+
+	// Input data check
+	switch {
+	case data.Name == "":
+		NewErrorResponse(c, http.StatusBadRequest, "Empty name.")
+	case len(data.Name) > 32:
+		NewErrorResponse(c, http.StatusBadRequest, "A name longer than 32 characters.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	// Updating the workspace name
+	if err := h.PostgresDB.UpdateWorkspace(models.Workspaces{Id: data.Id, Name: data.Name}); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "Updating the workspace data was successful.",
+	})
+}
+
+// @Summary   Shows the workspaces of the user
+// @Tags      Working with workspaces
+// @Accept    json
+// @Produce   json
+// @Success   200  {object}  models.ApiShowWorkspaces
+// @Failure   401  {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/workspace/show [get]
+func (h *Handler) ShowWorkspaces(c *gin.Context) {
+	c.JSON(http.StatusOK, models.ApiShowWorkspaces{
+		Workspaces: h.PostgresDB.GetUserWorkspaces(c.GetInt(userIdKey)),
+	})
+}
+
+// checkWorkspaceRole checks that the user has the required role or a higher one in the workspace
+func (h *Handler) checkWorkspaceRole(c *gin.Context, workspaceId int, required string) bool {
+	switch role := h.PostgresDB.GetWorkspaceRole(workspaceId, c.GetInt(userIdKey)); {
+	case role == "":
+		NewErrorResponse(c, http.StatusNotFound, "This workspace not found.")
+	case !hasListRole(role, required):
+		NewErrorResponse(c, http.StatusForbidden, "Not enough rights.")
+	}
+	return !c.IsAborted()
+}
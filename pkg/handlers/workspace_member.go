@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/NKTKLN/todo-api/models"
+)
+
+// @Summary   Shows the members of the workspace
+// @Tags      Working with workspace members
+// @Accept    json
+// @Produce   json
+// @Param     workspace_id  query     int  true  "Workspace id"
+// @Success   200           {object}  models.ApiShowWorkspaceMembers
+// @Failure   401           {object}  models.ApiError
+// @Failure   404           {object}  models.ApiError
+// @Failure   500           {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/workspace/members [get]
+func (h *Handler) ShowWorkspaceMembers(c *gin.Context) {
+	workspaceId, err := strconv.Atoi(c.Query("workspace_id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Error when converting workspace_id.")
+		return
+	}
+	if !h.checkWorkspaceRole(c, workspaceId, models.LIST_VIEWER_ROLE) {
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiShowWorkspaceMembers{
+		Members: h.PostgresDB.GetWorkspaceMembers(workspaceId),
+	})
+}
+
+// @Summary   Add a member to the workspace by the username or email, only admins of the workspace can add members
+// @Tags      Working with workspace members
+// @Accept    json
+// @Produce   json
+// @Param     MemberData  body      models.WorkspaceMemberAddData  true  "Member data"
+// @Success   200         {object}  models.ApiMessage
+// @Failure   400         {object}  models.ApiError
+// @Failure   401         {object}  models.ApiError
+// @Failure   403         {object}  models.ApiError
+// @Failure   404         {object}  models.ApiError
+// @Failure   500         {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/workspace/members [post]
+func (h *Handler) AddWorkspaceMember(c *gin.Context) {
+	/*
+		Example of JSON received
+
+		{
+		  "role": "editor",
+		  "user": "NKTKLN",
+		  "workspace_id": 1023456789
+		}
+	*/
+
+	var data models.WorkspaceMemberAddData
+	if c.ShouldBindJSON(&data) != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+		return
+	}
+	if !h.checkWorkspaceRole(c, data.WorkspaceId, models.LIST_ADMIN_ROLE) {
+		return
+	}
+
+	// Usernames can't contain the @
+	var user models.Users
+	if strings.Contains(data.User, "@") {
+		user = h.PostgresDB.GetUserByEmail(data.User)
+	} else {
+		user = h.PostgresDB.GetUserByUsername(data.User)
+	}
+
+	// Input data check
+	switch {
+	case !checkMemberRole(data.Role):
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect role.")
+	case user.Id == 0:
+		NewErrorResponse(c, http.StatusNotFound, "User not found.")
+	case h.PostgresDB.GetWorkspaceRole(data.WorkspaceId, user.Id) != "":
+		NewErrorResponse(c, http.StatusBadRequest, "This user is already a member of the workspace.")
+	case len(h.PostgresDB.GetWorkspaceMembers(data.WorkspaceId)) >= models.MAX_WORKSPACE_MEMBERS:
+		NewErrorResponse(c, http.StatusBadRequest, "Too many members.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	// Adding the member
+	err := h.PostgresDB.CreateWorkspaceMember(models.WorkspaceMembers{WorkspaceId: data.WorkspaceId, UserId: user.Id, Role: data.Role})
+	if err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The member has been added to the workspace.",
+	})
+}
+
+// @Summary   Change the role of the workspace member, only admins of the workspace can change roles
+// @Tags      Working with workspace members
+// @Accept    json
+// @Produce   json
+// @Param     MemberData  body      models.WorkspaceMemberEditData  true  "Member data"
+// @Success   200         {object}  models.ApiMessage
+// @Failure   400         {object}  models.ApiError
+// @Failure   401         {object}  models.ApiError
+// @Failure   403         {object}  models.ApiError
+// @Failure   404         {object}  models.ApiError
+// @Failure   500         {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/workspace/members [patch]
+func (h *Handler) EditWorkspaceMember(c *gin.Context) {
+	/*
+		Example of JSON received
+
+		{
+		  "role": "viewer",
+		  "user_id": 1023456789,
+		  "workspace_id": 1023456789
+		}
+	*/
+
+	var data models.WorkspaceMemberEditData
+	if c.ShouldBindJSON(&data) != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, "Data retrieval error.")
+		return
+	}
+	if !h.checkWorkspaceRole(c, data.WorkspaceId, models.LIST_ADMIN_ROLE) {
+		return
+	}
+	role := h.PostgresDB.GetWorkspaceRole(data.WorkspaceId, data.UserId)
+
+	// Input data check
+	switch {
+	case !checkMemberRole(data.Role):
+		NewErrorResponse(c, http.StatusBadRequest, "Incorrect role.")
+	case role == "":
+		NewErrorResponse(c, http.StatusNotFound, "This member not found.")
+	case role == models.LIST_ADMIN_ROLE && data.Role != models.LIST_ADMIN_ROLE && h.lastWorkspaceAdmin(data.WorkspaceId):
+		NewErrorResponse(c, http.StatusBadRequest, "The workspace must have an admin.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	// Updating the member role
+	err := h.PostgresDB.UpdateWorkspaceMemberRole(models.WorkspaceMembers{WorkspaceId: data.WorkspaceId, UserId: data.UserId, Role: data.Role})
+	if err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The member role has been changed.",
+	})
+}
+
+// @Summary   Remove the member from the workspace, only admins of the workspace can remove members and any member can leave
+// @Tags      Working with workspace members
+// @Accept    json
+// @Produce   json
+// @Param     workspace_id  query     int  true  "Workspace id"
+// @Param     user_id       query     int  true  "Id of the member to be removed"
+// @Success   200           {object}  models.ApiMessage
+// @Failure   400           {object}  models.ApiError
+// @Failure   401           {object}  models.ApiError
+// @Failure   403           {object}  models.ApiError
+// @Failure   404           {object}  models.ApiError
+// @Failure   500           {object}  models.ApiError
+// @Security  token
+// @Security  bearer
+// @Router    /todo/workspace/members [delete]
+func (h *Handler) DeleteWorkspaceMember(c *gin.Context) {
+	workspaceId, workspaceErr := strconv.Atoi(c.Query("workspace_id"))
+	memberId, memberErr := strconv.Atoi(c.Query("user_id"))
+	userId := c.GetInt(userIdKey)
+
+	// Input data check
+	switch {
+	case workspaceErr != nil:
+		NewErrorResponse(c, http.StatusInternalServerError, "Error when converting workspace_id.")
+	case memberErr != nil:
+		NewErrorResponse(c, http.StatusInternalServerError, "Error when converting user_id.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	// Members leave the workspace by removing themselves
+	required := models.LIST_ADMIN_ROLE
+	if memberId == userId {
+		required = models.LIST_VIEWER_ROLE
+	}
+	if !h.checkWorkspaceRole(c, workspaceId, required) {
+		return
+	}
+
+	switch role := h.PostgresDB.GetWorkspaceRole(workspaceId, memberId); {
+	case role == "":
+		NewErrorResponse(c, http.StatusNotFound, "This member not found.")
+	case role == models.LIST_ADMIN_ROLE && h.lastWorkspaceAdmin(workspaceId):
+		NewErrorResponse(c, http.StatusBadRequest, "The workspace must have an admin.")
+	}
+	if c.IsAborted() {
+		return
+	}
+
+	// Removing the member
+	if err := h.PostgresDB.DeleteWorkspaceMember(models.WorkspaceMembers{WorkspaceId: workspaceId, UserId: memberId}); err != nil {
+		NewServerErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApiMessage{
+		Message: "The member has been removed from the workspace.",
+	})
+}
+
+// lastWorkspaceAdmin checks that the workspace has only one admin
+func (h *Handler) lastWorkspaceAdmin(workspaceId int) bool {
+	var admins int
+	for _, member := range h.PostgresDB.GetWorkspaceMembers(workspaceId) {
+		if member.Role == models.LIST_ADMIN_ROLE {
+			admins++
+		}
+	}
+	return admins <= 1
+}
@@ -0,0 +1,30 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/NKTKLN/todo-api/models"
+	"github.com/NKTKLN/todo-api/pkg/common"
+)
+
+// Notifier delivers task reminders to a user
+type Notifier interface {
+	NotifyReminder(models.Users, models.Tasks) error
+}
+
+type EmailNotifier struct {
+	EmailProvider common.EmailProvider
+}
+
+func NewEmailNotifier(emailProvider common.EmailProvider) Notifier {
+	return &EmailNotifier{EmailProvider: emailProvider}
+}
+
+// NotifyReminder sends the reminder with the end time of the task in the user timezone
+func (n *EmailNotifier) NotifyReminder(user models.Users, task models.Tasks) error {
+	location, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		location = time.UTC
+	}
+	return n.EmailProvider.TaskReminder(user.Email, task.Name, task.EndTime.In(location))
+}
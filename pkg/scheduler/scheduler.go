@@ -0,0 +1,87 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/NKTKLN/todo-api/models"
+	"github.com/NKTKLN/todo-api/pkg/db"
+)
+
+// Scheduler sends the reminders queued in the redis. Every API replica runs its own scheduler: a reminder is claimed
+// in the redis by one replica at a time and is marked as sent in the postgres before the sending, so it is never
+// sent twice. A reminder that failed to send is claimed again when its lease expires.
+type Scheduler struct {
+	PostgresDB  db.PostgresDB
+	RedisClient db.RedisClient
+	Notifier    Notifier
+	Interval    time.Duration
+}
+
+// LoadReminders returns the unsent reminders to the redis queue, so they survive the loss of the redis data
+func (s *Scheduler) LoadReminders(ctx context.Context) error {
+	for _, reminder := range s.PostgresDB.GetUnsentReminders() {
+		if err := s.RedisClient.RestoreReminder(ctx, reminder.Id, reminder.RemindAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.SendReminders(ctx, now); err != nil {
+				logrus.Errorf("error when sending reminders: %s", err.Error())
+			}
+		}
+	}
+}
+
+// SendReminders sends all reminders whose time has come by now
+func (s *Scheduler) SendReminders(ctx context.Context, now time.Time) error {
+	ids, err := s.RedisClient.ClaimReminders(ctx, now, models.REMINDER_LEASE, models.REMINDERS_BATCH)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := s.sendReminder(ctx, id); err != nil {
+			logrus.Errorf("error when sending the reminder %d: %s", id, err.Error())
+		}
+	}
+	return nil
+}
+
+func (s *Scheduler) sendReminder(ctx context.Context, id int) error {
+	reminder := s.PostgresDB.GetReminderById(id)
+
+	// Deleted reminders and reminders sent by another replica are only removed from the queue
+	if reminder.Id != 0 && !reminder.Sent {
+		marked, err := s.PostgresDB.MarkReminderSent(id)
+		if err != nil {
+			return err
+		}
+
+		task := s.PostgresDB.GetTaskById(reminder.TaskId)
+		if marked && task.Id != 0 && !task.Done {
+			if err := s.Notifier.NotifyReminder(s.PostgresDB.GetUserById(reminder.UserId), task); err != nil {
+				// The reminder stays in the queue and is sent again after the lease
+				if err := s.PostgresDB.UpdateReminderTime(id, reminder.RemindAt); err != nil {
+					return err
+				}
+				return err
+			}
+		}
+	}
+
+	return s.RedisClient.UnscheduleReminder(ctx, id)
+}
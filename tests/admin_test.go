@@ -0,0 +1,414 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/NKTKLN/todo-api/models"
+	rd "github.com/NKTKLN/todo-api/pkg/db/redis"
+	"github.com/NKTKLN/todo-api/pkg/handlers"
+)
+
+var _ = Describe("Admin", func() {
+	var (
+		r                       *gin.Engine
+		w                       *httptest.ResponseRecorder
+		accessJwt               string
+		userAccessJwt           string
+		handler                 handlers.Handler
+		postgresMock            sqlmock.Sqlmock
+		redisClientEmail        *redis.Client
+		redisClientAccessToken  *redis.Client
+		redisClientRefreshToken *redis.Client
+		redisClientSession      *redis.Client
+	)
+
+	userColumns := []string{"id", "email", "name", "username", "password", "icon", "role", "disabled"}
+
+	// Query building for the admin check
+	expectAdmin := func() {
+		postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+			WithArgs(117115101114).
+			WillReturnRows(sqlmock.NewRows(userColumns).
+				AddRow(117115101114, "admin@example.com", "Admin", "admin", "", "", "admin", false))
+	}
+
+	// Query building for the moderated user
+	expectUser := func() {
+		postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+			WithArgs(115101114).
+			WillReturnRows(sqlmock.NewRows(userColumns).
+				AddRow(115101114, "email@example.com", "Test Name", "test_username", "", "", "user", false))
+	}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.ReleaseMode)
+
+		r = gin.New()
+		w = httptest.NewRecorder()
+
+		redisClientEmail = TestRedisConnection()
+		redisClientAccessToken = TestRedisConnection()
+		redisClientRefreshToken = TestRedisConnection()
+		redisClientSession = TestRedisConnection()
+
+		handler.EmailAuthData = NewFakeEmailProvider("email@example.com", "StRon9Pa$$w0rd", "smtp.example.com", 0)
+
+		handler.RedisClient = &rd.RedisClients{
+			EmailClient:        redisClientEmail,
+			AccessTokenClient:  redisClientAccessToken,
+			RefreshTokenClient: redisClientRefreshToken,
+			SessionClient:      redisClientSession,
+		}
+
+		handler.PostgresDB, postgresMock = MockPostgresConnection()
+
+		// Creating sessions of the admin and of the moderated user
+		accessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
+		userAccessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 115101114})
+	})
+
+	AfterEach(func() {
+		redisClientEmail.Close()
+		redisClientAccessToken.Close()
+		redisClientRefreshToken.Close()
+		redisClientSession.Close()
+	})
+
+	Describe("Admin middleware", func() {
+		BeforeEach(func() {
+			r.GET("/admin/users", handler.AuthMiddleware(), handlers.SessionMiddleware(), handler.AdminMiddleware(), handler.ShowUsers)
+
+			// Query building for the postgres
+			postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+				WithArgs(115101114).
+				WillReturnRows(sqlmock.NewRows(userColumns).
+					AddRow(115101114, "email@example.com", "Test Name", "test_username", "", "", "user", false))
+
+			// Sending a query with data
+			req := httptest.NewRequest(http.MethodGet, "/admin/users", nil)
+			req.Header.Set("token", userAccessJwt)
+			r.ServeHTTP(w, req)
+		})
+
+		It("should return an error that admin access is required", func() {
+			Expect(w.Code).To(Equal(http.StatusForbidden))
+			Expect(w.Body.String()).To(Equal(`{"error":"Admin access required."}`))
+		})
+	})
+
+	Describe("Show users", func() {
+		BeforeEach(func() {
+			r.GET("/admin/users", handler.AuthMiddleware(), handler.AdminMiddleware(), handler.ShowUsers)
+			expectAdmin()
+		})
+
+		Context("incorrect page", func() {
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/admin/users?page=0", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the page is incorrect", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Incorrect page."}`))
+			})
+		})
+
+		Context("incorrect limit", func() {
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/admin/users?limit=1000", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the limit is incorrect", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Incorrect limit."}`))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUsersCountBySearch)).
+					WithArgs("%test\\_%", "%test\\_%", "%test\\_%").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUsersBySearch+" LIMIT 2 OFFSET 2")).
+					WithArgs("%test\\_%", "%test\\_%", "%test\\_%").
+					WillReturnRows(sqlmock.NewRows(userColumns).
+						AddRow(115101114, "email@example.com", "Test Name", "test_username", "", "", "user", true))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/admin/users?search=test_&page=2&limit=2", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return the page of users", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"users":[{"id":115101114,"email":"email@example.com","name":"Test Name","username":"test_username","role":"user","disabled":true,"totp_enabled":false}],"page":2,"limit":2,"total":3}`))
+			})
+		})
+	})
+
+	Describe("Disable user", func() {
+		BeforeEach(func() {
+			r.POST("/admin/users/:id/disable", handler.AuthMiddleware(), handler.AdminMiddleware(), handler.DisableUser)
+			expectAdmin()
+		})
+
+		Context("user not found", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(115101114).
+					WillReturnRows(sqlmock.NewRows(userColumns))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/admin/users/115101114/disable", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the user is not found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(Equal(`{"error":"User not found."}`))
+			})
+		})
+
+		Context("own account", func() {
+			BeforeEach(func() {
+				expectAdmin()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/admin/users/117115101114/disable", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the admin can't moderate the own account", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"You can't moderate your own account."}`))
+			})
+		})
+
+		Context("admin account", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(115101114).
+					WillReturnRows(sqlmock.NewRows(userColumns).
+						AddRow(115101114, "other.admin@example.com", "Other Admin", "other_admin", "", "", "admin", false))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/admin/users/115101114/disable", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the admin can't moderate other admins", func() {
+				Expect(w.Code).To(Equal(http.StatusForbidden))
+				Expect(w.Body.String()).To(Equal(`{"error":"You can't moderate other admins."}`))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				expectUser()
+
+				// Query building for the postgres
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditUserDisabled)).
+					WithArgs(true, 115101114).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/admin/users/115101114/disable", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the account has been disabled", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The account has been disabled."}`))
+			})
+
+			It("should mark the user as disabled and revoke the sessions", func() {
+				Expect(handler.RedisClient.IsUserDisabled(context.Background(), 115101114)).To(BeTrue())
+				Expect(handler.RedisClient.VerifyToken(context.Background(), userAccessJwt)).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("Enable user", func() {
+		BeforeEach(func() {
+			r.POST("/admin/users/:id/enable", handler.AuthMiddleware(), handler.AdminMiddleware(), handler.EnableUser)
+			expectAdmin()
+			expectUser()
+
+			Expect(handler.RedisClient.AddDisabledUser(context.Background(), 115101114)).To(BeNil())
+
+			// Query building for the postgres
+			postgresMock.ExpectBegin()
+			postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditUserDisabled)).
+				WithArgs(false, 115101114).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			postgresMock.ExpectCommit()
+
+			// Sending a query with data
+			req := httptest.NewRequest(http.MethodPost, "/admin/users/115101114/enable", nil)
+			req.Header.Set("token", accessJwt)
+			r.ServeHTTP(w, req)
+		})
+
+		It("should return a message that the account has been enabled", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(Equal(`{"message":"The account has been enabled."}`))
+			Expect(handler.RedisClient.IsUserDisabled(context.Background(), 115101114)).To(BeFalse())
+		})
+	})
+
+	Describe("Force user password reset", func() {
+		BeforeEach(func() {
+			r.POST("/admin/users/:id/reset-password", handler.AuthMiddleware(), handler.AdminMiddleware(), handler.ForceUserPasswordReset)
+			expectAdmin()
+			expectUser()
+
+			// Query building for the postgres
+			postgresMock.ExpectBegin()
+			postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditUserPassword)).
+				WithArgs(AnyString{}, "email@example.com").
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			postgresMock.ExpectCommit()
+			postgresMock.ExpectBegin()
+			postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteAllUserPersonalTokens)).
+				WithArgs(115101114).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			postgresMock.ExpectCommit()
+
+			// Sending a query with data
+			req := httptest.NewRequest(http.MethodPost, "/admin/users/115101114/reset-password", nil)
+			req.Header.Set("token", accessJwt)
+			r.ServeHTTP(w, req)
+		})
+
+		It("should return a message that the password has been reset", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(Equal(`{"message":"The password has been reset, a reset key was sent to the user's email."}`))
+		})
+
+		It("should revoke all user sessions", func() {
+			Expect(handler.RedisClient.VerifyToken(context.Background(), userAccessJwt)).To(Equal(0))
+		})
+
+		It("should delete the personal access tokens", func() {
+			Expect(postgresMock.ExpectationsWereMet()).To(BeNil())
+		})
+	})
+
+	Describe("Revoke user sessions", func() {
+		BeforeEach(func() {
+			r.DELETE("/admin/users/:id/sessions", handler.AuthMiddleware(), handler.AdminMiddleware(), handler.RevokeUserSessions)
+			expectAdmin()
+			expectUser()
+
+			// Sending a query with data
+			req := httptest.NewRequest(http.MethodDelete, "/admin/users/115101114/sessions", nil)
+			req.Header.Set("token", accessJwt)
+			r.ServeHTTP(w, req)
+		})
+
+		It("should revoke all user sessions", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(Equal(`{"message":"The sessions have been revoked."}`))
+			Expect(handler.RedisClient.VerifyToken(context.Background(), userAccessJwt)).To(Equal(0))
+			Expect(handler.RedisClient.VerifyToken(context.Background(), accessJwt)).To(Equal(117115101114))
+		})
+	})
+
+	Describe("Delete user", func() {
+		BeforeEach(func() {
+			r.DELETE("/admin/users/:id", handler.AuthMiddleware(), handler.AdminMiddleware(), handler.AdminDeleteUser)
+			expectAdmin()
+			expectUser()
+
+			// Query building for the postgres
+			postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllListsByUserId)).
+				WithArgs(115101114).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}))
+			postgresMock.ExpectBegin()
+			postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteAllUserSavedFilters)).
+				WithArgs(115101114).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			postgresMock.ExpectCommit()
+			postgresMock.ExpectBegin()
+			postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteAllUserTags)).
+				WithArgs(115101114).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			postgresMock.ExpectCommit()
+
+			postgresMock.ExpectBegin()
+			postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteAllUserReminders)).
+				WithArgs(115101114).
+				WillReturnResult(sqlmock.NewResult(1, 0))
+			postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteAllUserAssignees)).
+				WithArgs(115101114).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteAllUserListMembers)).
+				WithArgs(115101114).
+				WillReturnResult(sqlmock.NewResult(1, 0))
+			postgresMock.ExpectCommit()
+			postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserAdminWorkspaceIds)).
+				WithArgs(115101114, "admin").
+				WillReturnRows(sqlmock.NewRows([]string{"workspace_id"}).
+					AddRow(119111114107))
+			postgresMock.ExpectBegin()
+			postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteAllUserWorkspaceMembers)).
+				WithArgs(115101114).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectWorkspaceMemberRoles)).
+				WithArgs(119111114107).
+				WillReturnRows(sqlmock.NewRows([]string{"role"}).
+					AddRow("viewer").
+					AddRow("editor"))
+			postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlPromoteWorkspaceMembers)).
+				WithArgs("admin", 119111114107, "editor").
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			postgresMock.ExpectCommit()
+			postgresMock.ExpectBegin()
+			postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteAllUserPersonalTokens)).
+				WithArgs(115101114).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			postgresMock.ExpectCommit()
+			postgresMock.ExpectBegin()
+			postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteUser)).
+				WithArgs(115101114).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			postgresMock.ExpectCommit()
+
+			// Sending a query with data
+			req := httptest.NewRequest(http.MethodDelete, "/admin/users/115101114", nil)
+			req.Header.Set("token", accessJwt)
+			r.ServeHTTP(w, req)
+		})
+
+		It("should return a message that the account was successfully deleted", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(Equal(`{"message":"The account has been deleted."}`))
+			Expect(handler.RedisClient.VerifyToken(context.Background(), userAccessJwt)).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,331 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/NKTKLN/todo-api/models"
+	rd "github.com/NKTKLN/todo-api/pkg/db/redis"
+	"github.com/NKTKLN/todo-api/pkg/handlers"
+)
+
+var _ = Describe("Assignees", func() {
+	var (
+		r                       *gin.Engine
+		w                       *httptest.ResponseRecorder
+		accessJwt               string
+		handler                 handlers.Handler
+		postgresMock            sqlmock.Sqlmock
+		redisClientAccessToken  *redis.Client
+		redisClientRefreshToken *redis.Client
+		redisClientSession      *redis.Client
+	)
+
+	userColumns := []string{"id", "email", "name", "username", "password", "icon", "role", "disabled"}
+	assigneeColumns := []string{"task_id", "user_id"}
+
+	// The task is found in the list where the user has the role
+	expectTaskList := func(role string) {
+		postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+			WithArgs(11697115107).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).
+				AddRow(11697115107))
+
+		postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
+			WithArgs(117115101114, 117115101114, 11697115107).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).
+				AddRow(108105115116))
+
+		postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+			WithArgs(117115101114, "owner", 117115101114, 108105115116).
+			WillReturnRows(sqlmock.NewRows([]string{"role"}).
+				AddRow(role))
+	}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.ReleaseMode)
+
+		r = gin.New()
+		w = httptest.NewRecorder()
+
+		redisClientAccessToken = TestRedisConnection()
+		redisClientRefreshToken = TestRedisConnection()
+		redisClientSession = TestRedisConnection()
+
+		handler.RedisClient = &rd.RedisClients{
+			AccessTokenClient:  redisClientAccessToken,
+			RefreshTokenClient: redisClientRefreshToken,
+			SessionClient:      redisClientSession,
+		}
+
+		handler.PostgresDB, postgresMock = MockPostgresConnection()
+		handler.EmailAuthData = NewFakeEmailProvider("email@example.com", "StRon9Pa$$w0rd", "smtp.example.com", 0)
+
+		// Creating new session with a jwt token
+		accessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
+	})
+
+	AfterEach(func() {
+		redisClientAccessToken.Close()
+		redisClientRefreshToken.Close()
+		redisClientSession.Close()
+
+		Expect(postgresMock.ExpectationsWereMet()).ShouldNot(HaveOccurred())
+	})
+
+	Describe("Add assignee", func() {
+		const requestBody = `{"task_id": 11697115107, "user_id": 117115101115}`
+
+		BeforeEach(func() {
+			r.POST("/todo/assignee/add", handler.AuthMiddleware(), handler.AddAssignee)
+		})
+
+		Context("this task not found", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+					WithArgs(11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
+					WithArgs(117115101114, 117115101114, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/assignee/add", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the task not found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(Equal(`{"error":"This task not found."}`))
+			})
+		})
+
+		Context("not enough rights", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectTaskList("viewer")
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/assignee/add", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that there are not enough rights", func() {
+				Expect(w.Code).To(Equal(http.StatusForbidden))
+				Expect(w.Body.String()).To(Equal(`{"error":"Not enough rights."}`))
+			})
+		})
+
+		Context("the user is not a member of the list", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectTaskList("editor")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101115, "owner", 117115101115, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/assignee/add", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the user is not a member of the list", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"This user is not a member of the list."}`))
+			})
+		})
+
+		Context("already assigned", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectTaskList("owner")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101115, "owner", 117115101115, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("viewer"))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskAssignee)).
+					WithArgs(11697115107, 117115101115).
+					WillReturnRows(sqlmock.NewRows(assigneeColumns).
+						AddRow(11697115107, 117115101115))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/assignee/add", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the user is already assigned", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"This user is already assigned to the task."}`))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectTaskList("owner")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101115, "owner", 117115101115, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("viewer"))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskAssignee)).
+					WithArgs(11697115107, 117115101115).
+					WillReturnRows(sqlmock.NewRows(assigneeColumns))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskAssignees)).
+					WithArgs(11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"task_id", "id", "username"}))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlInsertTaskAssignee)).
+					WithArgs(11697115107, 117115101115).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101115).
+					WillReturnRows(sqlmock.NewRows(userColumns).
+						AddRow(117115101115, "test@example.com", "Test", "test", "", "", "user", false))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+					WithArgs(11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "name"}).
+						AddRow(11697115107, 108105115116, "Test Task Name"))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListById)).
+					WithArgs(108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
+						AddRow(108105115116, 117115101114, "Test List Name", "Test List Comment", 0))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows(userColumns).
+						AddRow(117115101114, "email@example.com", "Test Name", "nktkln", "", "", "user", false))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/assignee/add", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should assign the task and notify the assignee", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The task has been assigned."}`))
+				Expect(handler.EmailAuthData.(*fakeEmailAuthData).assignedEmails).To(Equal([]string{"test@example.com"}))
+			})
+		})
+	})
+
+	Describe("Delete assignee", func() {
+		BeforeEach(func() {
+			r.DELETE("/todo/assignee/delete", handler.AuthMiddleware(), handler.DeleteAssignee)
+		})
+
+		Context("error when converting user_id", func() {
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, `/todo/assignee/delete?task_id=11697115107&user_id="117115101115"`, nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error message when converting the user_id", func() {
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+				Expect(w.Body.String()).To(Equal(`{"error":"Error when converting user_id."}`))
+			})
+		})
+
+		Context("not enough rights to remove another assignee", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectTaskList("viewer")
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/todo/assignee/delete?task_id=11697115107&user_id=117115101115", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that there are not enough rights", func() {
+				Expect(w.Code).To(Equal(http.StatusForbidden))
+				Expect(w.Body.String()).To(Equal(`{"error":"Not enough rights."}`))
+			})
+		})
+
+		Context("this assignee not found", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectTaskList("editor")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskAssignee)).
+					WithArgs(11697115107, 117115101115).
+					WillReturnRows(sqlmock.NewRows(assigneeColumns))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/todo/assignee/delete?task_id=11697115107&user_id=117115101115", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the assignee not found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(Equal(`{"error":"This assignee not found."}`))
+			})
+		})
+
+		Context("the viewer removes themselves", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+					WithArgs(11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(11697115107))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
+					WithArgs(117115101114, 117115101114, 11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(108105115116))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskAssignee)).
+					WithArgs(11697115107, 117115101114).
+					WillReturnRows(sqlmock.NewRows(assigneeColumns).
+						AddRow(11697115107, 117115101114))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskAssignee)).
+					WithArgs(11697115107, 117115101114).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/todo/assignee/delete?task_id=11697115107&user_id=117115101114", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the assignee has been removed", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The assignee has been removed from the task."}`))
+			})
+		})
+	})
+})
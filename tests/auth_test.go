@@ -30,6 +30,8 @@ var _ = Describe("Auth", func() {
 		redisClientEmail        *redis.Client
 		redisClientAccessToken  *redis.Client
 		redisClientRefreshToken *redis.Client
+		redisClientSession      *redis.Client
+		redisClientLimit        *redis.Client
 	)
 
 	BeforeEach(func() {
@@ -41,6 +43,8 @@ var _ = Describe("Auth", func() {
 		redisClientEmail = TestRedisConnection()
 		redisClientAccessToken = TestRedisConnection()
 		redisClientRefreshToken = TestRedisConnection()
+		redisClientSession = TestRedisConnection()
+		redisClientLimit = TestRedisConnection()
 
 		handler.EmailAuthData = NewFakeEmailProvider("email@example.com", "StRon9Pa$$w0rd", "smtp.example.com", 0)
 
@@ -48,6 +52,8 @@ var _ = Describe("Auth", func() {
 			EmailClient:        redisClientEmail,
 			AccessTokenClient:  redisClientAccessToken,
 			RefreshTokenClient: redisClientRefreshToken,
+			SessionClient:      redisClientSession,
+			LimitClient:        redisClientLimit,
 		}
 
 		handler.PostgresDB, postgresMock = MockPostgresConnection()
@@ -57,6 +63,8 @@ var _ = Describe("Auth", func() {
 		redisClientEmail.Close()
 		redisClientAccessToken.Close()
 		redisClientRefreshToken.Close()
+		redisClientSession.Close()
+		redisClientLimit.Close()
 
 		Expect(postgresMock.ExpectationsWereMet()).ShouldNot(HaveOccurred())
 	})
@@ -214,6 +222,59 @@ var _ = Describe("Auth", func() {
 			})
 		})
 
+		Context("invitation key is not valid", func() {
+			BeforeEach(func() {
+				const requestBody = `{"email": "email@example.com", "invite": "key", "name": "Test Name", "password": "StRon9Pa$$w0rd", "username": "test_username"}`
+
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllUsersByEmail)).
+					WithArgs("email@example.com").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "username", "password", "icon"}))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllUsersByUsername)).
+					WithArgs("test_username").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "username", "password", "icon"}))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/auth/sign-up", bytes.NewBufferString(requestBody))
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error about invalid invitation key", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Time has expired, your invitation key is not valid."}`))
+			})
+		})
+
+		Context("invitation sent to another email", func() {
+			BeforeEach(func() {
+				const requestBody = `{"email": "email@example.com", "invite": "key", "name": "Test Name", "password": "StRon9Pa$$w0rd", "username": "test_username"}`
+
+				// Adding data to redis
+				inviteData, err := json.Marshal(models.ListInvites{ListId: 108105115116, Email: "test@example.com", Role: "editor"})
+				Expect(err).To(BeNil())
+				redisClientEmail.Set(context.Background(), models.LIST_INVITE_FLOW+":key", inviteData, time.Minute)
+
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllUsersByEmail)).
+					WithArgs("email@example.com").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "username", "password", "icon"}))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllUsersByUsername)).
+					WithArgs("test_username").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "username", "password", "icon"}))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/auth/sign-up", bytes.NewBufferString(requestBody))
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error about invalid invitation key", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Time has expired, your invitation key is not valid."}`))
+			})
+		})
+
 		Context("ok", func() {
 			BeforeEach(func() {
 				const requestBody = `{"email": "email@example.com", "name": "Test Name", "password": "StRon9Pa$$w0rd", "username": "test_username"}`
@@ -264,7 +325,7 @@ var _ = Describe("Auth", func() {
 				Expect(err).To(BeNil())
 
 				// Adding data to redis
-				redisClientEmail.Set(context.Background(), "key", jsonData, time.Minute)
+				redisClientEmail.Set(context.Background(), models.VERIFICATION_FLOW+":key", jsonData, time.Minute)
 			})
 
 			Context("mail is already in use", func() {
@@ -329,7 +390,7 @@ var _ = Describe("Auth", func() {
 
 				postgresMock.ExpectBegin()
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlInsertUserData)).
-					WithArgs("email@example.com", AnyString{}, "Test Name", "test_username", "", AnyInt{}).
+					WithArgs("email@example.com", AnyString{}, "Test Name", "test_username", "", "", false, 0, nil, "user", false, "UTC", false, AnyInt{}).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).
 						AddRow(0))
 				postgresMock.ExpectCommit()
@@ -339,7 +400,7 @@ var _ = Describe("Auth", func() {
 				Expect(err).To(BeNil())
 
 				// Adding data to redis
-				redisClientEmail.Set(context.Background(), "key", jsonData, time.Minute)
+				redisClientEmail.Set(context.Background(), models.VERIFICATION_FLOW+":key", jsonData, time.Minute)
 
 				// Sending a query with data
 				req := httptest.NewRequest(http.MethodGet, "/auth/verify?key=key", nil)
@@ -355,6 +416,127 @@ var _ = Describe("Auth", func() {
 				Expect(tokens).NotTo(Equal(nil))
 			})
 		})
+
+		Context("Ok with an invitation to the list", func() {
+			var tokens models.UserTokens
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllUsersByEmail)).
+					WithArgs("email@example.com").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "username", "password", "icon"}))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllUsersByUsername)).
+					WithArgs("test_username").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "username", "password", "icon"}))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllUsersById)).
+					WithArgs(AnyInt{}).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "username", "password", "icon"}))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlInsertUserData)).
+					WithArgs("email@example.com", AnyString{}, "Test Name", "test_username", "", "", false, 0, nil, "user", false, "UTC", false, AnyInt{}).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(0))
+				postgresMock.ExpectCommit()
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListById)).
+					WithArgs(108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
+						AddRow(108105115116, 117115101114, "Test List Name", "Test List Comment", 0))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListMembersCount)).
+					WithArgs(108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlInsertListMember)).
+					WithArgs(108105115116, AnyInt{}, "editor").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Convert data to json
+				jsonData, err := json.Marshal(models.UserData{Email: "email@example.com", Username: "test_username", Password: "StRon9Pa$$w0rd", Name: "Test Name", Invite: "invite"})
+				Expect(err).To(BeNil())
+				inviteData, err := json.Marshal(models.ListInvites{ListId: 108105115116, Email: "email@example.com", Role: "editor"})
+				Expect(err).To(BeNil())
+
+				// Adding data to redis
+				redisClientEmail.Set(context.Background(), models.VERIFICATION_FLOW+":key", jsonData, time.Minute)
+				redisClientEmail.Set(context.Background(), models.LIST_INVITE_FLOW+":invite", inviteData, time.Minute)
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/auth/verify?key=key", nil)
+				r.ServeHTTP(w, req)
+
+				// Converting the query body into a model
+				Expect(json.Unmarshal(w.Body.Bytes(), &tokens)).To(BeNil())
+			})
+
+			It("should join the list and return a couple of new tokens", func() {
+				Expect(redisClientEmail.Exists(context.Background(), models.LIST_INVITE_FLOW+":invite").Val()).To(Equal(int64(0)))
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(tokens).NotTo(Equal(nil))
+			})
+		})
+
+		Context("Ok with an invitation to the full list", func() {
+			var tokens models.UserTokens
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllUsersByEmail)).
+					WithArgs("email@example.com").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "username", "password", "icon"}))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllUsersByUsername)).
+					WithArgs("test_username").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "username", "password", "icon"}))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllUsersById)).
+					WithArgs(AnyInt{}).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "username", "password", "icon"}))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlInsertUserData)).
+					WithArgs("email@example.com", AnyString{}, "Test Name", "test_username", "", "", false, 0, nil, "user", false, "UTC", false, AnyInt{}).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(0))
+				postgresMock.ExpectCommit()
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListById)).
+					WithArgs(108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
+						AddRow(108105115116, 117115101114, "Test List Name", "Test List Comment", 0))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListMembersCount)).
+					WithArgs(108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(models.MAX_LIST_MEMBERS))
+
+				// Convert data to json
+				jsonData, err := json.Marshal(models.UserData{Email: "email@example.com", Username: "test_username", Password: "StRon9Pa$$w0rd", Name: "Test Name", Invite: "invite"})
+				Expect(err).To(BeNil())
+				inviteData, err := json.Marshal(models.ListInvites{ListId: 108105115116, Email: "email@example.com", Role: "editor"})
+				Expect(err).To(BeNil())
+
+				// Adding data to redis
+				redisClientEmail.Set(context.Background(), models.VERIFICATION_FLOW+":key", jsonData, time.Minute)
+				redisClientEmail.Set(context.Background(), models.LIST_INVITE_FLOW+":invite", inviteData, time.Minute)
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/auth/verify?key=key", nil)
+				r.ServeHTTP(w, req)
+
+				// Converting the query body into a model
+				Expect(json.Unmarshal(w.Body.Bytes(), &tokens)).To(BeNil())
+			})
+
+			It("should skip joining the list and return a couple of new tokens", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(tokens.AccessToken).NotTo(BeEmpty())
+			})
+		})
 	})
 
 	Describe("Sign In", func() {
@@ -420,6 +602,29 @@ var _ = Describe("Auth", func() {
 			})
 		})
 
+		Context("disabled account", func() {
+			BeforeEach(func() {
+				// Password hash generation
+				hashedPassword, err := bcrypt.GenerateFromPassword([]byte("StRon9Pa$$w0rd"), bcrypt.DefaultCost)
+				Expect(err).To(BeNil())
+
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserByEmail)).
+					WithArgs("email@example.com").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "username", "password", "icon", "disabled"}).
+						AddRow(117115101114, "email@example.com", "", "", hashedPassword, "", true))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/auth/sign-in", bytes.NewBufferString(requestBody))
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the account is disabled", func() {
+				Expect(w.Code).To(Equal(http.StatusForbidden))
+				Expect(w.Body.String()).To(Equal(`{"error":"Account is disabled."}`))
+			})
+		})
+
 		Describe("Ok", func() {
 			var tokens models.UserTokens
 
@@ -432,7 +637,7 @@ var _ = Describe("Auth", func() {
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserByEmail)).
 					WithArgs("email@example.com").
 					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "username", "password", "icon"}).
-						AddRow(0, "email@example.com", "", "", hashedPassword, ""))
+						AddRow(117115101114, "email@example.com", "", "", hashedPassword, ""))
 			})
 
 			Context("without tokens in the db", func() {
@@ -451,29 +656,126 @@ var _ = Describe("Auth", func() {
 				})
 			})
 
-			Context("with tokens in the db", func() {
+			Context("with an existing session in the db", func() {
+				var existingAccessJwt string
+
 				BeforeEach(func() {
-					// Adding data to redis
-					ctx := context.Background()
-					redisClientRefreshToken.Set(ctx, "0", "VeRy$eCrEt@nDc0mPlExReFrE$Ht0kEn", time.Minute)
-					redisClientAccessToken.Set(ctx, "0", "VeRy$eCrEt@nDc0mPlEx@cCe$$T0KeN", time.Minute)
+					const requestBody = `{"device": "Test Device", "email": "email@example.com", "password": "StRon9Pa$$w0rd"}`
+
+					// Creating a session on another device
+					existingAccessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114, Device: "Another Device"})
 
 					// Sending a query with data
 					req := httptest.NewRequest(http.MethodGet, "/auth/sign-in", bytes.NewBufferString(requestBody))
+					req.Header.Set("User-Agent", "Test User Agent")
 					r.ServeHTTP(w, req)
 
 					// Converting the query body into a model
 					Expect(json.Unmarshal(w.Body.Bytes(), &tokens)).To(BeNil())
 				})
 
-				It("should return a couple of tokens from the db", func() {
+				It("should return a couple of tokens of a new session", func() {
 					Expect(w.Code).To(Equal(http.StatusOK))
-					Expect(tokens).To(Equal(models.UserTokens{
-						AccessToken:  "VeRy$eCrEt@nDc0mPlEx@cCe$$T0KeN",
-						RefreshToken: "VeRy$eCrEt@nDc0mPlExReFrE$Ht0kEn"},
-					))
+					Expect(tokens.AccessToken).NotTo(Equal(existingAccessJwt))
+
+					session := handler.RedisClient.VerifySession(context.Background(), tokens.AccessToken)
+					Expect(session.Device).To(Equal("Test Device"))
+					Expect(session.UserAgent).To(Equal("Test User Agent"))
+				})
+
+				It("should keep the session of another device active", func() {
+					sessions, err := handler.RedisClient.GetUserSessions(context.Background(), 117115101114)
+					Expect(err).To(BeNil())
+					Expect(sessions).To(HaveLen(2))
+					Expect(handler.RedisClient.VerifySession(context.Background(), existingAccessJwt).Device).To(Equal("Another Device"))
 				})
 			})
 		})
 	})
+
+	Describe("Sign out", func() {
+		var accessJwt, otherAccessJwt string
+
+		BeforeEach(func() {
+			r.POST("/auth/sign-out", handler.AuthMiddleware(), handler.SignOut)
+
+			// Creating sessions on two devices
+			accessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
+			otherAccessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
+		})
+
+		Context("missing access token", func() {
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/auth/sign-out", nil)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the access token is missing", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/auth/sign-out", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should revoke only the current session", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"You have been signed out."}`))
+				Expect(handler.RedisClient.VerifyToken(context.Background(), accessJwt)).To(Equal(0))
+				Expect(handler.RedisClient.VerifyToken(context.Background(), otherAccessJwt)).To(Equal(117115101114))
+			})
+		})
+	})
+
+	Describe("Sign out all", func() {
+		var accessJwt, otherAccessJwt string
+
+		BeforeEach(func() {
+			r.POST("/auth/sign-out-all", handler.AuthMiddleware(), handler.SignOutAll)
+
+			// Creating sessions on two devices
+			accessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
+			otherAccessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
+		})
+
+		Context("missing access token", func() {
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/auth/sign-out-all", nil)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the access token is missing", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/auth/sign-out-all", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should revoke all user sessions", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"You have been signed out of all sessions."}`))
+				Expect(handler.RedisClient.VerifyToken(context.Background(), accessJwt)).To(Equal(0))
+				Expect(handler.RedisClient.VerifyToken(context.Background(), otherAccessJwt)).To(Equal(0))
+
+				sessions, err := handler.RedisClient.GetUserSessions(context.Background(), 117115101114)
+				Expect(err).To(BeNil())
+				Expect(sessions).To(BeEmpty())
+			})
+		})
+	})
 })
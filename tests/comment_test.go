@@ -0,0 +1,514 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/NKTKLN/todo-api/models"
+	"github.com/NKTKLN/todo-api/pkg/common"
+	rd "github.com/NKTKLN/todo-api/pkg/db/redis"
+	"github.com/NKTKLN/todo-api/pkg/handlers"
+)
+
+var _ = Describe("Comments", func() {
+	var (
+		r                       *gin.Engine
+		w                       *httptest.ResponseRecorder
+		accessJwt               string
+		handler                 handlers.Handler
+		postgresMock            sqlmock.Sqlmock
+		redisClientAccessToken  *redis.Client
+		redisClientRefreshToken *redis.Client
+		redisClientSession      *redis.Client
+		comments                models.ApiShowComments
+	)
+
+	userColumns := []string{"id", "email", "name", "username", "password", "icon", "role", "disabled"}
+	commentColumns := []string{"id", "task_id", "user_id", "text", "created_at", "edited_at"}
+	createdAt := time.Date(2077, 12, 10, 13, 13, 0, 0, time.UTC)
+
+	// The task is found in the list where the user has the role
+	expectTaskList := func(role string) {
+		postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+			WithArgs(11697115107).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).
+				AddRow(11697115107))
+
+		postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
+			WithArgs(117115101114, 117115101114, 11697115107).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).
+				AddRow(108105115116))
+
+		postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+			WithArgs(117115101114, "owner", 117115101114, 108105115116).
+			WillReturnRows(sqlmock.NewRows([]string{"role"}).
+				AddRow(role))
+	}
+
+	// The comment of the author with the text is found in the task
+	expectComment := func(authorId int, text string) {
+		postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskCommentById)).
+			WithArgs(99111109109).
+			WillReturnRows(sqlmock.NewRows(commentColumns).
+				AddRow(99111109109, 11697115107, authorId, text, createdAt, nil))
+	}
+
+	// The names of the task and the author are taken for the notifications
+	expectMentionNames := func() {
+		postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+			WithArgs(11697115107).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "name"}).
+				AddRow(11697115107, 108105115116, "Test Task Name"))
+
+		postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+			WithArgs(117115101114).
+			WillReturnRows(sqlmock.NewRows(userColumns).
+				AddRow(117115101114, "email@example.com", "Test Name", "nktkln", "", "", "user", false))
+	}
+
+	DescribeTable("Parse mentions",
+		func(text string, usernames []string) {
+			Expect(common.ParseMentions(text)).To(Equal(usernames))
+		},
+		Entry("no mentions", "The design is ready", nil),
+		Entry("mentions in the text", "@nktkln and @test, take a look", []string{"nktkln", "test"}),
+		Entry("repeated mentions", "@test @Test @nktkln @test", []string{"test", "nktkln"}),
+		Entry("emails are not mentions", "Write to email@example.com", nil),
+	)
+
+	BeforeEach(func() {
+		gin.SetMode(gin.ReleaseMode)
+
+		r = gin.New()
+		w = httptest.NewRecorder()
+
+		redisClientAccessToken = TestRedisConnection()
+		redisClientRefreshToken = TestRedisConnection()
+		redisClientSession = TestRedisConnection()
+
+		handler.RedisClient = &rd.RedisClients{
+			AccessTokenClient:  redisClientAccessToken,
+			RefreshTokenClient: redisClientRefreshToken,
+			SessionClient:      redisClientSession,
+		}
+
+		handler.PostgresDB, postgresMock = MockPostgresConnection()
+		handler.EmailAuthData = NewFakeEmailProvider("email@example.com", "StRon9Pa$$w0rd", "smtp.example.com", 0)
+
+		// Creating new session with a jwt token
+		accessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
+	})
+
+	AfterEach(func() {
+		redisClientAccessToken.Close()
+		redisClientRefreshToken.Close()
+		redisClientSession.Close()
+
+		Expect(postgresMock.ExpectationsWereMet()).ShouldNot(HaveOccurred())
+	})
+
+	Describe("Add comment", func() {
+		BeforeEach(func() {
+			r.POST("/todo/comment/add", handler.AuthMiddleware(), handler.AddComment)
+		})
+
+		Context("this task not found", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+					WithArgs(11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
+					WithArgs(117115101114, 117115101114, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/comment/add", bytes.NewBufferString(`{"task_id": 11697115107, "text": "Done"}`))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the task not found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(Equal(`{"error":"This task not found."}`))
+			})
+		})
+
+		Context("not enough rights", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectTaskList("viewer")
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/comment/add", bytes.NewBufferString(`{"task_id": 11697115107, "text": "Done"}`))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that there are not enough rights", func() {
+				Expect(w.Code).To(Equal(http.StatusForbidden))
+				Expect(w.Body.String()).To(Equal(`{"error":"Not enough rights."}`))
+			})
+		})
+
+		Context("empty comment", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectTaskList("editor")
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/comment/add", bytes.NewBufferString(`{"task_id": 11697115107, "text": "  "}`))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the comment is empty", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Empty comment."}`))
+			})
+		})
+
+		Context("ok", func() {
+			const text = "@test @nktkln @unknown take a look"
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectTaskList("editor")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskCommentById)).
+					WithArgs(AnyInt{}).
+					WillReturnRows(sqlmock.NewRows(commentColumns))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlInsertTaskCommentData)).
+					WithArgs(11697115107, 117115101114, text, AnyTime{}, nil, AnyInt{}).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
+				postgresMock.ExpectCommit()
+
+				// Only the member of the list is notified, the author and the unknown users are skipped
+				expectMentionNames()
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserByUsername)).
+					WithArgs("test").
+					WillReturnRows(sqlmock.NewRows(userColumns).
+						AddRow(117115101115, "test@example.com", "Test", "test", "", "", "user", false))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101115, "owner", 117115101115, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("viewer"))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserByUsername)).
+					WithArgs("nktkln").
+					WillReturnRows(sqlmock.NewRows(userColumns).
+						AddRow(117115101114, "email@example.com", "Test Name", "nktkln", "", "", "user", false))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserByUsername)).
+					WithArgs("unknown").
+					WillReturnRows(sqlmock.NewRows(userColumns))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/comment/add", bytes.NewBufferString(`{"task_id": 11697115107, "text": "`+text+`"}`))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should add the comment and notify the mentioned member", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The comment has been added."}`))
+				Expect(handler.EmailAuthData.(*fakeEmailAuthData).mentionedEmails).To(Equal([]string{"test@example.com"}))
+			})
+		})
+	})
+
+	Describe("Edit comment", func() {
+		BeforeEach(func() {
+			r.PUT("/todo/comment/edit", handler.AuthMiddleware(), handler.EditComment)
+		})
+
+		Context("this comment not found", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskCommentById)).
+					WithArgs(99111109109).
+					WillReturnRows(sqlmock.NewRows(commentColumns))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPut, "/todo/comment/edit", bytes.NewBufferString(`{"id": 99111109109, "text": "Done"}`))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the comment not found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(Equal(`{"error":"This comment not found."}`))
+			})
+		})
+
+		Context("the comment of another user", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectComment(117115101115, "Done")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+					WithArgs(11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(11697115107))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
+					WithArgs(117115101114, 117115101114, 11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(108105115116))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPut, "/todo/comment/edit", bytes.NewBufferString(`{"id": 99111109109, "text": "Not done"}`))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that there are not enough rights", func() {
+				Expect(w.Code).To(Equal(http.StatusForbidden))
+				Expect(w.Body.String()).To(Equal(`{"error":"Not enough rights."}`))
+			})
+		})
+
+		Context("the comment is too long", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectComment(117115101114, "Done")
+				expectTaskList("editor")
+
+				// Sending a query with data
+				text := string(bytes.Repeat([]byte("a"), models.MAX_COMMENT_LENGTH+1))
+				req := httptest.NewRequest(http.MethodPut, "/todo/comment/edit", bytes.NewBufferString(`{"id": 99111109109, "text": "`+text+`"}`))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the comment is too long", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"The comment is too long."}`))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectComment(117115101114, "@nktkln take a look")
+				expectTaskList("editor")
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditTaskComment)).
+					WithArgs(AnyTime{}, "@nktkln @test take a look", 99111109109).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Only the newly mentioned members are notified
+				expectMentionNames()
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserByUsername)).
+					WithArgs("test").
+					WillReturnRows(sqlmock.NewRows(userColumns).
+						AddRow(117115101115, "test@example.com", "Test", "test", "", "", "user", false))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101115, "owner", 117115101115, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("editor"))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPut, "/todo/comment/edit", bytes.NewBufferString(`{"id": 99111109109, "text": "@nktkln @test take a look"}`))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should edit the comment and notify the newly mentioned member", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The comment has been edited."}`))
+				Expect(handler.EmailAuthData.(*fakeEmailAuthData).mentionedEmails).To(Equal([]string{"test@example.com"}))
+			})
+		})
+	})
+
+	Describe("Delete comment", func() {
+		BeforeEach(func() {
+			r.DELETE("/todo/comment/delete", handler.AuthMiddleware(), handler.DeleteComment)
+		})
+
+		Context("error when converting comment_id", func() {
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, `/todo/comment/delete?comment_id="99111109109"`, nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error message when converting the comment_id", func() {
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+				Expect(w.Body.String()).To(Equal(`{"error":"Error when converting comment_id."}`))
+			})
+		})
+
+		Context("the editor deletes the comment of another user", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectComment(117115101115, "Done")
+				expectTaskList("editor")
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/todo/comment/delete?comment_id=99111109109", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that there are not enough rights", func() {
+				Expect(w.Code).To(Equal(http.StatusForbidden))
+				Expect(w.Body.String()).To(Equal(`{"error":"Not enough rights."}`))
+			})
+		})
+
+		Context("the admin deletes the comment of another user", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectComment(117115101115, "Done")
+				expectTaskList("admin")
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskComment)).
+					WithArgs(99111109109).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/todo/comment/delete?comment_id=99111109109", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the comment has been deleted", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The comment has been deleted."}`))
+			})
+		})
+
+		Context("the author deletes their comment", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectComment(117115101114, "Done")
+				expectTaskList("editor")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101114, "owner", 117115101114, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("editor"))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskComment)).
+					WithArgs(99111109109).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/todo/comment/delete?comment_id=99111109109", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the comment has been deleted", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The comment has been deleted."}`))
+			})
+		})
+	})
+
+	Describe("Show comments", func() {
+		BeforeEach(func() {
+			r.GET("/todo/comment/show", handler.AuthMiddleware(), handler.ShowComments)
+			comments = models.ApiShowComments{}
+		})
+
+		Context("this task not found", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+					WithArgs(11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
+					WithArgs(117115101114, 117115101114, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/comment/show?task_id=11697115107", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the task not found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(Equal(`{"error":"This task not found."}`))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+					WithArgs(11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(11697115107))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
+					WithArgs(117115101114, 117115101114, 11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(108105115116))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskComments)).
+					WithArgs(11697115107).
+					WillReturnRows(sqlmock.NewRows(append(commentColumns, "username", "page_key")).
+						AddRow(99111109109, 11697115107, 117115101115, "@nktkln take a look", createdAt, createdAt.Add(30*time.Minute), "test", `["2077-12-10T13:13:00+00:00", 99111109109]`).
+						AddRow(99111109110, 11697115107, 117115101114, "Done", createdAt.Add(time.Hour), nil, "nktkln", `["2077-12-10T14:13:00+00:00", 99111109110]`))
+
+				// The times are shown in the user timezone
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "timezone"}).
+						AddRow(117115101114, "Asia/Tokyo"))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/comment/show?task_id=11697115107&limit=1", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+
+				json.Unmarshal(w.Body.Bytes(), &comments)
+			})
+
+			It("should return the first page of the comments", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(comments).To(Equal(models.ApiShowComments{
+					Comments: []models.CommentData{{
+						Id:        99111109109,
+						UserId:    117115101115,
+						Username:  "test",
+						Text:      "@nktkln take a look",
+						CreatedAt: "2077-12-10 22:13",
+						EditedAt:  "2077-12-10 22:43",
+					}},
+					NextCursor: common.EncodeCursor([]interface{}{"2077-12-10T13:13:00+00:00", 99111109109}),
+				}))
+			})
+		})
+	})
+})
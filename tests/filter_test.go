@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/NKTKLN/todo-api/models"
+	"github.com/NKTKLN/todo-api/pkg/common"
+)
+
+var _ = Describe("Filter expression", func() {
+	location, _ := time.LoadLocation("Asia/Tokyo")
+	now := time.Date(2077, time.December, 10, 13, 13, 0, 0, location)
+	today := time.Date(2077, time.December, 10, 0, 0, 0, 0, location)
+
+	Describe("Parsing", func() {
+		DescribeTable("incorrect filters",
+			func(query, message string) {
+				_, err := common.ParseFilter(query)
+				Expect(err).To(MatchError(message))
+			},
+			Entry("empty filter", " ", "empty filter"),
+			Entry("unknown term", "archived", `unknown term "archived"`),
+			Entry("missing AND", "done special", `expected AND before "special"`),
+			Entry("OR", "done OR special", "OR is not supported, terms can only be joined with AND"),
+			Entry("missing term", "done AND", `missing a term after "and"`),
+			Entry("negated category", `not category contains Work`, `"not" can only be used with "done" and "special", not with "category"`),
+			Entry("missing contains", "category Work", `expected "contains" after "category"`),
+			Entry("missing value", "text contains", `missing a value after "contains"`),
+			Entry("unclosed quote", `text contains "buy milk`, "unclosed double quote"),
+			Entry("incorrect days", "due within week days", `incorrect number of days "week"`),
+			Entry("missing days", "due within 7", `expected "days" after "7"`),
+			Entry("incorrect date", "due before 10.12.2077", `incorrect date "10.12.2077", expected the 2006-01-02 format`),
+			Entry("duplicate condition", "done AND not done", `duplicate "done" condition`),
+			Entry("conflicting due conditions", "overdue AND due within 7 days", "conflicting due conditions"),
+		)
+	})
+
+	Describe("Tasks filter", func() {
+		tasksFilter := func(query string) models.TasksFilter {
+			filter, err := common.ParseFilter(query)
+			Expect(err).To(BeNil())
+			return filter.TasksFilter(now)
+		}
+
+		It("should join the terms", func() {
+			notDone := false
+			Expect(tasksFilter(`category contains Work AND not done AND text contains "buy milk" AND due within 7 days`)).To(Equal(models.TasksFilter{
+				Done:      &notDone,
+				Category:  "Work",
+				Search:    "buy milk",
+				DueAfter:  today,
+				DueBefore: today.AddDate(0, 0, 8),
+			}))
+		})
+
+		It("should end the overdue range now", func() {
+			Expect(tasksFilter("overdue")).To(Equal(models.TasksFilter{DueBefore: now}))
+		})
+
+		It("should take the dates in the user timezone", func() {
+			special := true
+			Expect(tasksFilter("SPECIAL and Due After 2077-12-01 and due before 2078-01-01")).To(Equal(models.TasksFilter{
+				Special:   &special,
+				DueAfter:  time.Date(2077, time.December, 1, 0, 0, 0, 0, location),
+				DueBefore: time.Date(2078, time.January, 1, 0, 0, 0, 0, location),
+			}))
+		})
+	})
+})
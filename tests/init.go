@@ -2,6 +2,7 @@ package tests
 
 import (
 	"context"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/alicebob/miniredis"
@@ -52,12 +53,23 @@ type fakeEmailAuthData struct {
 	password string
 	server   string
 	port     int
+
+	lockedEmails    []string
+	remindedEmails  []string
+	invitedEmails   []string
+	assignedEmails  []string
+	mentionedEmails []string
 }
 
 type fakeEmailProvider interface {
 	UserEmailVerification(context.Context, db.RedisClient, models.UserData) error
 	UserPasswordReset(context.Context, db.RedisClient, string) error
 	UserEmailReset(context.Context, db.RedisClient, string, int) error
+	AccountLocked(string, time.Duration) error
+	TaskReminder(string, string, time.Time) error
+	ListInvite(context.Context, db.RedisClient, models.ListInvites, string, string) error
+	TaskAssigned(string, string, string, string) error
+	TaskMentioned(string, string, string, string) error
 }
 
 func NewFakeEmailProvider(senderEmail, emailPassword, emailServer string, emailServerPort int) fakeEmailProvider {
@@ -80,3 +92,28 @@ func (d *fakeEmailAuthData) UserPasswordReset(ctx context.Context, client db.Red
 func (d *fakeEmailAuthData) UserEmailReset(ctx context.Context, client db.RedisClient, userEmail string, userId int) (err error) {
 	return
 }
+
+func (d *fakeEmailAuthData) AccountLocked(userEmail string, lockout time.Duration) (err error) {
+	d.lockedEmails = append(d.lockedEmails, userEmail)
+	return
+}
+
+func (d *fakeEmailAuthData) TaskReminder(userEmail, taskName string, endTime time.Time) (err error) {
+	d.remindedEmails = append(d.remindedEmails, userEmail)
+	return
+}
+
+func (d *fakeEmailAuthData) ListInvite(ctx context.Context, client db.RedisClient, invite models.ListInvites, inviterName, listName string) (err error) {
+	d.invitedEmails = append(d.invitedEmails, invite.Email)
+	return
+}
+
+func (d *fakeEmailAuthData) TaskAssigned(userEmail, taskName, listName, assignerName string) (err error) {
+	d.assignedEmails = append(d.assignedEmails, userEmail)
+	return
+}
+
+func (d *fakeEmailAuthData) TaskMentioned(userEmail, taskName, authorName, text string) (err error) {
+	d.mentionedEmails = append(d.mentionedEmails, userEmail)
+	return
+}
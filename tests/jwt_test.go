@@ -0,0 +1,268 @@
+package tests
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/golang-jwt/jwt/v4"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/NKTKLN/todo-api/models"
+	"github.com/NKTKLN/todo-api/pkg/common"
+	rd "github.com/NKTKLN/todo-api/pkg/db/redis"
+	"github.com/NKTKLN/todo-api/pkg/handlers"
+)
+
+var _ = Describe("JWT keys", func() {
+	var (
+		keysDir    string
+		edKeyFile  common.JWTKeyFile
+		rsaKeyFile common.JWTKeyFile
+	)
+
+	// Writing the key to a PEM file
+	writeKey := func(id, blockType string, der []byte) common.JWTKeyFile {
+		file := filepath.Join(keysDir, id+".pem")
+		Expect(os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600)).To(BeNil())
+		return common.JWTKeyFile{Id: id, File: file}
+	}
+
+	BeforeEach(func() {
+		var err error
+		keysDir, err = os.MkdirTemp("", "jwt-keys")
+		Expect(err).To(BeNil())
+
+		// Key generation
+		_, edPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).To(BeNil())
+		edDer, err := x509.MarshalPKCS8PrivateKey(edPrivateKey)
+		Expect(err).To(BeNil())
+		edKeyFile = writeKey("ed-key", "PRIVATE KEY", edDer)
+
+		rsaPrivateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).To(BeNil())
+		rsaKeyFile = writeKey("rsa-key", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaPrivateKey))
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(keysDir)
+	})
+
+	Describe("Load keys", func() {
+		It("should use HS256 without keys", func() {
+			keys, err := common.LoadJWTKeys(nil)
+			Expect(err).To(BeNil())
+			Expect(keys).To(BeNil())
+		})
+
+		It("should not sign tokens with a public key", func() {
+			publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).To(BeNil())
+			publicDer, err := x509.MarshalPKIXPublicKey(publicKey)
+			Expect(err).To(BeNil())
+
+			_, err = common.LoadJWTKeys([]common.JWTKeyFile{writeKey("public-key", "PUBLIC KEY", publicDer), edKeyFile})
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should not accept the same key id twice", func() {
+			_, err := common.LoadJWTKeys([]common.JWTKeyFile{edKeyFile, {Id: "ed-key", File: rsaKeyFile.File}})
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("Sign and verify", func() {
+		It("should sign tokens with EdDSA and a kid header", func() {
+			keys, err := common.LoadJWTKeys([]common.JWTKeyFile{edKeyFile})
+			Expect(err).To(BeNil())
+
+			token, err := keys.NewJWT(117115101114, "session", models.ACCESS_TOKEN, time.Minute)
+			Expect(err).To(BeNil())
+
+			parsedToken, _, err := new(jwt.Parser).ParseUnverified(token, &common.TokenClaims{})
+			Expect(err).To(BeNil())
+			Expect(parsedToken.Header["alg"]).To(Equal("EdDSA"))
+			Expect(parsedToken.Header["kid"]).To(Equal("ed-key"))
+
+			userId, claims := keys.VerifyToken(token, models.ACCESS_TOKEN)
+			Expect(userId).To(Equal(117115101114))
+			Expect(claims.SessionId).To(Equal("session"))
+		})
+
+		It("should verify tokens of the previous key after the rotation", func() {
+			oldKeys, err := common.LoadJWTKeys([]common.JWTKeyFile{rsaKeyFile})
+			Expect(err).To(BeNil())
+			token, err := oldKeys.NewJWT(117115101114, "session", models.ACCESS_TOKEN, time.Minute)
+			Expect(err).To(BeNil())
+
+			keys, err := common.LoadJWTKeys([]common.JWTKeyFile{edKeyFile, rsaKeyFile})
+			Expect(err).To(BeNil())
+			userId, _ := keys.VerifyToken(token, models.ACCESS_TOKEN)
+			Expect(userId).To(Equal(117115101114))
+		})
+
+		It("should not verify tokens of a removed key", func() {
+			oldKeys, err := common.LoadJWTKeys([]common.JWTKeyFile{rsaKeyFile})
+			Expect(err).To(BeNil())
+			token, err := oldKeys.NewJWT(117115101114, "session", models.ACCESS_TOKEN, time.Minute)
+			Expect(err).To(BeNil())
+
+			keys, err := common.LoadJWTKeys([]common.JWTKeyFile{edKeyFile})
+			Expect(err).To(BeNil())
+			userId, _ := keys.VerifyToken(token, models.ACCESS_TOKEN)
+			Expect(userId).To(Equal(0))
+		})
+
+		It("should not accept a refresh token as an access token", func() {
+			keys, err := common.LoadJWTKeys([]common.JWTKeyFile{edKeyFile})
+			Expect(err).To(BeNil())
+
+			token, err := keys.NewJWT(117115101114, "session", models.REFRESH_TOKEN, time.Minute)
+			Expect(err).To(BeNil())
+			userId, _ := keys.VerifyToken(token, models.ACCESS_TOKEN)
+			Expect(userId).To(Equal(0))
+		})
+
+		It("should not accept a HS256 token signed with the public key", func() {
+			keys, err := common.LoadJWTKeys([]common.JWTKeyFile{rsaKeyFile})
+			Expect(err).To(BeNil())
+
+			publicPem, err := os.ReadFile(rsaKeyFile.File)
+			Expect(err).To(BeNil())
+			token := jwt.NewWithClaims(jwt.SigningMethodHS256, common.TokenClaims{
+				SessionId: "session",
+				TokenUse:  models.ACCESS_TOKEN,
+				RegisteredClaims: jwt.RegisteredClaims{
+					ExpiresAt: &jwt.NumericDate{Time: time.Now().Add(time.Minute)},
+					Subject:   "117115101114",
+				},
+			})
+			token.Header["kid"] = "rsa-key"
+			tokenString, err := token.SignedString(publicPem)
+			Expect(err).To(BeNil())
+
+			userId, _ := keys.VerifyToken(tokenString, models.ACCESS_TOKEN)
+			Expect(userId).To(Equal(0))
+		})
+	})
+
+	Describe("Sessions with asymmetric keys", func() {
+		var (
+			redisClientAccessToken  *redis.Client
+			redisClientRefreshToken *redis.Client
+			redisClientSession      *redis.Client
+		)
+
+		BeforeEach(func() {
+			redisClientAccessToken = TestRedisConnection()
+			redisClientRefreshToken = TestRedisConnection()
+			redisClientSession = TestRedisConnection()
+		})
+
+		AfterEach(func() {
+			redisClientAccessToken.Close()
+			redisClientRefreshToken.Close()
+			redisClientSession.Close()
+		})
+
+		It("should verify the tokens of the session", func() {
+			keys, err := common.LoadJWTKeys([]common.JWTKeyFile{edKeyFile})
+			Expect(err).To(BeNil())
+
+			redisClient := &rd.RedisClients{
+				AccessTokenClient:  redisClientAccessToken,
+				RefreshTokenClient: redisClientRefreshToken,
+				SessionClient:      redisClientSession,
+				JWTKeys:            keys,
+			}
+
+			accessJwt, refreshJwt, err := redisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
+			Expect(err).To(BeNil())
+			Expect(redisClient.VerifyToken(context.Background(), accessJwt)).To(Equal(117115101114))
+			Expect(redisClient.VerifyToken(context.Background(), refreshJwt)).To(Equal(0))
+			Expect(redisClient.VerifyRefreshToken(context.Background(), refreshJwt).UserId).To(Equal(117115101114))
+		})
+	})
+
+	Describe("JWKS", func() {
+		var (
+			r       *gin.Engine
+			w       *httptest.ResponseRecorder
+			handler handlers.Handler
+		)
+
+		BeforeEach(func() {
+			gin.SetMode(gin.ReleaseMode)
+
+			r = gin.New()
+			w = httptest.NewRecorder()
+
+			r.GET("/.well-known/jwks.json", handler.ShowJWKS)
+		})
+
+		Context("without keys", func() {
+			BeforeEach(func() {
+				handler.JWTKeys = nil
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an empty key set", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"keys":[]}`))
+			})
+		})
+
+		Context("with keys", func() {
+			var jwks models.JWKS
+
+			BeforeEach(func() {
+				var err error
+				handler.JWTKeys, err = common.LoadJWTKeys([]common.JWTKeyFile{edKeyFile, rsaKeyFile})
+				Expect(err).To(BeNil())
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+				r.ServeHTTP(w, req)
+
+				// Converting the query body into a model
+				Expect(json.Unmarshal(w.Body.Bytes(), &jwks)).To(BeNil())
+			})
+
+			It("should return the public keys", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(jwks.Keys).To(HaveLen(2))
+
+				Expect(jwks.Keys[0].KeyId).To(Equal("ed-key"))
+				Expect(jwks.Keys[0].KeyType).To(Equal("OKP"))
+				Expect(jwks.Keys[0].Algorithm).To(Equal("EdDSA"))
+				Expect(jwks.Keys[0].X).NotTo(BeEmpty())
+
+				Expect(jwks.Keys[1].KeyId).To(Equal("rsa-key"))
+				Expect(jwks.Keys[1].KeyType).To(Equal("RSA"))
+				Expect(jwks.Keys[1].Algorithm).To(Equal("RS256"))
+				Expect(jwks.Keys[1].E).To(Equal("AQAB"))
+			})
+
+			It("should not contain private keys", func() {
+				Expect(strings.Contains(w.Body.String(), `"d"`)).To(BeFalse())
+			})
+		})
+	})
+})
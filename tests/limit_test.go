@@ -0,0 +1,251 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/NKTKLN/todo-api/models"
+	rd "github.com/NKTKLN/todo-api/pkg/db/redis"
+	"github.com/NKTKLN/todo-api/pkg/handlers"
+)
+
+var _ = Describe("Limits", func() {
+	var (
+		r                       *gin.Engine
+		w                       *httptest.ResponseRecorder
+		handler                 handlers.Handler
+		postgresMock            sqlmock.Sqlmock
+		redisClientEmail        *redis.Client
+		redisClientAccessToken  *redis.Client
+		redisClientRefreshToken *redis.Client
+		redisClientSession      *redis.Client
+		redisClientLimit        *redis.Client
+	)
+
+	BeforeEach(func() {
+		gin.SetMode(gin.ReleaseMode)
+
+		r = gin.New()
+		w = httptest.NewRecorder()
+
+		redisClientEmail = TestRedisConnection()
+		redisClientAccessToken = TestRedisConnection()
+		redisClientRefreshToken = TestRedisConnection()
+		redisClientSession = TestRedisConnection()
+		redisClientLimit = TestRedisConnection()
+
+		handler.EmailAuthData = NewFakeEmailProvider("email@example.com", "StRon9Pa$$w0rd", "smtp.example.com", 0)
+
+		handler.RedisClient = &rd.RedisClients{
+			EmailClient:        redisClientEmail,
+			AccessTokenClient:  redisClientAccessToken,
+			RefreshTokenClient: redisClientRefreshToken,
+			SessionClient:      redisClientSession,
+			LimitClient:        redisClientLimit,
+		}
+
+		handler.PostgresDB, postgresMock = MockPostgresConnection()
+
+		handler.Limits = models.LimitSettings{
+			SignIn:   models.Limit{MaxAttempts: 3, Window: time.Minute, Lockout: 15 * time.Minute, MaxLockout: time.Hour},
+			SignInIP: models.Limit{MaxAttempts: 10, Window: time.Minute, Lockout: 15 * time.Minute, MaxLockout: time.Hour},
+			Email:    models.Limit{MaxAttempts: 2, Window: time.Hour, Lockout: time.Hour, MaxLockout: 24 * time.Hour},
+			EmailIP:  models.Limit{MaxAttempts: 10, Window: time.Hour, Lockout: time.Hour, MaxLockout: 24 * time.Hour},
+		}
+	})
+
+	AfterEach(func() {
+		redisClientEmail.Close()
+		redisClientAccessToken.Close()
+		redisClientRefreshToken.Close()
+		redisClientSession.Close()
+		redisClientLimit.Close()
+	})
+
+	Describe("Hit limit", func() {
+		It("should double the lockout up to the max lockout", func() {
+			limit := models.Limit{MaxAttempts: 1, Window: time.Minute, Lockout: time.Minute, MaxLockout: 3 * time.Minute}
+
+			for _, expected := range []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute, 3 * time.Minute} {
+				lockout, err := handler.RedisClient.HitLimit(context.Background(), "key", limit)
+				Expect(err).To(BeNil())
+				Expect(lockout).To(Equal(expected))
+			}
+
+			retryAfter, err := handler.RedisClient.GetLimitLock(context.Background(), "key")
+			Expect(err).To(BeNil())
+			Expect(retryAfter).To(Equal(3 * time.Minute))
+		})
+
+		It("should not lock the key with a disabled limit", func() {
+			for i := 0; i < 10; i++ {
+				lockout, err := handler.RedisClient.HitLimit(context.Background(), "key", models.Limit{})
+				Expect(err).To(BeNil())
+				Expect(lockout).To(BeZero())
+			}
+		})
+	})
+
+	Describe("Sign in", func() {
+		const requestBody = `{"email": "email@example.com", "password": "StRon9Pa$$w0rd"}`
+
+		var hashedPassword []byte
+
+		signIn := func(password string) *httptest.ResponseRecorder {
+			// Query building for the postgres
+			postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserByEmail)).
+				WithArgs("email@example.com").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "username", "password", "icon"}).
+					AddRow(117115101114, "email@example.com", "", "", hashedPassword, ""))
+
+			// Sending a query with data
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/auth/sign-in", bytes.NewBufferString(`{"email": "email@example.com", "password": "`+password+`"}`))
+			r.ServeHTTP(recorder, req)
+			return recorder
+		}
+
+		BeforeEach(func() {
+			r.POST("/auth/sign-in", handler.SignIn)
+
+			// Password hash generation
+			var err error
+			hashedPassword, err = bcrypt.GenerateFromPassword([]byte("StRon9Pa$$w0rd"), bcrypt.MinCost)
+			Expect(err).To(BeNil())
+		})
+
+		Context("locked account", func() {
+			BeforeEach(func() {
+				for i := 0; i < 3; i++ {
+					Expect(signIn("wRoNgPa$$w0rd").Code).To(Equal(http.StatusBadRequest))
+				}
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/auth/sign-in", bytes.NewBufferString(requestBody))
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error with the time of the lockout", func() {
+				Expect(w.Code).To(Equal(http.StatusTooManyRequests))
+				Expect(w.Header().Get("Retry-After")).To(Equal("900"))
+				Expect(w.Body.String()).To(Equal(`{"error":"Too many attempts, try again later."}`))
+			})
+
+			It("should notify the user about the lockout", func() {
+				Expect(handler.EmailAuthData.(*fakeEmailAuthData).lockedEmails).To(Equal([]string{"email@example.com"}))
+			})
+		})
+
+		Context("successful sign in", func() {
+			BeforeEach(func() {
+				for i := 0; i < 2; i++ {
+					Expect(signIn("wRoNgPa$$w0rd").Code).To(Equal(http.StatusBadRequest))
+				}
+				Expect(signIn("StRon9Pa$$w0rd").Code).To(Equal(http.StatusOK))
+				w = signIn("wRoNgPa$$w0rd")
+			})
+
+			It("should reset the failed attempts", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Wrong email or password."}`))
+			})
+		})
+
+		Context("wrong two-factor code", func() {
+			userColumns := []string{"id", "email", "name", "username", "password", "icon", "totp_secret", "totp_enabled", "recovery_codes"}
+
+			BeforeEach(func() {
+				r.POST("/auth/sign-in/2fa", handler.SignInTwoFactor)
+
+				for i := 0; i < 2; i++ {
+					Expect(signIn("wRoNgPa$$w0rd").Code).To(Equal(http.StatusBadRequest))
+				}
+
+				// The right password doesn't reset the failed attempts of the account with two-factor authentication
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserByEmail)).
+					WithArgs("email@example.com").
+					WillReturnRows(sqlmock.NewRows(userColumns).
+						AddRow(117115101114, "email@example.com", "", "", hashedPassword, "", "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", true, "{}"))
+
+				recorder := httptest.NewRecorder()
+				req := httptest.NewRequest(http.MethodPost, "/auth/sign-in", bytes.NewBufferString(requestBody))
+				r.ServeHTTP(recorder, req)
+				Expect(recorder.Code).To(Equal(http.StatusAccepted))
+
+				var challenge models.ApiTwoFactorChallenge
+				Expect(json.Unmarshal(recorder.Body.Bytes(), &challenge)).To(BeNil())
+
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows(userColumns).
+						AddRow(117115101114, "email@example.com", "", "", hashedPassword, "", "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", true, "{}"))
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlUseRecoveryCode)).
+					WithArgs(AnyString{}, 117115101114, AnyString{}).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				postgresMock.ExpectCommit()
+
+				recorder = httptest.NewRecorder()
+				req = httptest.NewRequest(http.MethodPost, "/auth/sign-in/2fa", bytes.NewBufferString(`{"challenge": "`+challenge.Challenge+`", "code": "000000"}`))
+				r.ServeHTTP(recorder, req)
+				Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+
+				// Sending a query with data
+				req = httptest.NewRequest(http.MethodPost, "/auth/sign-in", bytes.NewBufferString(requestBody))
+				r.ServeHTTP(w, req)
+			})
+
+			It("should count the wrong code as a failed attempt", func() {
+				Expect(w.Code).To(Equal(http.StatusTooManyRequests))
+				Expect(w.Body.String()).To(Equal(`{"error":"Too many attempts, try again later."}`))
+				Expect(handler.EmailAuthData.(*fakeEmailAuthData).lockedEmails).To(Equal([]string{"email@example.com"}))
+			})
+		})
+	})
+
+	Describe("Email sending", func() {
+		const requestBody = `{"email": "email@example.com"}`
+
+		resetPassword := func() *httptest.ResponseRecorder {
+			// Query building for the postgres
+			postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllUsersByEmail)).
+				WithArgs("email@example.com").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "username", "password", "icon"}).
+					AddRow(117115101114, "email@example.com", "Test User Name", "test_username", "", ""))
+
+			// Sending a query with data
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/user/settings/reset/password", bytes.NewBufferString(requestBody))
+			r.ServeHTTP(recorder, req)
+			return recorder
+		}
+
+		BeforeEach(func() {
+			r.POST("/user/settings/reset/password", handler.ResetUserPassword)
+
+			for i := 0; i < 2; i++ {
+				Expect(resetPassword().Code).To(Equal(http.StatusOK))
+			}
+			w = resetPassword()
+		})
+
+		It("should return an error after too many emails", func() {
+			Expect(w.Code).To(Equal(http.StatusTooManyRequests))
+			Expect(w.Header().Get("Retry-After")).To(Equal("3600"))
+			Expect(w.Body.String()).To(Equal(`{"error":"Too many attempts, try again later."}`))
+		})
+	})
+})
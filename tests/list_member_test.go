@@ -0,0 +1,685 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/NKTKLN/todo-api/models"
+	rd "github.com/NKTKLN/todo-api/pkg/db/redis"
+	"github.com/NKTKLN/todo-api/pkg/handlers"
+)
+
+var _ = Describe("List members", func() {
+	var (
+		r                       *gin.Engine
+		w                       *httptest.ResponseRecorder
+		accessJwt               string
+		handler                 handlers.Handler
+		postgresMock            sqlmock.Sqlmock
+		redisClientEmail        *redis.Client
+		redisClientAccessToken  *redis.Client
+		redisClientRefreshToken *redis.Client
+		redisClientSession      *redis.Client
+		redisClientLimit        *redis.Client
+	)
+
+	listColumns := []string{"id", "user_id", "name", "comment", "index"}
+	userColumns := []string{"id", "email", "name", "username", "password", "icon", "role", "disabled"}
+	memberColumns := []string{"list_id", "user_id", "role"}
+
+	expectListRole := func(role string) {
+		rows := sqlmock.NewRows([]string{"role"})
+		if role != "" {
+			rows.AddRow(role)
+		}
+		postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+			WithArgs(117115101114, "owner", 117115101114, 108105115116).
+			WillReturnRows(rows)
+	}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.ReleaseMode)
+
+		r = gin.New()
+		w = httptest.NewRecorder()
+
+		redisClientEmail = TestRedisConnection()
+		redisClientAccessToken = TestRedisConnection()
+		redisClientRefreshToken = TestRedisConnection()
+		redisClientSession = TestRedisConnection()
+		redisClientLimit = TestRedisConnection()
+
+		handler.RedisClient = &rd.RedisClients{
+			EmailClient:        redisClientEmail,
+			AccessTokenClient:  redisClientAccessToken,
+			RefreshTokenClient: redisClientRefreshToken,
+			SessionClient:      redisClientSession,
+			LimitClient:        redisClientLimit,
+		}
+
+		handler.PostgresDB, postgresMock = MockPostgresConnection()
+		handler.EmailAuthData = NewFakeEmailProvider("email@example.com", "StRon9Pa$$w0rd", "smtp.example.com", 0)
+
+		// Creating new session with a jwt token
+		accessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
+	})
+
+	AfterEach(func() {
+		redisClientEmail.Close()
+		redisClientAccessToken.Close()
+		redisClientRefreshToken.Close()
+		redisClientSession.Close()
+		redisClientLimit.Close()
+
+		Expect(postgresMock.ExpectationsWereMet()).ShouldNot(HaveOccurred())
+	})
+
+	Describe("Show list members", func() {
+		BeforeEach(func() {
+			r.GET("/todo/list/members", handler.AuthMiddleware(), handler.ShowListMembers)
+		})
+
+		Context("this list not found", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListByIdAndUserId)).
+					WithArgs(108105115116, 117115101114, 117115101114).
+					WillReturnRows(sqlmock.NewRows(listColumns))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/list/members?list_id=108105115116", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the list not found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(Equal(`{"error":"This list not found."}`))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListByIdAndUserId)).
+					WithArgs(108105115116, 117115101114, 117115101114).
+					WillReturnRows(sqlmock.NewRows(listColumns).
+						AddRow(108105115116, 117115101115, "Test List Name", "Test List Comment", 0))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListMembers)).
+					WithArgs("owner", 108105115116, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "name", "role", "position"}).
+						AddRow(117115101115, "owner", "Owner", "owner", 0).
+						AddRow(117115101114, "NKTKLN", "Test Name", "editor", 1))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/list/members?list_id=108105115116", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return the owner and members of the list", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"members":[{"id":117115101115,"username":"owner","name":"Owner","role":"owner"},{"id":117115101114,"username":"NKTKLN","name":"Test Name","role":"editor"}]}`))
+			})
+		})
+	})
+
+	Describe("Add list member", func() {
+		BeforeEach(func() {
+			r.POST("/todo/list/members", handler.AuthMiddleware(), handler.AddListMember)
+		})
+
+		Context("not enough rights", func() {
+			const requestBody = `{"list_id": 108105115116, "role": "editor", "user": "test"}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectListRole("editor")
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/list/members", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that there are not enough rights", func() {
+				Expect(w.Code).To(Equal(http.StatusForbidden))
+				Expect(w.Body.String()).To(Equal(`{"error":"Not enough rights."}`))
+			})
+		})
+
+		Context("incorrect role", func() {
+			const requestBody = `{"list_id": 108105115116, "role": "owner", "user": "test"}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectListRole("owner")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserByUsername)).
+					WithArgs("test").
+					WillReturnRows(sqlmock.NewRows(userColumns).
+						AddRow(117115101115, "test@example.com", "Test", "test", "", "", "user", false))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/list/members", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the role is incorrect", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Incorrect role."}`))
+			})
+		})
+
+		Context("user not found", func() {
+			const requestBody = `{"list_id": 108105115116, "role": "editor", "user": "test@example.com"}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectListRole("admin")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserByEmail)).
+					WithArgs("test@example.com").
+					WillReturnRows(sqlmock.NewRows(userColumns))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/list/members", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the user not found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(Equal(`{"error":"User not found."}`))
+			})
+		})
+
+		Context("already a member", func() {
+			const requestBody = `{"list_id": 108105115116, "role": "editor", "user": "test"}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectListRole("owner")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserByUsername)).
+					WithArgs("test").
+					WillReturnRows(sqlmock.NewRows(userColumns).
+						AddRow(117115101115, "test@example.com", "Test", "test", "", "", "user", false))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101115, "owner", 117115101115, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("viewer"))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/list/members", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the user is already a member", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"This user is already a member of the list."}`))
+			})
+		})
+
+		Context("too many members", func() {
+			const requestBody = `{"list_id": 108105115116, "role": "editor", "user": "test"}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectListRole("owner")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserByUsername)).
+					WithArgs("test").
+					WillReturnRows(sqlmock.NewRows(userColumns).
+						AddRow(117115101115, "test@example.com", "Test", "test", "", "", "user", false))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101115, "owner", 117115101115, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListMembersCount)).
+					WithArgs(108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(models.MAX_LIST_MEMBERS))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/list/members", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that there are too many members", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Too many members."}`))
+			})
+		})
+
+		Context("ok", func() {
+			const requestBody = `{"list_id": 108105115116, "role": "editor", "user": "test"}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectListRole("owner")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserByUsername)).
+					WithArgs("test").
+					WillReturnRows(sqlmock.NewRows(userColumns).
+						AddRow(117115101115, "test@example.com", "Test", "test", "", "", "user", false))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101115, "owner", 117115101115, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListMembersCount)).
+					WithArgs(108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlInsertListMember)).
+					WithArgs(108105115116, 117115101115, "editor").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/list/members", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the member has been added", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The member has been added to the list."}`))
+			})
+		})
+	})
+
+	Describe("Invite list member", func() {
+		BeforeEach(func() {
+			r.POST("/todo/list/members/invite", handler.AuthMiddleware(), handler.InviteListMember)
+		})
+
+		Context("incorrect email", func() {
+			const requestBody = `{"email": "email", "list_id": 108105115116, "role": "editor"}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectListRole("owner")
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/list/members/invite", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the email is incorrect", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Incorrect email."}`))
+			})
+		})
+
+		Context("user already has an account", func() {
+			const requestBody = `{"email": "test@example.com", "list_id": 108105115116, "role": "editor"}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectListRole("owner")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllUsersByEmail)).
+					WithArgs("test@example.com").
+					WillReturnRows(sqlmock.NewRows(userColumns).
+						AddRow(117115101115, "test@example.com", "Test", "test", "", "", "user", false))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/list/members/invite", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the user already has an account", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"This user already has an account, add them by the email."}`))
+			})
+		})
+
+		Context("ok", func() {
+			const requestBody = `{"email": "test@example.com", "list_id": 108105115116, "role": "editor"}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectListRole("admin")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllUsersByEmail)).
+					WithArgs("test@example.com").
+					WillReturnRows(sqlmock.NewRows(userColumns))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListMembersCount)).
+					WithArgs(108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows(userColumns).
+						AddRow(117115101114, "email@example.com", "Test Name", "NKTKLN", "", "", "user", false))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListById)).
+					WithArgs(108105115116).
+					WillReturnRows(sqlmock.NewRows(listColumns).
+						AddRow(108105115116, 117115101115, "Test List Name", "Test List Comment", 0))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/list/members/invite", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should send the invitation", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The invitation has been sent."}`))
+				Expect(handler.EmailAuthData.(*fakeEmailAuthData).invitedEmails).To(Equal([]string{"test@example.com"}))
+			})
+		})
+	})
+
+	Describe("Accept list invitation", func() {
+		BeforeEach(func() {
+			r.POST("/todo/list/members/accept", handler.AuthMiddleware(), handler.AcceptListInvite)
+		})
+
+		Context("key is not valid", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows(userColumns).
+						AddRow(117115101114, "email@example.com", "Test Name", "NKTKLN", "", "", "user", false))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/list/members/accept?key=key", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error about invalid key", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Time has expired, your key is not valid."}`))
+			})
+		})
+
+		Context("invitation sent to another email", func() {
+			BeforeEach(func() {
+				// Adding data to redis
+				inviteData, err := json.Marshal(models.ListInvites{ListId: 108105115116, Email: "test@example.com", Role: "viewer"})
+				Expect(err).To(BeNil())
+				redisClientEmail.Set(context.Background(), models.LIST_INVITE_FLOW+":key", inviteData, time.Minute)
+
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows(userColumns).
+						AddRow(117115101114, "email@example.com", "Test Name", "NKTKLN", "", "", "user", false))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/list/members/accept?key=key", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error about invalid key and keep the key", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Time has expired, your key is not valid."}`))
+				Expect(redisClientEmail.Exists(context.Background(), models.LIST_INVITE_FLOW+":key").Val()).To(Equal(int64(1)))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				// Adding data to redis
+				inviteData, err := json.Marshal(models.ListInvites{ListId: 108105115116, Email: "email@example.com", Role: "viewer"})
+				Expect(err).To(BeNil())
+				redisClientEmail.Set(context.Background(), models.LIST_INVITE_FLOW+":key", inviteData, time.Minute)
+
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows(userColumns).
+						AddRow(117115101114, "email@example.com", "Test Name", "NKTKLN", "", "", "user", false))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListById)).
+					WithArgs(108105115116).
+					WillReturnRows(sqlmock.NewRows(listColumns).
+						AddRow(108105115116, 117115101115, "Test List Name", "Test List Comment", 0))
+
+				expectListRole("")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListMembersCount)).
+					WithArgs(108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlInsertListMember)).
+					WithArgs(108105115116, 117115101114, "viewer").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/list/members/accept?key=key", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should add the user to the list and use the key", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"You have joined the list."}`))
+				Expect(redisClientEmail.Exists(context.Background(), models.LIST_INVITE_FLOW+":key").Val()).To(Equal(int64(0)))
+			})
+		})
+	})
+
+	Describe("Edit list member", func() {
+		BeforeEach(func() {
+			r.PATCH("/todo/list/members", handler.AuthMiddleware(), handler.EditListMember)
+		})
+
+		Context("this member not found", func() {
+			const requestBody = `{"list_id": 108105115116, "role": "viewer", "user_id": 117115101115}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectListRole("admin")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListMember)).
+					WithArgs(108105115116, 117115101115).
+					WillReturnRows(sqlmock.NewRows(memberColumns))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPatch, "/todo/list/members", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the member not found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(Equal(`{"error":"This member not found."}`))
+			})
+		})
+
+		Context("ok", func() {
+			const requestBody = `{"list_id": 108105115116, "role": "viewer", "user_id": 117115101115}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectListRole("admin")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListMember)).
+					WithArgs(108105115116, 117115101115).
+					WillReturnRows(sqlmock.NewRows(memberColumns).
+						AddRow(108105115116, 117115101115, "editor"))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditListMemberRole)).
+					WithArgs("viewer", 108105115116, 117115101115).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPatch, "/todo/list/members", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the member role has been changed", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The member role has been changed."}`))
+			})
+		})
+	})
+
+	Describe("Delete list member", func() {
+		BeforeEach(func() {
+			r.DELETE("/todo/list/members", handler.AuthMiddleware(), handler.DeleteListMember)
+		})
+
+		Context("this list not found", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectListRole("")
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/todo/list/members?list_id=108105115116&user_id=117115101115", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the list not found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(Equal(`{"error":"This list not found."}`))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectListRole("owner")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListMember)).
+					WithArgs(108105115116, 117115101115).
+					WillReturnRows(sqlmock.NewRows(memberColumns).
+						AddRow(108105115116, 117115101115, "editor"))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteListMemberReminders)).
+					WithArgs(117115101115, 108105115116).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteListMemberAssignees)).
+					WithArgs(117115101115, 108105115116).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteListMember)).
+					WithArgs(108105115116, 117115101115).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/todo/list/members?list_id=108105115116&user_id=117115101115", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the member has been removed", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The member has been removed from the list."}`))
+			})
+		})
+	})
+
+	Describe("Leave list", func() {
+		BeforeEach(func() {
+			r.DELETE("/todo/list/members/leave", handler.AuthMiddleware(), handler.LeaveList)
+		})
+
+		Context("the owner", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectListRole("owner")
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/todo/list/members/leave?list_id=108105115116", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the owner can't leave the list", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"The owner can't leave the list."}`))
+			})
+		})
+
+		Context("the workspace list", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectListRole("editor")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListMember)).
+					WithArgs(108105115116, 117115101114).
+					WillReturnRows(sqlmock.NewRows(memberColumns))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/todo/list/members/leave?list_id=108105115116", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the workspace lists are left with the workspace", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"The workspace lists are left together with the workspace."}`))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectListRole("viewer")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListMember)).
+					WithArgs(108105115116, 117115101114).
+					WillReturnRows(sqlmock.NewRows(memberColumns).
+						AddRow(108105115116, 117115101114, "viewer"))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteListMemberReminders)).
+					WithArgs(117115101114, 108105115116).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteListMemberAssignees)).
+					WithArgs(117115101114, 108105115116).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteListMember)).
+					WithArgs(108105115116, 117115101114).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/todo/list/members/leave?list_id=108105115116", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the user has left the list", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"You have left the list."}`))
+			})
+		})
+	})
+})
@@ -7,14 +7,12 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"regexp"
-	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-	"github.com/spf13/viper"
 
 	"github.com/NKTKLN/todo-api/models"
 	"github.com/NKTKLN/todo-api/pkg/common"
@@ -31,6 +29,7 @@ var _ = Describe("Lists", func() {
 		postgresMock            sqlmock.Sqlmock
 		redisClientAccessToken  *redis.Client
 		redisClientRefreshToken *redis.Client
+		redisClientSession      *redis.Client
 	)
 
 	BeforeEach(func() {
@@ -41,31 +40,31 @@ var _ = Describe("Lists", func() {
 
 		redisClientAccessToken = TestRedisConnection()
 		redisClientRefreshToken = TestRedisConnection()
+		redisClientSession = TestRedisConnection()
 
 		handler.RedisClient = &rd.RedisClients{
 			AccessTokenClient:  redisClientAccessToken,
 			RefreshTokenClient: redisClientRefreshToken,
+			SessionClient:      redisClientSession,
 		}
 
 		handler.PostgresDB, postgresMock = MockPostgresConnection()
 
-		// Generate new jwt token
-		accessJwt, _ = common.NewJWT(117115101114, time.Minute, viper.GetString("api.jwt.access-secret"))
-
-		// Adding data to redis
-		redisClientAccessToken.Set(context.Background(), "117115101114", accessJwt, time.Minute)
+		// Creating new session with a jwt token
+		accessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
 	})
 
 	AfterEach(func() {
 		redisClientAccessToken.Close()
 		redisClientRefreshToken.Close()
+		redisClientSession.Close()
 
 		Expect(postgresMock.ExpectationsWereMet()).ShouldNot(HaveOccurred())
 	})
 
 	Describe("Add list", func() {
 		BeforeEach(func() {
-			r.POST("/todo/list/add", handler.AddList)
+			r.POST("/todo/list/add", handler.AuthMiddleware(), handler.AddList)
 		})
 
 		Context("data retrieval error", func() {
@@ -82,7 +81,7 @@ var _ = Describe("Lists", func() {
 			})
 		})
 
-		Context("inactive user", func() {
+		Context("missing access token", func() {
 			const requestBody = `{"comment": "Test List Comment", "name": "Test List Name"}`
 
 			BeforeEach(func() {
@@ -91,9 +90,9 @@ var _ = Describe("Lists", func() {
 				r.ServeHTTP(w, req)
 			})
 
-			It("should return an error that the user is inactive", func() {
-				Expect(w.Code).To(Equal(http.StatusNotFound))
-				Expect(w.Body.String()).To(Equal(`{"error":"Inactive user."}`))
+			It("should return an error that the access token is missing", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
 			})
 		})
 
@@ -131,6 +130,64 @@ var _ = Describe("Lists", func() {
 			})
 		})
 
+		Describe("Workspace list", func() {
+			const requestBody = `{"comment": "Test List Comment", "name": "Test List Name", "workspace_id": 119111114107}`
+
+			Context("not enough rights", func() {
+				BeforeEach(func() {
+					// Query building for the postgres
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectWorkspaceRole)).
+						WithArgs(119111114107, 117115101114).
+						WillReturnRows(sqlmock.NewRows([]string{"role"}).
+							AddRow("viewer"))
+
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodPost, "/todo/list/add", bytes.NewBufferString(requestBody))
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+				})
+
+				It("should return an error that there are not enough rights", func() {
+					Expect(w.Code).To(Equal(http.StatusForbidden))
+					Expect(w.Body.String()).To(Equal(`{"error":"Not enough rights."}`))
+				})
+			})
+
+			Context("ok", func() {
+				BeforeEach(func() {
+					// Query building for the postgres
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectWorkspaceRole)).
+						WithArgs(119111114107, 117115101114).
+						WillReturnRows(sqlmock.NewRows([]string{"role"}).
+							AddRow("editor"))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListById)).
+						WithArgs(AnyInt{}).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllListsByWorkspaceId)).
+						WithArgs(119111114107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "workspace_id", "name", "comment", "index"}))
+
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlInsertListData)).
+						WithArgs(0, 119111114107, "Test List Name", "Test List Comment", 0, AnyInt{}).
+						WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
+					postgresMock.ExpectCommit()
+
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodPost, "/todo/list/add", bytes.NewBufferString(requestBody))
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+				})
+
+				It("should return a message that the list was successfully created", func() {
+					Expect(w.Code).To(Equal(http.StatusOK))
+					Expect(w.Body.String()).To(Equal(`{"message":"List added to db."}`))
+				})
+			})
+		})
+
 		Describe("Ok", func() {
 			const requestBody = `{"comment": "Test List Comment", "name": "Test List Name"}`
 
@@ -150,7 +207,7 @@ var _ = Describe("Lists", func() {
 
 					postgresMock.ExpectBegin()
 					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlInsertListData)).
-						WithArgs(117115101114, "Test List Name", "Test List Comment", 0, AnyInt{}).
+						WithArgs(117115101114, 0, "Test List Name", "Test List Comment", 0, AnyInt{}).
 						WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
 					postgresMock.ExpectCommit()
 
@@ -181,7 +238,7 @@ var _ = Describe("Lists", func() {
 
 					postgresMock.ExpectBegin()
 					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlInsertListData)).
-						WithArgs(117115101114, "Test List Name", "Test List Comment", 1, AnyInt{}).
+						WithArgs(117115101114, 0, "Test List Name", "Test List Comment", 1, AnyInt{}).
 						WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
 					postgresMock.ExpectCommit()
 
@@ -201,14 +258,14 @@ var _ = Describe("Lists", func() {
 
 	Describe("Delete list", func() {
 		BeforeEach(func() {
-			r.DELETE("/todo/list/delete", handler.DeleteList)
+			r.DELETE("/todo/list/delete", handler.AuthMiddleware(), handler.DeleteList)
 		})
 
 		Context("error when converting list_id", func() {
 			BeforeEach(func() {
 				// Query building for the postgres
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListByIdAndUserId)).
-					WithArgs(0, 117115101114).
+					WithArgs(0, 117115101114, 117115101114).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}))
 
 				// Sending a query with data
@@ -223,21 +280,16 @@ var _ = Describe("Lists", func() {
 			})
 		})
 
-		Context("inactive user", func() {
+		Context("missing access token", func() {
 			BeforeEach(func() {
-				// Query building for the postgres
-				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListByIdAndUserId)).
-					WithArgs(108105115116, 0).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}))
-
 				// Sending a query with data
 				req := httptest.NewRequest(http.MethodDelete, `/todo/list/delete?list_id=108105115116`, nil)
 				r.ServeHTTP(w, req)
 			})
 
-			It("should return an error that the user is inactive", func() {
-				Expect(w.Code).To(Equal(http.StatusNotFound))
-				Expect(w.Body.String()).To(Equal(`{"error":"Inactive user."}`))
+			It("should return an error that the access token is missing", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
 			})
 		})
 
@@ -245,7 +297,7 @@ var _ = Describe("Lists", func() {
 			BeforeEach(func() {
 				// Query building for the postgres
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListByIdAndUserId)).
-					WithArgs(108105115116, 117115101114).
+					WithArgs(108105115116, 117115101114, 117115101114).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}))
 
 				// Sending a query with data
@@ -260,11 +312,81 @@ var _ = Describe("Lists", func() {
 			})
 		})
 
+		Context("shared list", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListByIdAndUserId)).
+					WithArgs(108105115116, 117115101114, 117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
+						AddRow(108105115116, 117115101115, "Test List Name", "Test List Comment", 0))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101114, "owner", 117115101114, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("admin"))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/todo/list/delete?list_id=108105115116", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that only the owner can delete the list", func() {
+				Expect(w.Code).To(Equal(http.StatusForbidden))
+				Expect(w.Body.String()).To(Equal(`{"error":"Only the owner can delete the list."}`))
+			})
+		})
+
+		Context("workspace list deleted by the workspace admin", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListByIdAndUserId)).
+					WithArgs(108105115116, 117115101114, 117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "workspace_id", "name", "comment", "index"}).
+						AddRow(108105115116, 0, 119111114107, "Test List Name", "Test List Comment", 0))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101114, "owner", 117115101114, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("owner"))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectWorkspaceListsForEditIndex)).
+					WithArgs(119111114107, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "workspace_id", "name", "comment", "index"}))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllTasksByListId)).
+					WithArgs(AnyInt{}).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteListMembers)).
+					WithArgs(108105115116).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				postgresMock.ExpectCommit()
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteList)).
+					WithArgs(108105115116).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/todo/list/delete?list_id=108105115116", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the list was successfully deleted", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The list has been deleted."}`))
+			})
+		})
+
 		Describe("Ok", func() {
 			BeforeEach(func() {
 				// Query building for the postgres
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListByIdAndUserId)).
-					WithArgs(108105115116, 117115101114).
+					WithArgs(108105115116, 117115101114, 117115101114).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
 						AddRow(108105115116, 117115101114, "Test List Name", "Test List Comment", 0))
 			})
@@ -280,6 +402,12 @@ var _ = Describe("Lists", func() {
 						WithArgs(AnyInt{}).
 						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}))
 
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteListMembers)).
+						WithArgs(108105115116).
+						WillReturnResult(sqlmock.NewResult(1, 0))
+					postgresMock.ExpectCommit()
+
 					postgresMock.ExpectBegin()
 					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteList)).
 						WithArgs(108105115116).
@@ -316,6 +444,12 @@ var _ = Describe("Lists", func() {
 						WithArgs(AnyInt{}).
 						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}))
 
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteListMembers)).
+						WithArgs(108105115116).
+						WillReturnResult(sqlmock.NewResult(1, 0))
+					postgresMock.ExpectCommit()
+
 					postgresMock.ExpectBegin()
 					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteList)).
 						WithArgs(108105115116).
@@ -352,17 +486,48 @@ var _ = Describe("Lists", func() {
 							AddRow(1151179811697115107, 0, 11697115107, "Test Task Name", "Test Task Comment", 0, nil, nil, false, false))
 
 					postgresMock.ExpectBegin()
-					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTask)).
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskTreeReminders)).
+						WithArgs(1151179811697115107).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskTreeAssignees)).
+						WithArgs(1151179811697115107).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskTreeComments)).
+						WithArgs(1151179811697115107).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskTree)).
 						WithArgs(1151179811697115107).
 						WillReturnResult(sqlmock.NewResult(1, 1))
 					postgresMock.ExpectCommit()
 
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskReminders)).
+						WithArgs(11697115107).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					postgresMock.ExpectCommit()
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskAssignees)).
+						WithArgs(11697115107).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					postgresMock.ExpectCommit()
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskComments)).
+						WithArgs(11697115107).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					postgresMock.ExpectCommit()
+
 					postgresMock.ExpectBegin()
 					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTask)).
 						WithArgs(11697115107).
 						WillReturnResult(sqlmock.NewResult(1, 1))
 					postgresMock.ExpectCommit()
 
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteListMembers)).
+						WithArgs(108105115116).
+						WillReturnResult(sqlmock.NewResult(1, 0))
+					postgresMock.ExpectCommit()
+
 					postgresMock.ExpectBegin()
 					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteList)).
 						WithArgs(108105115116).
@@ -385,7 +550,7 @@ var _ = Describe("Lists", func() {
 
 	Describe("Edit list", func() {
 		BeforeEach(func() {
-			r.PATCH("/todo/list/edit", handler.EditList)
+			r.PATCH("/todo/list/edit", handler.AuthMiddleware(), handler.EditList)
 		})
 
 		Context("data retrieval error", func() {
@@ -402,7 +567,7 @@ var _ = Describe("Lists", func() {
 			})
 		})
 
-		Context("inactive user", func() {
+		Context("missing access token", func() {
 			const requestBody = `{"comment": "Test List Comment", "id": 108105115116, "index": 0, "name": "Test List Name"}`
 
 			BeforeEach(func() {
@@ -411,9 +576,9 @@ var _ = Describe("Lists", func() {
 				r.ServeHTTP(w, req)
 			})
 
-			It("should return an error that the user is inactive", func() {
-				Expect(w.Code).To(Equal(http.StatusNotFound))
-				Expect(w.Body.String()).To(Equal(`{"error":"Inactive user."}`))
+			It("should return an error that the access token is missing", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
 			})
 		})
 
@@ -422,9 +587,9 @@ var _ = Describe("Lists", func() {
 
 			BeforeEach(func() {
 				// Query building for the postgres
-				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListByIdAndUserId)).
-					WithArgs(108105115116, 117115101114).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}))
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101114, "owner", 117115101114, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}))
 
 				// Sending a query with data
 				req := httptest.NewRequest(http.MethodPatch, `/todo/list/edit`, bytes.NewBufferString(requestBody))
@@ -441,8 +606,13 @@ var _ = Describe("Lists", func() {
 		Describe("Incorrect data", func() {
 			BeforeEach(func() {
 				// Query building for the postgres
-				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListByIdAndUserId)).
-					WithArgs(108105115116, 117115101114).
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101114, "owner", 117115101114, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("owner"))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListById)).
+					WithArgs(108105115116).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
 						AddRow(108105115116, 117115101114, "Test List Name", "Test List Comment", 0))
 			})
@@ -525,10 +695,10 @@ var _ = Describe("Lists", func() {
 		Describe("Ok", func() {
 			BeforeEach(func() {
 				// Query building for the postgres
-				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListByIdAndUserId)).
-					WithArgs(108105115116, 117115101114).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
-						AddRow(108105115116, 117115101114, "Test List Name", "Test List Comment", 0))
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101114, "owner", 117115101114, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("owner"))
 			})
 
 			Context("update the list without changing the index", func() {
@@ -536,6 +706,11 @@ var _ = Describe("Lists", func() {
 
 				BeforeEach(func() {
 					// Query building for the postgres
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListById)).
+						WithArgs(108105115116).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
+							AddRow(108105115116, 117115101114, "Test List Name", "Test List Comment", 0))
+
 					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectMaxListIndex)).
 						WithArgs(117115101114).
 						WillReturnRows(sqlmock.NewRows([]string{"index"}).
@@ -547,11 +722,6 @@ var _ = Describe("Lists", func() {
 						WillReturnResult(sqlmock.NewResult(1, 1))
 					postgresMock.ExpectCommit()
 
-					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListById)).
-						WithArgs(108105115116).
-						WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
-							AddRow(108105115116, 117115101114, "Test List Name", "Test List Comment", 0))
-
 					// Sending a query with data
 					req := httptest.NewRequest(http.MethodPatch, `/todo/list/edit`, bytes.NewBufferString(requestBody))
 					req.Header.Set("token", accessJwt)
@@ -569,6 +739,11 @@ var _ = Describe("Lists", func() {
 
 				BeforeEach(func() {
 					// Query building for the postgres
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListById)).
+						WithArgs(108105115116).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
+							AddRow(108105115116, 117115101114, "Test List Name", "Test List Comment", 0))
+
 					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectMaxListIndex)).
 						WithArgs(117115101114).
 						WillReturnRows(sqlmock.NewRows([]string{"index"}).
@@ -580,11 +755,6 @@ var _ = Describe("Lists", func() {
 						WillReturnResult(sqlmock.NewResult(1, 1))
 					postgresMock.ExpectCommit()
 
-					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListById)).
-						WithArgs(108105115116).
-						WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
-							AddRow(108105115116, 117115101114, "Test List Name", "Test List Comment", 0))
-
 					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListById)).
 						WithArgs(108105115116).
 						WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
@@ -631,6 +801,11 @@ var _ = Describe("Lists", func() {
 
 				BeforeEach(func() {
 					// Query building for the postgres
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListById)).
+						WithArgs(108105115116).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
+							AddRow(108105115116, 117115101114, "Test List Name", "Test List Comment", 1))
+
 					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectMaxListIndex)).
 						WithArgs(117115101114).
 						WillReturnRows(sqlmock.NewRows([]string{"index"}).
@@ -642,11 +817,6 @@ var _ = Describe("Lists", func() {
 						WillReturnResult(sqlmock.NewResult(1, 1))
 					postgresMock.ExpectCommit()
 
-					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListById)).
-						WithArgs(108105115116).
-						WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
-							AddRow(108105115116, 117115101114, "Test List Name", "Test List Comment", 1))
-
 					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListById)).
 						WithArgs(108105115116).
 						WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
@@ -692,30 +862,38 @@ var _ = Describe("Lists", func() {
 
 	Describe("Show lists", func() {
 		BeforeEach(func() {
-			r.GET("/todo/list/show", handler.ShowLists)
+			r.GET("/todo/list/show", handler.AuthMiddleware(), handler.ShowLists)
 		})
 
-		Context("inactive user", func() {
+		Context("missing access token", func() {
 			BeforeEach(func() {
 				// Sending a query with data
 				req := httptest.NewRequest(http.MethodGet, `/todo/list/show`, nil)
 				r.ServeHTTP(w, req)
 			})
 
-			It("should return an error that the user is inactive", func() {
-				Expect(w.Code).To(Equal(http.StatusNotFound))
-				Expect(w.Body.String()).To(Equal(`{"error":"Inactive user."}`))
+			It("should return an error that the access token is missing", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
 			})
 		})
 
 		Describe("Ok", func() {
 			var lists models.ApiShowLists
 
+			BeforeEach(func() {
+				lists = models.ApiShowLists{}
+			})
+
 			Context("without lists", func() {
 				BeforeEach(func() {
 					// Query building for the postgres
-					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllListsByUserId)).
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectSavedFiltersByUserId)).
 						WithArgs(117115101114).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "query"}))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserLists)).
+						WithArgs("owner", 117115101114, 117115101114).
 						WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}))
 
 					// Sending a query with data
@@ -736,8 +914,12 @@ var _ = Describe("Lists", func() {
 			Context("with lists", func() {
 				BeforeEach(func() {
 					// Query building for the postgres
-					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllListsByUserId)).
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectSavedFiltersByUserId)).
 						WithArgs(117115101114).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "query"}))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserLists)).
+						WithArgs("owner", 117115101114, 117115101114).
 						WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
 							AddRow(108105115116, 117115101114, "Test List Name", "Test List Comment", 0))
 
@@ -752,8 +934,125 @@ var _ = Describe("Lists", func() {
 
 				It("should return list data", func() {
 					Expect(w.Code).To(Equal(http.StatusOK))
-					Expect(lists.Lists).To(Equal([]models.ListsData{{Id: 108105115116, Name: "Test List Name", Comment: "Test List Comment", Index: 0}}))
+					Expect(lists.Lists).To(Equal([]models.ListsData{{Id: 108105115116, Type: "list", Name: "Test List Name", Comment: "Test List Comment", Index: 0}}))
+				})
+			})
+
+			Context("with workspace lists", func() {
+				BeforeEach(func() {
+					// Query building for the postgres
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectSavedFiltersByUserId)).
+						WithArgs(117115101114).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "query"}))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserLists)).
+						WithArgs("owner", 117115101114, 117115101114).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "workspace_id", "name", "comment", "index", "role", "workspace"}).
+							AddRow(108105115116, 117115101114, 0, "Test List Name", "Test List Comment", 0, "owner", "").
+							AddRow(108105115117, 0, 119111114107, "Test List Name", "Test List Comment", 0, "editor", "Test Workspace Name"))
+
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodGet, "/todo/list/show", nil)
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+
+					// Converting the query body into a model
+					Expect(json.Unmarshal(w.Body.Bytes(), &lists)).To(BeNil())
 				})
+
+				It("should return the workspace lists after the user lists", func() {
+					Expect(w.Code).To(Equal(http.StatusOK))
+					Expect(lists.Lists).To(Equal([]models.ListsData{
+						{Id: 108105115116, Type: "list", Name: "Test List Name", Comment: "Test List Comment", Role: "owner", Index: 0},
+						{Id: 108105115117, Type: "list", Name: "Test List Name", Comment: "Test List Comment", Role: "editor", WorkspaceId: 119111114107, Workspace: "Test Workspace Name", Index: 0},
+					}))
+				})
+			})
+
+			Context("with the search and the next page", func() {
+				BeforeEach(func() {
+					// Query building for the postgres
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectSavedFiltersBySearch)).
+						WithArgs(117115101114, `%100\%%`).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "query"}))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListsBySearch)).
+						WithArgs("owner", 117115101114, 117115101114, `%100\%%`, `%100\%%`).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index", "page_key"}).
+							AddRow(108105115116, 117115101114, "Test List Name", "100% done", 0, `[false, "", 0, false, 0, 108105115116]`).
+							AddRow(108105115117, 117115101114, "Test List Name", "100% done", 1, `[false, "", 0, false, 1, 108105115117]`))
+
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodGet, "/todo/list/show?q=100%25&limit=1", nil)
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+
+					// Converting the query body into a model
+					Expect(json.Unmarshal(w.Body.Bytes(), &lists)).To(BeNil())
+				})
+
+				It("should return the first page of the list data", func() {
+					Expect(w.Code).To(Equal(http.StatusOK))
+					Expect(lists.Lists).To(Equal([]models.ListsData{{Id: 108105115116, Type: "list", Name: "Test List Name", Comment: "100% done", Index: 0}}))
+					Expect(lists.NextCursor).To(Equal(common.EncodeCursor([]interface{}{"list", false, "", 0, false, 0, 108105115116})))
+				})
+			})
+
+			Context("with the saved filters on the page", func() {
+				BeforeEach(func() {
+					// Query building for the postgres
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectSavedFiltersAfterKey)).
+						WithArgs(117115101114, "Party", "1023456789").
+						WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "query", "page_key"}).
+							AddRow(1023456790, 117115101114, "Work", "category contains Work", `["Work", 1023456790]`))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserLists)).
+						WithArgs("owner", 117115101114, 117115101114).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
+							AddRow(108105115116, 117115101114, "Test List Name", "Test List Comment", 0))
+
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodGet, "/todo/list/show?limit=1&cursor="+common.EncodeCursor([]interface{}{"filter", "Party", 1023456789}), nil)
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+
+					// Converting the query body into a model
+					Expect(json.Unmarshal(w.Body.Bytes(), &lists)).To(BeNil())
+				})
+
+				It("should start the next page with the lists", func() {
+					Expect(w.Code).To(Equal(http.StatusOK))
+					Expect(lists.Lists).To(Equal([]models.ListsData{{Id: 1023456790, Type: "filter", Name: "Work", Query: "category contains Work"}}))
+					Expect(lists.NextCursor).To(Equal(common.EncodeCursor([]interface{}{"list"})))
+				})
+			})
+		})
+
+		Context("incorrect limit", func() {
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/list/show?limit=1000", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the limit is incorrect", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Incorrect limit."}`))
+			})
+		})
+
+		Context("incorrect cursor", func() {
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/list/show?cursor=LTE", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the cursor is incorrect", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Incorrect cursor."}`))
 			})
 		})
 	})
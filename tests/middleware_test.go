@@ -0,0 +1,145 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/NKTKLN/todo-api/models"
+	rd "github.com/NKTKLN/todo-api/pkg/db/redis"
+	"github.com/NKTKLN/todo-api/pkg/handlers"
+)
+
+var _ = Describe("Auth middleware", func() {
+	var (
+		r                       *gin.Engine
+		w                       *httptest.ResponseRecorder
+		accessJwt               string
+		handler                 handlers.Handler
+		redisClientAccessToken  *redis.Client
+		redisClientRefreshToken *redis.Client
+		redisClientSession      *redis.Client
+	)
+
+	BeforeEach(func() {
+		gin.SetMode(gin.ReleaseMode)
+
+		r = gin.New()
+		w = httptest.NewRecorder()
+
+		redisClientAccessToken = TestRedisConnection()
+		redisClientRefreshToken = TestRedisConnection()
+		redisClientSession = TestRedisConnection()
+
+		handler.RedisClient = &rd.RedisClients{
+			AccessTokenClient:  redisClientAccessToken,
+			RefreshTokenClient: redisClientRefreshToken,
+			SessionClient:      redisClientSession,
+		}
+
+		// Creating new session with a pair of jwt tokens
+		accessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
+
+		r.GET("/protected", handler.AuthMiddleware(), func(c *gin.Context) {
+			c.String(http.StatusOK, "%d", c.GetInt("userId"))
+		})
+	})
+
+	AfterEach(func() {
+		redisClientAccessToken.Close()
+		redisClientRefreshToken.Close()
+		redisClientSession.Close()
+	})
+
+	Context("missing access token", func() {
+		BeforeEach(func() {
+			// Sending a query with data
+			req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+			r.ServeHTTP(w, req)
+		})
+
+		It("should return an error that the access token is missing", func() {
+			Expect(w.Code).To(Equal(http.StatusUnauthorized))
+			Expect(w.Header().Get("WWW-Authenticate")).To(Equal(`Bearer realm="todo-api"`))
+			Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
+		})
+	})
+
+	Context("invalid access token", func() {
+		BeforeEach(func() {
+			// Sending a query with data
+			req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+			req.Header.Set("Authorization", "Bearer token")
+			r.ServeHTTP(w, req)
+		})
+
+		It("should return an error that the access token is invalid", func() {
+			Expect(w.Code).To(Equal(http.StatusUnauthorized))
+			Expect(w.Header().Get("WWW-Authenticate")).To(Equal(`Bearer realm="todo-api", error="invalid_token"`))
+			Expect(w.Body.String()).To(Equal(`{"error":"Invalid access token."}`))
+		})
+	})
+
+	Context("unsupported authorization scheme", func() {
+		BeforeEach(func() {
+			// Sending a query with data
+			req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+			req.Header.Set("Authorization", "Basic "+accessJwt)
+			r.ServeHTTP(w, req)
+		})
+
+		It("should return an error that the access token is missing", func() {
+			Expect(w.Code).To(Equal(http.StatusUnauthorized))
+			Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
+		})
+	})
+
+	Context("bearer token", func() {
+		BeforeEach(func() {
+			// Sending a query with data
+			req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+			req.Header.Set("Authorization", "Bearer "+accessJwt)
+			r.ServeHTTP(w, req)
+		})
+
+		It("should pass the user id to the handler", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(Equal("117115101114"))
+		})
+	})
+
+	Context("legacy token header", func() {
+		BeforeEach(func() {
+			// Sending a query with data
+			req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+			req.Header.Set("token", accessJwt)
+			r.ServeHTTP(w, req)
+		})
+
+		It("should pass the user id to the handler", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(Equal("117115101114"))
+		})
+	})
+
+	Context("disabled user", func() {
+		BeforeEach(func() {
+			Expect(handler.RedisClient.AddDisabledUser(context.Background(), 117115101114)).To(BeNil())
+
+			// Sending a query with data
+			req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+			req.Header.Set("Authorization", "Bearer "+accessJwt)
+			r.ServeHTTP(w, req)
+		})
+
+		It("should return an error that the account is disabled", func() {
+			Expect(w.Code).To(Equal(http.StatusForbidden))
+			Expect(w.Body.String()).To(Equal(`{"error":"Account is disabled."}`))
+		})
+	})
+})
@@ -0,0 +1,383 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/NKTKLN/todo-api/models"
+	"github.com/NKTKLN/todo-api/pkg/common"
+	rd "github.com/NKTKLN/todo-api/pkg/db/redis"
+	"github.com/NKTKLN/todo-api/pkg/handlers"
+)
+
+var _ = Describe("Personal access tokens", func() {
+	const personalToken = "pat_3f9a10c7b28d2e4b61f03f9a10c7b28d2e4b61f03f9a10c7b28d2e4b61f0aa"
+
+	var (
+		r                       *gin.Engine
+		w                       *httptest.ResponseRecorder
+		accessJwt               string
+		handler                 handlers.Handler
+		postgresMock            sqlmock.Sqlmock
+		redisClientAccessToken  *redis.Client
+		redisClientRefreshToken *redis.Client
+		redisClientSession      *redis.Client
+	)
+
+	tokenColumns := []string{"id", "user_id", "name", "token_hash", "scopes", "expires_at", "created_at"}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.ReleaseMode)
+
+		r = gin.New()
+		w = httptest.NewRecorder()
+
+		redisClientAccessToken = TestRedisConnection()
+		redisClientRefreshToken = TestRedisConnection()
+		redisClientSession = TestRedisConnection()
+
+		handler.RedisClient = &rd.RedisClients{
+			AccessTokenClient:  redisClientAccessToken,
+			RefreshTokenClient: redisClientRefreshToken,
+			SessionClient:      redisClientSession,
+		}
+
+		handler.PostgresDB, postgresMock = MockPostgresConnection()
+
+		// Creating new session with a pair of jwt tokens
+		accessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
+	})
+
+	AfterEach(func() {
+		redisClientAccessToken.Close()
+		redisClientRefreshToken.Close()
+		redisClientSession.Close()
+
+		Expect(postgresMock.ExpectationsWereMet()).ShouldNot(HaveOccurred())
+	})
+
+	Describe("Create personal token", func() {
+		BeforeEach(func() {
+			r.POST("/user/tokens", handler.AuthMiddleware(), handlers.SessionMiddleware(), handler.CreatePersonalToken)
+		})
+
+		Context("empty name", func() {
+			const requestBody = `{"name": "", "scopes": ["lists:read"]}`
+
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/user/tokens", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the name is empty", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Empty name."}`))
+			})
+		})
+
+		Context("incorrect scope", func() {
+			const requestBody = `{"name": "CI", "scopes": ["lists:read", "admin"]}`
+
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/user/tokens", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the scope is incorrect", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Incorrect scope: admin."}`))
+			})
+		})
+
+		Context("incorrect expiration time", func() {
+			const requestBody = `{"name": "CI", "scopes": ["lists:read"], "expires_at": "2007-12-10 13:13"}`
+
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/user/tokens", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the expiration time is incorrect", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Incorrect expiration time."}`))
+			})
+		})
+
+		Context("ok", func() {
+			const requestBody = `{"name": "CI", "scopes": ["lists:read", "tasks:write"], "expires_at": "2077-12-10 13:13"}`
+
+			var token models.ApiPersonalToken
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectPersonalTokenById)).
+					WithArgs(AnyInt{}).
+					WillReturnRows(sqlmock.NewRows(tokenColumns))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlInsertPersonalTokenData)).
+					WithArgs(117115101114, "CI", AnyString{}, `{"lists:read","tasks:write"}`, AnyTime{}, AnyTime{}, AnyInt{}).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/user/tokens", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+
+				// Converting the query body into a model
+				Expect(json.Unmarshal(w.Body.Bytes(), &token)).To(BeNil())
+			})
+
+			It("should return the new token once", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(token.Token).To(HavePrefix(models.PERSONAL_TOKEN_PREFIX))
+				Expect(token.Name).To(Equal("CI"))
+				Expect(token.Scopes).To(Equal([]string{"lists:read", "tasks:write"}))
+				Expect(token.ExpiresAt).To(Equal("2077-12-10 13:13"))
+			})
+		})
+	})
+
+	Describe("Show personal tokens", func() {
+		var tokens models.ApiShowPersonalTokens
+
+		BeforeEach(func() {
+			r.GET("/user/tokens", handler.AuthMiddleware(), handlers.SessionMiddleware(), handler.ShowPersonalTokens)
+
+			// Query building for the postgres
+			postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllPersonalTokensByUserId)).
+				WithArgs(117115101114).
+				WillReturnRows(sqlmock.NewRows(tokenColumns).
+					AddRow(1, 117115101114, "CI", common.HashToken(personalToken), "{lists:read}", nil, time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)))
+
+			// Sending a query with data
+			req := httptest.NewRequest(http.MethodGet, "/user/tokens", nil)
+			req.Header.Set("token", accessJwt)
+			r.ServeHTTP(w, req)
+
+			// Converting the query body into a model
+			Expect(json.Unmarshal(w.Body.Bytes(), &tokens)).To(BeNil())
+		})
+
+		It("should return the tokens without their values", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(tokens.Tokens).To(Equal([]models.PersonalTokenInfo{{
+				Id:        1,
+				Name:      "CI",
+				Scopes:    []string{"lists:read"},
+				CreatedAt: "2022-06-01 12:00",
+			}}))
+			Expect(w.Body.String()).NotTo(ContainSubstring(common.HashToken(personalToken)))
+		})
+	})
+
+	Describe("Delete personal token", func() {
+		BeforeEach(func() {
+			r.DELETE("/user/tokens/:id", handler.AuthMiddleware(), handlers.SessionMiddleware(), handler.DeletePersonalToken)
+		})
+
+		Context("this token not found", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectPersonalTokenByIdAndUserId)).
+					WithArgs(1, 117115101114).
+					WillReturnRows(sqlmock.NewRows(tokenColumns))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/user/tokens/1", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the token is not found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(Equal(`{"error":"This token not found."}`))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectPersonalTokenByIdAndUserId)).
+					WithArgs(1, 117115101114).
+					WillReturnRows(sqlmock.NewRows(tokenColumns).
+						AddRow(1, 117115101114, "CI", common.HashToken(personalToken), "{lists:read}", nil, time.Now()))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeletePersonalToken)).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/user/tokens/1", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the token has been revoked", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The token has been revoked."}`))
+			})
+		})
+	})
+
+	Describe("Authorization with a personal token", func() {
+		expectToken := func(expiresAt interface{}) {
+			postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectPersonalTokenByHash)).
+				WithArgs(common.HashToken(personalToken)).
+				WillReturnRows(sqlmock.NewRows(tokenColumns).
+					AddRow(1, 117115101114, "CI", common.HashToken(personalToken), "{lists:read}", expiresAt, time.Now()))
+		}
+
+		expectTokenUser := func(disabled bool) {
+			postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+				WithArgs(117115101114).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "email", "username", "disabled"}).
+					AddRow(117115101114, "email@example.com", "test_username", disabled))
+		}
+
+		BeforeEach(func() {
+			r.GET("/todo/list/show", handler.AuthMiddleware(), handlers.ScopeMiddleware("lists:read"), handler.ShowLists)
+			r.POST("/todo/list/add", handler.AuthMiddleware(), handlers.ScopeMiddleware("lists:write"), handler.AddList)
+			r.GET("/user/tokens", handler.AuthMiddleware(), handlers.SessionMiddleware(), handler.ShowPersonalTokens)
+		})
+
+		Context("unknown token", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectPersonalTokenByHash)).
+					WithArgs(common.HashToken(personalToken)).
+					WillReturnRows(sqlmock.NewRows(tokenColumns))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/list/show", nil)
+				req.Header.Set("Authorization", "Bearer "+personalToken)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the access token is invalid", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Body.String()).To(Equal(`{"error":"Invalid access token."}`))
+			})
+		})
+
+		Context("expired token", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectToken(time.Now().Add(-time.Hour))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/list/show", nil)
+				req.Header.Set("Authorization", "Bearer "+personalToken)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the access token is invalid", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Body.String()).To(Equal(`{"error":"Invalid access token."}`))
+			})
+		})
+
+		Context("disabled user", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectToken(nil)
+				expectTokenUser(true)
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/list/show", nil)
+				req.Header.Set("Authorization", "Bearer "+personalToken)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the account is disabled", func() {
+				Expect(w.Code).To(Equal(http.StatusForbidden))
+				Expect(w.Body.String()).To(Equal(`{"error":"Account is disabled."}`))
+			})
+		})
+
+		Context("route outside of the token scopes", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectToken(nil)
+				expectTokenUser(false)
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/list/add", bytes.NewBufferString(`{"name": "Test List Name"}`))
+				req.Header.Set("Authorization", "Bearer "+personalToken)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the token scope is insufficient", func() {
+				Expect(w.Code).To(Equal(http.StatusForbidden))
+				Expect(w.Header().Get("WWW-Authenticate")).To(ContainSubstring(`scope="lists:write"`))
+				Expect(w.Body.String()).To(Equal(`{"error":"Insufficient token scope."}`))
+			})
+		})
+
+		Context("session only route", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectToken(nil)
+				expectTokenUser(false)
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/user/tokens", nil)
+				req.Header.Set("Authorization", "Bearer "+personalToken)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the route is not available", func() {
+				Expect(w.Code).To(Equal(http.StatusForbidden))
+				Expect(w.Body.String()).To(Equal(`{"error":"Not available for personal access tokens."}`))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectToken(time.Now().Add(time.Hour))
+				expectTokenUser(false)
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectSavedFiltersByUserId)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "query"}))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserLists)).
+					WithArgs("owner", 117115101114, 117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "comment", "index"}).
+						AddRow(1, "Test List Name", "", 0))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/list/show", nil)
+				req.Header.Set("Authorization", "Bearer "+personalToken)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should give access to the routes of the token scopes", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(ContainSubstring("Test List Name"))
+			})
+		})
+	})
+})
@@ -0,0 +1,537 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/NKTKLN/todo-api/models"
+	rd "github.com/NKTKLN/todo-api/pkg/db/redis"
+	"github.com/NKTKLN/todo-api/pkg/handlers"
+	"github.com/NKTKLN/todo-api/pkg/scheduler"
+)
+
+var _ = Describe("Reminders", func() {
+	var (
+		r                       *gin.Engine
+		w                       *httptest.ResponseRecorder
+		accessJwt               string
+		handler                 handlers.Handler
+		postgresMock            sqlmock.Sqlmock
+		redisClientAccessToken  *redis.Client
+		redisClientRefreshToken *redis.Client
+		redisClientSession      *redis.Client
+		redisClientReminder     *redis.Client
+	)
+
+	taskColumns := []string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}
+	reminderColumns := []string{"id", "task_id", "user_id", "before", "remind_at", "sent"}
+	endTime := time.Date(2077, time.December, 10, 13, 13, 0, 0, time.UTC)
+
+	BeforeEach(func() {
+		gin.SetMode(gin.ReleaseMode)
+
+		r = gin.New()
+		w = httptest.NewRecorder()
+
+		redisClientAccessToken = TestRedisConnection()
+		redisClientRefreshToken = TestRedisConnection()
+		redisClientSession = TestRedisConnection()
+		redisClientReminder = TestRedisConnection()
+
+		handler.RedisClient = &rd.RedisClients{
+			AccessTokenClient:  redisClientAccessToken,
+			RefreshTokenClient: redisClientRefreshToken,
+			SessionClient:      redisClientSession,
+			ReminderClient:     redisClientReminder,
+		}
+
+		handler.PostgresDB, postgresMock = MockPostgresConnection()
+		handler.EmailAuthData = NewFakeEmailProvider("email@example.com", "StRon9Pa$$w0rd", "smtp.example.com", 0)
+
+		// Creating new session with a jwt token
+		accessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
+	})
+
+	AfterEach(func() {
+		redisClientAccessToken.Close()
+		redisClientRefreshToken.Close()
+		redisClientSession.Close()
+		redisClientReminder.Close()
+
+		Expect(postgresMock.ExpectationsWereMet()).ShouldNot(HaveOccurred())
+	})
+
+	Describe("Add reminder", func() {
+		BeforeEach(func() {
+			r.POST("/todo/reminder/add", handler.AuthMiddleware(), handler.AddReminder)
+		})
+
+		Context("this task not found", func() {
+			const requestBody = `{"task_id": 11697115107, "before": 30}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+					WithArgs(11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
+					WithArgs(117115101114, 117115101114, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/reminder/add", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the task is not found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(Equal(`{"error":"This task not found."}`))
+			})
+		})
+
+		Context("reminder of a subtask", func() {
+			const requestBody = `{"task_id": 1151179811697115107, "before": 30}`
+
+			BeforeEach(func() {
+				// Query building for the postgres, the list is resolved by the root task of the subtask
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+					WithArgs(1151179811697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(11697115107))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
+					WithArgs(117115101114, 117115101114, 11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(108105115116))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllRemindersByTaskId)).
+					WithArgs(1151179811697115107).
+					WillReturnRows(sqlmock.NewRows(reminderColumns))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+					WithArgs(1151179811697115107).
+					WillReturnRows(sqlmock.NewRows(taskColumns).
+						AddRow(1151179811697115107, 0, 11697115107, "Test Subtask Name", "Test Subtask Comment", 0, nil, endTime, false, false))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectReminderById)).
+					WithArgs(AnyInt{}).
+					WillReturnRows(sqlmock.NewRows(reminderColumns))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlInsertReminderData)).
+					WithArgs(1151179811697115107, 117115101114, 30, endTime.Add(-30*time.Minute), false, AnyInt{}).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/reminder/add", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should add the reminder to the queue", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"Reminder added to db."}`))
+				Expect(redisClientReminder.ZCard(context.Background(), "reminders").Val()).To(Equal(int64(1)))
+			})
+		})
+
+		Describe("Task found", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+					WithArgs(11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(11697115107))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
+					WithArgs(117115101114, 117115101114, 11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(108105115116))
+			})
+
+			Context("both before and remind_at", func() {
+				const requestBody = `{"task_id": 11697115107, "before": 30, "remind_at": "2077-12-10 12:00"}`
+
+				BeforeEach(func() {
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodPost, "/todo/reminder/add", bytes.NewBufferString(requestBody))
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+				})
+
+				It("should return an error that only one of the times can be specified", func() {
+					Expect(w.Code).To(Equal(http.StatusBadRequest))
+					Expect(w.Body.String()).To(Equal(`{"error":"Specify either before or remind_at."}`))
+				})
+			})
+
+			Context("task without end time", func() {
+				const requestBody = `{"task_id": 11697115107, "before": 30}`
+
+				BeforeEach(func() {
+					// Query building for the postgres
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllRemindersByTaskId)).
+						WithArgs(11697115107).
+						WillReturnRows(sqlmock.NewRows(reminderColumns))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+						WithArgs(11697115107).
+						WillReturnRows(sqlmock.NewRows(taskColumns).
+							AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 0, nil, nil, false, false))
+
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodPost, "/todo/reminder/add", bytes.NewBufferString(requestBody))
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+				})
+
+				It("should return an error that the task has no end time", func() {
+					Expect(w.Code).To(Equal(http.StatusBadRequest))
+					Expect(w.Body.String()).To(Equal(`{"error":"The task has no end time."}`))
+				})
+			})
+
+			Context("time in the past", func() {
+				const requestBody = `{"task_id": 11697115107, "remind_at": "2001-12-10 12:00"}`
+
+				BeforeEach(func() {
+					// Query building for the postgres
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllRemindersByTaskId)).
+						WithArgs(11697115107).
+						WillReturnRows(sqlmock.NewRows(reminderColumns))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+						WithArgs(117115101114).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "timezone"}).
+							AddRow(117115101114, "UTC"))
+
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodPost, "/todo/reminder/add", bytes.NewBufferString(requestBody))
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+				})
+
+				It("should return an error that the time is incorrect", func() {
+					Expect(w.Code).To(Equal(http.StatusBadRequest))
+					Expect(w.Body.String()).To(Equal(`{"error":"Incorrect time."}`))
+				})
+			})
+
+			Context("reminder before the end time", func() {
+				const requestBody = `{"task_id": 11697115107, "before": 30}`
+
+				BeforeEach(func() {
+					// Query building for the postgres
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllRemindersByTaskId)).
+						WithArgs(11697115107).
+						WillReturnRows(sqlmock.NewRows(reminderColumns))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+						WithArgs(11697115107).
+						WillReturnRows(sqlmock.NewRows(taskColumns).
+							AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 0, nil, endTime, false, false))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectReminderById)).
+						WithArgs(AnyInt{}).
+						WillReturnRows(sqlmock.NewRows(reminderColumns))
+
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlInsertReminderData)).
+						WithArgs(11697115107, 117115101114, 30, endTime.Add(-30*time.Minute), false, AnyInt{}).
+						WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
+					postgresMock.ExpectCommit()
+
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodPost, "/todo/reminder/add", bytes.NewBufferString(requestBody))
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+				})
+
+				It("should add the reminder to the queue", func() {
+					Expect(w.Code).To(Equal(http.StatusOK))
+					Expect(w.Body.String()).To(Equal(`{"message":"Reminder added to db."}`))
+
+					queue, err := redisClientReminder.ZRangeWithScores(context.Background(), "reminders", 0, -1).Result()
+					Expect(err).To(BeNil())
+					Expect(queue).To(HaveLen(1))
+					Expect(queue[0].Score).To(Equal(float64(endTime.Add(-30 * time.Minute).Unix())))
+				})
+			})
+
+			Context("reminder at the time", func() {
+				const requestBody = `{"task_id": 11697115107, "remind_at": "2077-12-10 12:00"}`
+
+				// The time is taken in the user timezone
+				tokyo, _ := time.LoadLocation("Asia/Tokyo")
+				remindAt := time.Date(2077, time.December, 10, 12, 0, 0, 0, tokyo)
+
+				BeforeEach(func() {
+					// Query building for the postgres
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllRemindersByTaskId)).
+						WithArgs(11697115107).
+						WillReturnRows(sqlmock.NewRows(reminderColumns))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+						WithArgs(117115101114).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "timezone"}).
+							AddRow(117115101114, "Asia/Tokyo"))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectReminderById)).
+						WithArgs(AnyInt{}).
+						WillReturnRows(sqlmock.NewRows(reminderColumns))
+
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlInsertReminderData)).
+						WithArgs(11697115107, 117115101114, nil, remindAt, false, AnyInt{}).
+						WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
+					postgresMock.ExpectCommit()
+
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodPost, "/todo/reminder/add", bytes.NewBufferString(requestBody))
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+				})
+
+				It("should add the reminder to the queue", func() {
+					Expect(w.Code).To(Equal(http.StatusOK))
+					Expect(w.Body.String()).To(Equal(`{"message":"Reminder added to db."}`))
+
+					queue, err := redisClientReminder.ZRangeWithScores(context.Background(), "reminders", 0, -1).Result()
+					Expect(err).To(BeNil())
+					Expect(queue).To(HaveLen(1))
+					Expect(queue[0].Score).To(Equal(float64(time.Date(2077, time.December, 10, 3, 0, 0, 0, time.UTC).Unix())))
+				})
+			})
+		})
+	})
+
+	Describe("Delete reminder", func() {
+		BeforeEach(func() {
+			r.DELETE("/todo/reminder/delete", handler.AuthMiddleware(), handler.DeleteReminder)
+		})
+
+		Context("this reminder not found", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectReminderByIdAndUserId)).
+					WithArgs(1141011092, 117115101114).
+					WillReturnRows(sqlmock.NewRows(reminderColumns))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/todo/reminder/delete?reminder_id=1141011092", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the reminder is not found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(Equal(`{"error":"This reminder not found."}`))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				Expect(handler.RedisClient.ScheduleReminder(context.Background(), 114101109, endTime)).To(BeNil())
+
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectReminderByIdAndUserId)).
+					WithArgs(114101109, 117115101114).
+					WillReturnRows(sqlmock.NewRows(reminderColumns).
+						AddRow(114101109, 11697115107, 117115101114, nil, endTime, false))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteReminder)).
+					WithArgs(114101109).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/todo/reminder/delete?reminder_id=114101109", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should remove the reminder from the queue", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The reminder has been deleted."}`))
+				Expect(redisClientReminder.ZCard(context.Background(), "reminders").Val()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("Show reminders", func() {
+		var reminders models.ApiShowReminders
+
+		BeforeEach(func() {
+			r.GET("/todo/reminder/show", handler.AuthMiddleware(), handler.ShowReminders)
+
+			// Query building for the postgres
+			postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+				WithArgs(11697115107).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).
+					AddRow(11697115107))
+
+			postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
+				WithArgs(117115101114, 117115101114, 11697115107).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).
+					AddRow(108105115116))
+
+			postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllRemindersByTaskId)).
+				WithArgs(11697115107).
+				WillReturnRows(sqlmock.NewRows(reminderColumns).
+					AddRow(114101109, 11697115107, 117115101114, 30, endTime.Add(-30*time.Minute), true).
+					AddRow(1141011092, 11697115107, 117115101114, nil, endTime, false))
+
+			// The times are shown in the user timezone
+			postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+				WithArgs(117115101114).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "timezone"}).
+					AddRow(117115101114, "Asia/Tokyo"))
+
+			// Sending a query with data
+			req := httptest.NewRequest(http.MethodGet, "/todo/reminder/show?task_id=11697115107", nil)
+			req.Header.Set("token", accessJwt)
+			r.ServeHTTP(w, req)
+
+			// Converting the query body into a model
+			Expect(json.Unmarshal(w.Body.Bytes(), &reminders)).To(BeNil())
+		})
+
+		It("should return the task reminders", func() {
+			before := 30
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(reminders.Reminders).To(Equal([]models.ReminderData{
+				{Id: 114101109, Before: &before, RemindAt: "2077-12-10 21:43", Sent: true},
+				{Id: 1141011092, RemindAt: "2077-12-10 22:13"},
+			}))
+		})
+	})
+
+	Describe("Scheduler", func() {
+		var reminderScheduler scheduler.Scheduler
+
+		BeforeEach(func() {
+			reminderScheduler = scheduler.Scheduler{
+				PostgresDB:  handler.PostgresDB,
+				RedisClient: handler.RedisClient,
+				Notifier:    scheduler.NewEmailNotifier(handler.EmailAuthData),
+			}
+		})
+
+		Context("reminder claimed by one replica", func() {
+			It("should not be claimed by another replica until the lease expires", func() {
+				ctx := context.Background()
+				Expect(handler.RedisClient.ScheduleReminder(ctx, 114101109, endTime)).To(BeNil())
+
+				Expect(handler.RedisClient.ClaimReminders(ctx, endTime.Add(-time.Second), time.Minute, 10)).To(BeEmpty())
+				Expect(handler.RedisClient.ClaimReminders(ctx, endTime, time.Minute, 10)).To(Equal([]int{114101109}))
+				Expect(handler.RedisClient.ClaimReminders(ctx, endTime.Add(30*time.Second), time.Minute, 10)).To(BeEmpty())
+				Expect(handler.RedisClient.ClaimReminders(ctx, endTime.Add(time.Minute), time.Minute, 10)).To(Equal([]int{114101109}))
+			})
+		})
+
+		Context("due reminder", func() {
+			BeforeEach(func() {
+				Expect(handler.RedisClient.ScheduleReminder(context.Background(), 114101109, endTime)).To(BeNil())
+
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectReminderById)).
+					WithArgs(114101109).
+					WillReturnRows(sqlmock.NewRows(reminderColumns).
+						AddRow(114101109, 11697115107, 117115101114, nil, endTime, false))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlMarkReminderSent)).
+					WithArgs(true, 114101109, false).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+					WithArgs(11697115107).
+					WillReturnRows(sqlmock.NewRows(taskColumns).
+						AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 0, nil, endTime, false, false))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).
+						AddRow(117115101114, "email@example.com"))
+
+				Expect(reminderScheduler.SendReminders(context.Background(), endTime)).To(BeNil())
+			})
+
+			It("should send the reminder and remove it from the queue", func() {
+				Expect(handler.EmailAuthData.(*fakeEmailAuthData).remindedEmails).To(Equal([]string{"email@example.com"}))
+				Expect(redisClientReminder.ZCard(context.Background(), "reminders").Val()).To(BeZero())
+			})
+		})
+
+		Context("reminder already sent by another replica", func() {
+			BeforeEach(func() {
+				Expect(handler.RedisClient.ScheduleReminder(context.Background(), 114101109, endTime)).To(BeNil())
+
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectReminderById)).
+					WithArgs(114101109).
+					WillReturnRows(sqlmock.NewRows(reminderColumns).
+						AddRow(114101109, 11697115107, 117115101114, nil, endTime, false))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlMarkReminderSent)).
+					WithArgs(true, 114101109, false).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				postgresMock.ExpectCommit()
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+					WithArgs(11697115107).
+					WillReturnRows(sqlmock.NewRows(taskColumns).
+						AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 0, nil, endTime, false, false))
+
+				Expect(reminderScheduler.SendReminders(context.Background(), endTime)).To(BeNil())
+			})
+
+			It("should not send the reminder again", func() {
+				Expect(handler.EmailAuthData.(*fakeEmailAuthData).remindedEmails).To(BeEmpty())
+				Expect(redisClientReminder.ZCard(context.Background(), "reminders").Val()).To(BeZero())
+			})
+		})
+
+		Context("loading reminders after a restart", func() {
+			BeforeEach(func() {
+				// The claimed reminder keeps its lease
+				Expect(handler.RedisClient.ScheduleReminder(context.Background(), 114101109, endTime.Add(time.Minute))).To(BeNil())
+
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUnsentReminders)).
+					WithArgs(false).
+					WillReturnRows(sqlmock.NewRows(reminderColumns).
+						AddRow(114101109, 11697115107, 117115101114, nil, endTime, false).
+						AddRow(1141011092, 11697115107, 117115101114, 30, endTime.Add(-30*time.Minute), false))
+
+				Expect(reminderScheduler.LoadReminders(context.Background())).To(BeNil())
+			})
+
+			It("should return the unsent reminders to the queue", func() {
+				queue, err := redisClientReminder.ZRangeWithScores(context.Background(), "reminders", 0, -1).Result()
+				Expect(err).To(BeNil())
+				Expect(queue).To(Equal([]redis.Z{
+					{Score: float64(endTime.Add(-30 * time.Minute).Unix()), Member: "1141011092"},
+					{Score: float64(endTime.Add(time.Minute).Unix()), Member: "114101109"},
+				}))
+			})
+		})
+	})
+})
@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/NKTKLN/todo-api/pkg/common"
+)
+
+var _ = Describe("Recurrence rule", func() {
+	// Monday
+	start := time.Date(2077, time.December, 6, 13, 13, 0, 0, time.UTC)
+
+	// Getting the dates of the following occurrences
+	occurrences := func(rule string, current time.Time, count int) (dates []string) {
+		r, err := common.ParseRRule(rule)
+		Expect(err).To(BeNil())
+
+		for occurrence := 0; occurrence < count; occurrence++ {
+			next, ok := r.Next(current, occurrence)
+			if !ok {
+				break
+			}
+			dates = append(dates, next.Format("2006-01-02 15:04"))
+			current = next
+		}
+		return
+	}
+
+	Describe("Parsing", func() {
+		DescribeTable("incorrect rules",
+			func(rule string) {
+				_, err := common.ParseRRule(rule)
+				Expect(err).NotTo(BeNil())
+			},
+			Entry("empty rule", ""),
+			Entry("missing frequency", "INTERVAL=2"),
+			Entry("unsupported frequency", "FREQ=YEARLY"),
+			Entry("incorrect interval", "FREQ=DAILY;INTERVAL=0"),
+			Entry("incorrect weekday", "FREQ=WEEKLY;BYDAY=XX"),
+			Entry("weekdays with the daily frequency", "FREQ=DAILY;BYDAY=MO"),
+			Entry("incorrect month day", "FREQ=MONTHLY;BYMONTHDAY=32"),
+			Entry("count with until", "FREQ=DAILY;COUNT=2;UNTIL=20780101"),
+			Entry("unsupported rule part", "FREQ=DAILY;BYHOUR=10"),
+		)
+
+		It("should normalize the rule", func() {
+			r, err := common.ParseRRule("rrule:freq=weekly;byday=th,mo,mo;interval=1;until=20780101")
+			Expect(err).To(BeNil())
+			Expect(r.String()).To(Equal("FREQ=WEEKLY;BYDAY=MO,TH;UNTIL=20780101T235959Z"))
+		})
+	})
+
+	Describe("Next occurrence", func() {
+		It("should repeat daily with an interval", func() {
+			Expect(occurrences("FREQ=DAILY;INTERVAL=2", start, 3)).To(Equal([]string{"2077-12-08 13:13", "2077-12-10 13:13", "2077-12-12 13:13"}))
+		})
+
+		It("should repeat weekly on the given weekdays", func() {
+			Expect(occurrences("FREQ=WEEKLY;BYDAY=MO,TH", start, 4)).To(Equal([]string{"2077-12-09 13:13", "2077-12-13 13:13", "2077-12-16 13:13", "2077-12-20 13:13"}))
+		})
+
+		It("should skip weeks with an interval", func() {
+			Expect(occurrences("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR", start, 3)).To(Equal([]string{"2077-12-10 13:13", "2077-12-20 13:13", "2077-12-24 13:13"}))
+		})
+
+		It("should skip months without the day", func() {
+			Expect(occurrences("FREQ=MONTHLY", time.Date(2078, time.January, 31, 9, 0, 0, 0, time.UTC), 2)).To(Equal([]string{"2078-03-31 09:00", "2078-05-31 09:00"}))
+		})
+
+		It("should count month days from the end of the month", func() {
+			Expect(occurrences("FREQ=MONTHLY;BYMONTHDAY=1,-1", start, 3)).To(Equal([]string{"2077-12-31 13:13", "2078-01-01 13:13", "2078-01-31 13:13"}))
+		})
+
+		It("should stop after the count", func() {
+			Expect(occurrences("FREQ=DAILY;COUNT=3", start, 5)).To(Equal([]string{"2077-12-07 13:13", "2077-12-08 13:13"}))
+		})
+
+		It("should stop after the until", func() {
+			Expect(occurrences("FREQ=WEEKLY;UNTIL=20771220", start, 5)).To(Equal([]string{"2077-12-13 13:13", "2077-12-20 13:13"}))
+		})
+	})
+})
@@ -0,0 +1,342 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/NKTKLN/todo-api/models"
+	rd "github.com/NKTKLN/todo-api/pkg/db/redis"
+	"github.com/NKTKLN/todo-api/pkg/handlers"
+)
+
+var _ = Describe("Saved filters", func() {
+	var (
+		r                       *gin.Engine
+		w                       *httptest.ResponseRecorder
+		accessJwt               string
+		handler                 handlers.Handler
+		postgresMock            sqlmock.Sqlmock
+		redisClientAccessToken  *redis.Client
+		redisClientRefreshToken *redis.Client
+		redisClientSession      *redis.Client
+	)
+
+	filterColumns := []string{"id", "user_id", "name", "query"}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.ReleaseMode)
+
+		r = gin.New()
+		w = httptest.NewRecorder()
+
+		redisClientAccessToken = TestRedisConnection()
+		redisClientRefreshToken = TestRedisConnection()
+		redisClientSession = TestRedisConnection()
+
+		handler.RedisClient = &rd.RedisClients{
+			AccessTokenClient:  redisClientAccessToken,
+			RefreshTokenClient: redisClientRefreshToken,
+			SessionClient:      redisClientSession,
+		}
+
+		handler.PostgresDB, postgresMock = MockPostgresConnection()
+
+		// Creating new session with a jwt token
+		accessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
+	})
+
+	AfterEach(func() {
+		redisClientAccessToken.Close()
+		redisClientRefreshToken.Close()
+		redisClientSession.Close()
+
+		Expect(postgresMock.ExpectationsWereMet()).ShouldNot(HaveOccurred())
+	})
+
+	Describe("Add filter", func() {
+		BeforeEach(func() {
+			r.POST("/todo/filter/add", handler.AuthMiddleware(), handler.AddSavedFilter)
+		})
+
+		Context("empty name", func() {
+			const requestBody = `{"name": "", "query": "not done"}`
+
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/filter/add", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the name is empty", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Empty name."}`))
+			})
+		})
+
+		Context("incorrect filter", func() {
+			const requestBody = `{"name": "Work", "query": "category contains Work AND due within a week"}`
+
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/filter/add", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that explains what is wrong with the filter", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Incorrect filter: incorrect number of days \"a\"."}`))
+			})
+		})
+
+		Context("ok", func() {
+			const requestBody = `{"name": "Work", "query": "category contains Work AND not done AND due within 7 days"}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectSavedFiltersByUserId)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows(filterColumns))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectSavedFilterById)).
+					WithArgs(AnyInt{}).
+					WillReturnRows(sqlmock.NewRows(filterColumns))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlInsertSavedFilterData)).
+					WithArgs(117115101114, "Work", "category contains Work AND not done AND due within 7 days", AnyInt{}).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/filter/add", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the filter was added", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"Filter added to db."}`))
+			})
+		})
+	})
+
+	Describe("Edit filter", func() {
+		BeforeEach(func() {
+			r.PUT("/todo/filter/edit", handler.AuthMiddleware(), handler.EditSavedFilter)
+		})
+
+		Context("this filter not found", func() {
+			const requestBody = `{"id": 102105108116, "name": "Work", "query": "not done"}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectSavedFilterByIdAndUserId)).
+					WithArgs(102105108116, 117115101114).
+					WillReturnRows(sqlmock.NewRows(filterColumns))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPut, "/todo/filter/edit", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the filter is not found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(Equal(`{"error":"This filter not found."}`))
+			})
+		})
+
+		Context("ok", func() {
+			const requestBody = `{"id": 102105108116, "name": "Work", "query": "special AND not done"}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectSavedFilterByIdAndUserId)).
+					WithArgs(102105108116, 117115101114).
+					WillReturnRows(sqlmock.NewRows(filterColumns).
+						AddRow(102105108116, 117115101114, "Work", "not done"))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditSavedFilter)).
+					WithArgs("Work", "special AND not done", 102105108116).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPut, "/todo/filter/edit", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message about successful update of the filter data", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"Updating the filter data was successful."}`))
+			})
+		})
+	})
+
+	Describe("Delete filter", func() {
+		BeforeEach(func() {
+			r.DELETE("/todo/filter/delete", handler.AuthMiddleware(), handler.DeleteSavedFilter)
+		})
+
+		Context("error when converting filter_id", func() {
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, `/todo/filter/delete?filter_id="102105108116"`, nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error message when converting the filter_id", func() {
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+				Expect(w.Body.String()).To(Equal(`{"error":"Error when converting filter_id."}`))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectSavedFilterByIdAndUserId)).
+					WithArgs(102105108116, 117115101114).
+					WillReturnRows(sqlmock.NewRows(filterColumns).
+						AddRow(102105108116, 117115101114, "Work", "not done"))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteSavedFilter)).
+					WithArgs(102105108116).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/todo/filter/delete?filter_id=102105108116", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the filter has been deleted", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The filter has been deleted."}`))
+			})
+		})
+	})
+
+	Describe("Show filter tasks", func() {
+		var tasks models.ApiShowViewTasks
+
+		BeforeEach(func() {
+			r.GET("/todo/filter/show", handler.AuthMiddleware(), handler.ShowSavedFilterTasks)
+		})
+
+		Context("this filter not found", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectSavedFilterByIdAndUserId)).
+					WithArgs(102105108116, 117115101114).
+					WillReturnRows(sqlmock.NewRows(filterColumns))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/filter/show?filter_id=102105108116", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the filter is not found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(Equal(`{"error":"This filter not found."}`))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectSavedFilterByIdAndUserId)).
+					WithArgs(102105108116, 117115101114).
+					WillReturnRows(sqlmock.NewRows(filterColumns).
+						AddRow(102105108116, 117115101114, "Starred", "not done AND special"))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "timezone"}).
+						AddRow(117115101114, "UTC"))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectStarredUserTasks)).
+					WithArgs(117115101114, 117115101114, false, true).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}).
+						AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 0, nil, nil, false, true))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserLists)).
+					WithArgs("owner", 117115101114, 117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
+						AddRow(108105115116, 117115101114, "Test List Name", "Test List Comment", 0))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskAssignees)).
+					WillReturnRows(sqlmock.NewRows([]string{"task_id", "id", "username"}))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/filter/show?filter_id=102105108116", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+
+				// Converting the query body into a model
+				Expect(json.Unmarshal(w.Body.Bytes(), &tasks)).To(BeNil())
+			})
+
+			It("should return tasks matching the filter", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(tasks.Tasks).To(Equal([]models.ViewTasksData{{
+					ListId:    108105115116,
+					ListName:  "Test List Name",
+					TasksData: models.TasksData{Id: 11697115107, Name: "Test Task Name", Comment: "Test Task Comment", EndTime: "0001-01-01 00:00", Special: true},
+				}}))
+			})
+		})
+	})
+
+	Describe("Show lists with filters", func() {
+		var lists models.ApiShowLists
+
+		BeforeEach(func() {
+			r.GET("/todo/list/show", handler.AuthMiddleware(), handler.ShowLists)
+
+			// Query building for the postgres
+			postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectSavedFiltersByUserId)).
+				WithArgs(117115101114).
+				WillReturnRows(sqlmock.NewRows(filterColumns).
+					AddRow(102105108116, 117115101114, "Work", "category contains Work AND not done"))
+
+			postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserLists)).
+				WithArgs("owner", 117115101114, 117115101114).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
+					AddRow(108105115116, 117115101114, "Test List Name", "Test List Comment", 0))
+
+			// Sending a query with data
+			req := httptest.NewRequest(http.MethodGet, "/todo/list/show", nil)
+			req.Header.Set("token", accessJwt)
+			r.ServeHTTP(w, req)
+
+			// Converting the query body into a model
+			Expect(json.Unmarshal(w.Body.Bytes(), &lists)).To(BeNil())
+		})
+
+		It("should return the filters before the lists", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(lists.Lists).To(Equal([]models.ListsData{
+				{Id: 102105108116, Type: "filter", Name: "Work", Query: "category contains Work AND not done", Index: 0},
+				{Id: 108105115116, Type: "list", Name: "Test List Name", Comment: "Test List Comment", Index: 0},
+			}))
+		})
+	})
+})
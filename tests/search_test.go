@@ -0,0 +1,156 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/NKTKLN/todo-api/models"
+	"github.com/NKTKLN/todo-api/pkg/common"
+	rd "github.com/NKTKLN/todo-api/pkg/db/redis"
+	"github.com/NKTKLN/todo-api/pkg/handlers"
+)
+
+var _ = Describe("Search", func() {
+	var (
+		r                       *gin.Engine
+		w                       *httptest.ResponseRecorder
+		accessJwt               string
+		handler                 handlers.Handler
+		postgresMock            sqlmock.Sqlmock
+		redisClientAccessToken  *redis.Client
+		redisClientRefreshToken *redis.Client
+		redisClientSession      *redis.Client
+		results                 models.ApiSearchResults
+	)
+
+	resultColumns := []string{"type", "id", "list_id", "task_id", "name", "snippet", "rank", "page_key"}
+
+	DescribeTable("Prefix search query",
+		func(text, query string) {
+			Expect(common.PrefixSearchQuery(text)).To(Equal(query))
+		},
+		Entry("empty text", "  ", ""),
+		Entry("one word", "Buy", "buy:*"),
+		Entry("several words", "buy  fresh milk", "buy:* & fresh:* & milk:*"),
+		Entry("tsquery operators", "milk | !bread & (eggs):*", "milk:* & bread:* & eggs:*"),
+	)
+
+	BeforeEach(func() {
+		gin.SetMode(gin.ReleaseMode)
+
+		r = gin.New()
+		w = httptest.NewRecorder()
+		results = models.ApiSearchResults{}
+
+		redisClientAccessToken = TestRedisConnection()
+		redisClientRefreshToken = TestRedisConnection()
+		redisClientSession = TestRedisConnection()
+
+		handler.RedisClient = &rd.RedisClients{
+			AccessTokenClient:  redisClientAccessToken,
+			RefreshTokenClient: redisClientRefreshToken,
+			SessionClient:      redisClientSession,
+		}
+
+		handler.PostgresDB, postgresMock = MockPostgresConnection()
+
+		// Creating new session with a jwt token
+		accessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
+
+		r.GET("/todo/search", handler.AuthMiddleware(), handler.Search)
+	})
+
+	AfterEach(func() {
+		redisClientAccessToken.Close()
+		redisClientRefreshToken.Close()
+		redisClientSession.Close()
+
+		Expect(postgresMock.ExpectationsWereMet()).ShouldNot(HaveOccurred())
+	})
+
+	Context("missing access token", func() {
+		BeforeEach(func() {
+			// Sending a query with data
+			req := httptest.NewRequest(http.MethodGet, "/todo/search?q=buy", nil)
+			r.ServeHTTP(w, req)
+		})
+
+		It("should return an error that the access token is missing", func() {
+			Expect(w.Code).To(Equal(http.StatusUnauthorized))
+			Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
+		})
+	})
+
+	Context("empty search query", func() {
+		BeforeEach(func() {
+			// Sending a query with data
+			req := httptest.NewRequest(http.MethodGet, "/todo/search?q=%26%21", nil)
+			req.Header.Set("token", accessJwt)
+			r.ServeHTTP(w, req)
+		})
+
+		It("should return an error that the search query is empty", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			Expect(w.Body.String()).To(Equal(`{"error":"Empty search query."}`))
+		})
+	})
+
+	Context("incorrect cursor", func() {
+		BeforeEach(func() {
+			// Sending a query with data
+			req := httptest.NewRequest(http.MethodGet, "/todo/search?q=buy&cursor=!", nil)
+			req.Header.Set("token", accessJwt)
+			r.ServeHTTP(w, req)
+		})
+
+		It("should return an error that the cursor is incorrect", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			Expect(w.Body.String()).To(Equal(`{"error":"Incorrect cursor."}`))
+		})
+	})
+
+	Context("ok", func() {
+		BeforeEach(func() {
+			options := models.SEARCH_HEADLINE_OPTIONS
+
+			// Query building for the postgres
+			postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSearch)).
+				WithArgs("buy:* & dri:*", 117115101114, 117115101114, options, options).
+				WillReturnRows(sqlmock.NewRows(resultColumns).
+					AddRow("task", 11697115107, 108105115116, 0, "Buy drinks", "<mark>Buy</mark> <mark>drinks</mark>", 0.1, `[0.1, "task", 11697115107]`).
+					AddRow("subtask", 1151179811697115107, 108105115116, 11697115107, "Buy drinks", "<mark>Buy</mark> <mark>drinks</mark>", 0.05, `[0.05, "subtask", 1151179811697115107]`))
+
+			// Sending a query with data
+			req := httptest.NewRequest(http.MethodGet, "/todo/search?q=Buy+dri&limit=1", nil)
+			req.Header.Set("token", accessJwt)
+			r.ServeHTTP(w, req)
+
+			// Converting the query body into a model
+			Expect(json.Unmarshal(w.Body.Bytes(), &results)).To(BeNil())
+		})
+
+		It("should return the most relevant result and the next page cursor", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(results).To(Equal(models.ApiSearchResults{
+				Results: []models.SearchResultData{{
+					Type:    "task",
+					Id:      11697115107,
+					ListId:  108105115116,
+					Name:    "Buy drinks",
+					Snippet: "<mark>Buy</mark> <mark>drinks</mark>",
+					Rank:    0.1,
+				}},
+				NextCursor: common.EncodeCursor([]interface{}{0.1, "task", 11697115107}),
+			}))
+		})
+	})
+})
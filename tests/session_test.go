@@ -0,0 +1,154 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/NKTKLN/todo-api/models"
+	rd "github.com/NKTKLN/todo-api/pkg/db/redis"
+	"github.com/NKTKLN/todo-api/pkg/handlers"
+)
+
+var _ = Describe("Sessions", func() {
+	var (
+		r                       *gin.Engine
+		w                       *httptest.ResponseRecorder
+		accessJwt               string
+		phoneAccessJwt          string
+		handler                 handlers.Handler
+		redisClientAccessToken  *redis.Client
+		redisClientRefreshToken *redis.Client
+		redisClientSession      *redis.Client
+	)
+
+	BeforeEach(func() {
+		gin.SetMode(gin.ReleaseMode)
+
+		r = gin.New()
+		w = httptest.NewRecorder()
+
+		redisClientAccessToken = TestRedisConnection()
+		redisClientRefreshToken = TestRedisConnection()
+		redisClientSession = TestRedisConnection()
+
+		handler.RedisClient = &rd.RedisClients{
+			AccessTokenClient:  redisClientAccessToken,
+			RefreshTokenClient: redisClientRefreshToken,
+			SessionClient:      redisClientSession,
+		}
+
+		// Creating sessions on two devices
+		accessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114, Device: "Laptop"})
+		phoneAccessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114, Device: "Phone"})
+	})
+
+	AfterEach(func() {
+		redisClientAccessToken.Close()
+		redisClientRefreshToken.Close()
+		redisClientSession.Close()
+	})
+
+	Describe("Show sessions", func() {
+		BeforeEach(func() {
+			r.GET("/user/sessions", handler.AuthMiddleware(), handler.ShowSessions)
+		})
+
+		Context("missing access token", func() {
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/user/sessions", nil)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the access token is missing", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
+			})
+		})
+
+		Context("ok", func() {
+			var sessions models.ApiShowSessions
+
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/user/sessions", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+
+				// Converting the query body into a model
+				Expect(json.Unmarshal(w.Body.Bytes(), &sessions)).To(BeNil())
+			})
+
+			It("should return all user sessions and mark the current one", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(sessions.Sessions).To(HaveLen(2))
+				Expect(sessions.Sessions[0].Device).To(Equal("Laptop"))
+				Expect(sessions.Sessions[0].Current).To(BeTrue())
+				Expect(sessions.Sessions[1].Device).To(Equal("Phone"))
+				Expect(sessions.Sessions[1].Current).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("Delete session", func() {
+		BeforeEach(func() {
+			r.DELETE("/user/sessions/:id", handler.AuthMiddleware(), handler.DeleteSession)
+		})
+
+		Context("missing access token", func() {
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/user/sessions/session", nil)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the access token is missing", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
+			})
+		})
+
+		Context("this session not found", func() {
+			BeforeEach(func() {
+				// Creating a session of another user
+				otherAccessJwt, _, _ := handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 1})
+				otherSession := handler.RedisClient.VerifySession(context.Background(), otherAccessJwt)
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/user/sessions/"+otherSession.Id, nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the session is not found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(Equal(`{"error":"This session not found."}`))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				phoneSession := handler.RedisClient.VerifySession(context.Background(), phoneAccessJwt)
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/user/sessions/"+phoneSession.Id, nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should revoke only the deleted session", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The session has been deleted."}`))
+				Expect(handler.RedisClient.VerifyToken(context.Background(), phoneAccessJwt)).To(Equal(0))
+				Expect(handler.RedisClient.VerifyToken(context.Background(), accessJwt)).To(Equal(117115101114))
+			})
+		})
+	})
+})
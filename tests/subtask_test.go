@@ -14,10 +14,8 @@ import (
 	"github.com/go-redis/redis/v8"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-	"github.com/spf13/viper"
 
 	"github.com/NKTKLN/todo-api/models"
-	"github.com/NKTKLN/todo-api/pkg/common"
 	rd "github.com/NKTKLN/todo-api/pkg/db/redis"
 	"github.com/NKTKLN/todo-api/pkg/handlers"
 )
@@ -31,6 +29,8 @@ var _ = Describe("Subtask", func() {
 		postgresMock            sqlmock.Sqlmock
 		redisClientAccessToken  *redis.Client
 		redisClientRefreshToken *redis.Client
+		redisClientSession      *redis.Client
+		redisClientReminder     *redis.Client
 	)
 
 	BeforeEach(func() {
@@ -41,31 +41,34 @@ var _ = Describe("Subtask", func() {
 
 		redisClientAccessToken = TestRedisConnection()
 		redisClientRefreshToken = TestRedisConnection()
+		redisClientSession = TestRedisConnection()
+		redisClientReminder = TestRedisConnection()
 
 		handler.RedisClient = &rd.RedisClients{
 			AccessTokenClient:  redisClientAccessToken,
 			RefreshTokenClient: redisClientRefreshToken,
+			SessionClient:      redisClientSession,
+			ReminderClient:     redisClientReminder,
 		}
 
 		handler.PostgresDB, postgresMock = MockPostgresConnection()
 
-		// Generate new jwt token
-		accessJwt, _ = common.NewJWT(117115101114, time.Minute, viper.GetString("api.jwt.access-secret"))
-
-		// Adding data to redis
-		redisClientAccessToken.Set(context.Background(), "117115101114", accessJwt, time.Minute)
+		// Creating new session with a jwt token
+		accessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
 	})
 
 	AfterEach(func() {
 		redisClientAccessToken.Close()
 		redisClientRefreshToken.Close()
+		redisClientSession.Close()
+		redisClientReminder.Close()
 
 		Expect(postgresMock.ExpectationsWereMet()).ShouldNot(HaveOccurred())
 	})
 
 	Describe("Add subtask", func() {
 		BeforeEach(func() {
-			r.POST("/todo/subtask/add", handler.AddSubtask)
+			r.POST("/todo/subtask/add", handler.AuthMiddleware(), handler.AddSubtask)
 		})
 
 		Context("data retrieval error", func() {
@@ -82,7 +85,7 @@ var _ = Describe("Subtask", func() {
 			})
 		})
 
-		Context("inactive user", func() {
+		Context("missing access token", func() {
 			const requestBody = `{"comment": "Test Subtask Comment", "task_id": 11697115107, "name": "Test Subtask Name"}`
 
 			BeforeEach(func() {
@@ -91,9 +94,9 @@ var _ = Describe("Subtask", func() {
 				r.ServeHTTP(w, req)
 			})
 
-			It("should return an error that the user is inactive", func() {
-				Expect(w.Code).To(Equal(http.StatusNotFound))
-				Expect(w.Body.String()).To(Equal(`{"error":"Inactive user."}`))
+			It("should return an error that the access token is missing", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
 			})
 		})
 
@@ -102,8 +105,13 @@ var _ = Describe("Subtask", func() {
 
 			BeforeEach(func() {
 				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+					WithArgs(11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(11697115107))
+
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
-					WithArgs(117115101114, 11697115107).
+					WithArgs(117115101114, 117115101114, 11697115107).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}))
 
 				// Sending a query with data
@@ -121,10 +129,20 @@ var _ = Describe("Subtask", func() {
 		Describe("Incorrect name", func() {
 			BeforeEach(func() {
 				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+					WithArgs(11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(11697115107))
+
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
-					WithArgs(117115101114, 11697115107).
+					WithArgs(117115101114, 117115101114, 11697115107).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).
 						AddRow(108105115116))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101114, "owner", 117115101114, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("owner"))
 			})
 
 			Context("empty name", func() {
@@ -165,11 +183,21 @@ var _ = Describe("Subtask", func() {
 
 			BeforeEach(func() {
 				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+					WithArgs(11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(11697115107))
+
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
-					WithArgs(117115101114, 11697115107).
+					WithArgs(117115101114, 117115101114, 11697115107).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).
 						AddRow(108105115116))
 
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101114, "owner", 117115101114, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("owner"))
+
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
 					WithArgs(AnyInt{}).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}))
@@ -184,7 +212,7 @@ var _ = Describe("Subtask", func() {
 
 					postgresMock.ExpectBegin()
 					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlInsertTaskData)).
-						WithArgs(0, 11697115107, "Test Subtask Name", "Test Subtask Comment", 0, nil, AnyTime{}, false, false, AnyInt{}).
+						WithArgs(0, 11697115107, "Test Subtask Name", "Test Subtask Comment", 0, nil, AnyTime{}, false, false, "none", "", 0, AnyTime{}, AnyInt{}).
 						WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
 					postgresMock.ExpectCommit()
 
@@ -215,7 +243,7 @@ var _ = Describe("Subtask", func() {
 
 					postgresMock.ExpectBegin()
 					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlInsertTaskData)).
-						WithArgs(0, 11697115107, "Test Subtask Name", "Test Subtask Comment", 1, nil, AnyTime{}, false, false, AnyInt{}).
+						WithArgs(0, 11697115107, "Test Subtask Name", "Test Subtask Comment", 1, nil, AnyTime{}, false, false, "none", "", 0, AnyTime{}, AnyInt{}).
 						WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
 					postgresMock.ExpectCommit()
 
@@ -235,7 +263,7 @@ var _ = Describe("Subtask", func() {
 
 	Describe("Delete task", func() {
 		BeforeEach(func() {
-			r.DELETE("/todo/subtask/delete", handler.DeleteSubtask)
+			r.DELETE("/todo/subtask/delete", handler.AuthMiddleware(), handler.DeleteSubtask)
 		})
 
 		Context("error when converting subtask_id", func() {
@@ -245,8 +273,12 @@ var _ = Describe("Subtask", func() {
 					WithArgs(0).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}))
 
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+					WithArgs(0).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
-					WithArgs(117115101114, 0).
+					WithArgs(117115101114, 117115101114, 0).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}))
 
 				// Sending a query with data
@@ -261,26 +293,16 @@ var _ = Describe("Subtask", func() {
 			})
 		})
 
-		Context("inactive user", func() {
+		Context("missing access token", func() {
 			BeforeEach(func() {
-				// Query building for the postgres
-				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskIdBySubtaskId)).
-					WithArgs(1151179811697115107).
-					WillReturnRows(sqlmock.NewRows([]string{"id"}).
-						AddRow(11697115107))
-
-				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
-					WithArgs(0, 11697115107).
-					WillReturnRows(sqlmock.NewRows([]string{"id"}))
-
 				// Sending a query with data
 				req := httptest.NewRequest(http.MethodDelete, `/todo/subtask/delete?subtask_id=1151179811697115107`, nil)
 				r.ServeHTTP(w, req)
 			})
 
-			It("should return an error that the user is inactive", func() {
-				Expect(w.Code).To(Equal(http.StatusNotFound))
-				Expect(w.Body.String()).To(Equal(`{"error":"Inactive user."}`))
+			It("should return an error that the access token is missing", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
 			})
 		})
 
@@ -291,8 +313,12 @@ var _ = Describe("Subtask", func() {
 					WithArgs(1151179811697115107).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}))
 
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+					WithArgs(0).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
-					WithArgs(117115101114, 0).
+					WithArgs(117115101114, 117115101114, 0).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}))
 
 				// Sending a query with data
@@ -315,14 +341,26 @@ var _ = Describe("Subtask", func() {
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).
 						AddRow(11697115107))
 
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+					WithArgs(11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(11697115107))
+
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
-					WithArgs(117115101114, 11697115107).
+					WithArgs(117115101114, 117115101114, 11697115107).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).
 						AddRow(108105115116))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101114, "owner", 117115101114, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("owner"))
 			})
 
 			Context("deleting a single subtask", func() {
 				BeforeEach(func() {
+					Expect(handler.RedisClient.ScheduleReminder(context.Background(), 114101109, time.Date(2077, time.December, 10, 13, 13, 0, 0, time.UTC))).To(BeNil())
+
 					// Query building for the postgres
 					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
 						WithArgs(1151179811697115107).
@@ -333,8 +371,22 @@ var _ = Describe("Subtask", func() {
 						WithArgs(11697115107, 0).
 						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}))
 
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskTreeReminders)).
+						WithArgs(1151179811697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "user_id", "before", "remind_at", "sent"}).
+							AddRow(114101109, 1151179811697115107, 117115101114, nil, time.Date(2077, time.December, 10, 13, 13, 0, 0, time.UTC), false))
+
 					postgresMock.ExpectBegin()
-					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTask)).
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskTreeReminders)).
+						WithArgs(1151179811697115107).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskTreeAssignees)).
+						WithArgs(1151179811697115107).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskTreeComments)).
+						WithArgs(1151179811697115107).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskTree)).
 						WithArgs(1151179811697115107).
 						WillReturnResult(sqlmock.NewResult(1, 1))
 					postgresMock.ExpectCommit()
@@ -348,6 +400,7 @@ var _ = Describe("Subtask", func() {
 				It("should return a message that the subtask was successfully deleted", func() {
 					Expect(w.Code).To(Equal(http.StatusOK))
 					Expect(w.Body.String()).To(Equal(`{"message":"The subtask has been deleted."}`))
+					Expect(redisClientReminder.ZCard(context.Background(), "reminders").Val()).To(BeZero())
 				})
 			})
 
@@ -370,8 +423,21 @@ var _ = Describe("Subtask", func() {
 						WillReturnResult(sqlmock.NewResult(1, 1))
 					postgresMock.ExpectCommit()
 
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskTreeReminders)).
+						WithArgs(1151179811697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "user_id", "before", "remind_at", "sent"}))
+
 					postgresMock.ExpectBegin()
-					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTask)).
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskTreeReminders)).
+						WithArgs(1151179811697115107).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskTreeAssignees)).
+						WithArgs(1151179811697115107).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskTreeComments)).
+						WithArgs(1151179811697115107).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskTree)).
 						WithArgs(1151179811697115107).
 						WillReturnResult(sqlmock.NewResult(1, 1))
 					postgresMock.ExpectCommit()
@@ -392,7 +458,7 @@ var _ = Describe("Subtask", func() {
 
 	Describe("Edit subtasks", func() {
 		BeforeEach(func() {
-			r.PATCH("/todo/subtask/edit", handler.EditSubtask)
+			r.PATCH("/todo/subtask/edit", handler.AuthMiddleware(), handler.EditSubtask)
 		})
 
 		Context("data retrieval error", func() {
@@ -409,26 +475,18 @@ var _ = Describe("Subtask", func() {
 			})
 		})
 
-		Context("inactive user", func() {
+		Context("missing access token", func() {
 			const requestBody = `{"end_time": "2077-12-10 13:13", "id": 1151179811697115107}`
 
 			BeforeEach(func() {
-				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskIdBySubtaskId)).
-					WithArgs(1151179811697115107).
-					WillReturnRows(sqlmock.NewRows([]string{"id"}))
-
-				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
-					WithArgs(0, 0).
-					WillReturnRows(sqlmock.NewRows([]string{"id"}))
-
 				// Sending a query with data
 				req := httptest.NewRequest(http.MethodPatch, "/todo/subtask/edit", bytes.NewBufferString(requestBody))
 				r.ServeHTTP(w, req)
 			})
 
-			It("should return a message that the user is inactive", func() {
-				Expect(w.Code).To(Equal(http.StatusNotFound))
-				Expect(w.Body.String()).To(Equal(`{"error":"Inactive user."}`))
+			It("should return an error that the access token is missing", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
 			})
 		})
 
@@ -437,12 +495,21 @@ var _ = Describe("Subtask", func() {
 
 			BeforeEach(func() {
 				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "timezone"}).
+						AddRow(117115101114, ""))
+
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskIdBySubtaskId)).
 					WithArgs(1151179811697115107).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}))
 
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+					WithArgs(0).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
-					WithArgs(117115101114, 0).
+					WithArgs(117115101114, 117115101114, 0).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}))
 
 				// Sending a query with data
@@ -460,15 +527,30 @@ var _ = Describe("Subtask", func() {
 		Describe("Incorrect data", func() {
 			BeforeEach(func() {
 				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "timezone"}).
+						AddRow(117115101114, ""))
+
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskIdBySubtaskId)).
 					WithArgs(1151179811697115107).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).
 						AddRow(11697115107))
 
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+					WithArgs(11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(11697115107))
+
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
-					WithArgs(117115101114, 11697115107).
+					WithArgs(117115101114, 117115101114, 11697115107).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).
 						AddRow(108105115116))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101114, "owner", 117115101114, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("owner"))
 			})
 
 			Describe("Incorrect time", func() {
@@ -539,6 +621,22 @@ var _ = Describe("Subtask", func() {
 				})
 			})
 
+			Context("incorrect priority", func() {
+				const requestBody = `{"name": "Test Subtask Name", "end_time": "2077-12-10 13:13", "id": 1151179811697115107, "priority": "critical"}`
+
+				BeforeEach(func() {
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodPatch, "/todo/subtask/edit", bytes.NewBufferString(requestBody))
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+				})
+
+				It("should return an error that the priority is incorrect", func() {
+					Expect(w.Code).To(Equal(http.StatusBadRequest))
+					Expect(w.Body.String()).To(Equal(`{"error":"Incorrect priority."}`))
+				})
+			})
+
 			Describe("Incorrect index", func() {
 				Context("index is greater than the maximum index", func() {
 					const requestBody = `{"name": "Test Subtask Name", "end_time": "2077-12-10 13:13", "id": 1151179811697115107, "index": 1}`
@@ -583,15 +681,30 @@ var _ = Describe("Subtask", func() {
 		Describe("Ok", func() {
 			BeforeEach(func() {
 				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "timezone"}).
+						AddRow(117115101114, ""))
+
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskIdBySubtaskId)).
 					WithArgs(1151179811697115107).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).
 						AddRow(11697115107))
 
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+					WithArgs(11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(11697115107))
+
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
-					WithArgs(117115101114, 11697115107).
+					WithArgs(117115101114, 117115101114, 11697115107).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).
 						AddRow(108105115116))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101114, "owner", 117115101114, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("owner"))
 			})
 
 			Context("update the subtask without changing the index", func() {
@@ -603,16 +716,66 @@ var _ = Describe("Subtask", func() {
 						WithArgs(11697115107).
 						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}))
 
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+						WithArgs(1151179811697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}).
+							AddRow(1151179811697115107, 0, 11697115107, "Test Subtask Name", "Test Subtask Comment", 0, nil, nil, false, false))
+
 					postgresMock.ExpectBegin()
 					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditTask)).
-						WithArgs("Test Subtask Name", "Test Subtask Comment", nil, AnyTime{}, false, false, 1151179811697115107).
+						WithArgs("Test Subtask Name", "Test Subtask Comment", nil, AnyTime{}, false, false, "none", 1151179811697115107).
 						WillReturnResult(sqlmock.NewResult(1, 1))
 					postgresMock.ExpectCommit()
 
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllRemindersByTaskId)).
+						WithArgs(1151179811697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "user_id", "before", "remind_at", "sent"}))
+
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodPatch, "/todo/subtask/edit", bytes.NewBufferString(requestBody))
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+				})
+
+				It("should return a message about successful update of the task data", func() {
+					Expect(w.Code).To(Equal(http.StatusOK))
+					Expect(w.Body.String()).To(Equal(`{"message":"Updating the task data was successful."}`))
+				})
+			})
+
+			Context("update the subtask with changing the end time", func() {
+				const requestBody = `{"name": "Test Subtask Name", "comment": "Test Subtask Comment", "end_time": "2077-12-10 13:13", "id": 1151179811697115107, "index": 0}`
+
+				endTime := time.Date(2077, time.December, 10, 13, 13, 0, 0, time.UTC)
+
+				BeforeEach(func() {
+					// Query building for the postgres
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectMaxSubtaskIndex)).
+						WithArgs(11697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"index"}))
+
 					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
 						WithArgs(1151179811697115107).
 						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}).
-							AddRow(1151179811697115107, 0, 11697115107, "Test Subtask Name", "Test Subtask Comment", 0, nil, nil, false, false))
+							AddRow(1151179811697115107, 0, 11697115107, "Test Subtask Name", "Test Subtask Comment", 0, nil, endTime.Add(-time.Hour), false, false))
+
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditTask)).
+						WithArgs("Test Subtask Name", "Test Subtask Comment", nil, endTime, false, false, "none", 1151179811697115107).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					postgresMock.ExpectCommit()
+
+					// The reminder relative to the end time moves together with it
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllRemindersByTaskId)).
+						WithArgs(1151179811697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "user_id", "before", "remind_at", "sent"}).
+							AddRow(114101109, 1151179811697115107, 117115101114, 30, endTime.Add(-90*time.Minute), false))
+
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditReminderTime)).
+						WithArgs(endTime.Add(-30*time.Minute), false, 114101109).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					postgresMock.ExpectCommit()
 
 					// Sending a query with data
 					req := httptest.NewRequest(http.MethodPatch, "/todo/subtask/edit", bytes.NewBufferString(requestBody))
@@ -620,9 +783,14 @@ var _ = Describe("Subtask", func() {
 					r.ServeHTTP(w, req)
 				})
 
-				It("should return a message about successful update of the task data", func() {
+				It("should move the reminder in the queue", func() {
 					Expect(w.Code).To(Equal(http.StatusOK))
 					Expect(w.Body.String()).To(Equal(`{"message":"Updating the task data was successful."}`))
+
+					queue, err := redisClientReminder.ZRangeWithScores(context.Background(), "reminders", 0, -1).Result()
+					Expect(err).To(BeNil())
+					Expect(queue).To(HaveLen(1))
+					Expect(queue[0].Score).To(Equal(float64(endTime.Add(-30 * time.Minute).Unix())))
 				})
 			})
 
@@ -636,16 +804,20 @@ var _ = Describe("Subtask", func() {
 						WillReturnRows(sqlmock.NewRows([]string{"index"}).
 							AddRow(1))
 
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+						WithArgs(1151179811697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}).
+							AddRow(1151179811697115107, 0, 11697115107, "Test Subtask Name", "Test Subtask Comment", 0, nil, nil, false, false))
+
 					postgresMock.ExpectBegin()
 					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditTask)).
-						WithArgs("Test Subtask Name", "Test Subtask Comment", nil, AnyTime{}, false, false, 1151179811697115107).
+						WithArgs("Test Subtask Name", "Test Subtask Comment", nil, AnyTime{}, false, false, "none", 1151179811697115107).
 						WillReturnResult(sqlmock.NewResult(1, 1))
 					postgresMock.ExpectCommit()
 
-					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllRemindersByTaskId)).
 						WithArgs(1151179811697115107).
-						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}).
-							AddRow(1151179811697115107, 0, 11697115107, "Test Subtask Name", "Test Subtask Comment", 0, nil, nil, false, false))
+						WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "user_id", "before", "remind_at", "sent"}))
 
 					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
 						WithArgs(1151179811697115107).
@@ -698,16 +870,20 @@ var _ = Describe("Subtask", func() {
 						WillReturnRows(sqlmock.NewRows([]string{"index"}).
 							AddRow(1))
 
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+						WithArgs(1151179811697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}).
+							AddRow(1151179811697115107, 0, 11697115107, "Test Subtask Name", "Test Subtask Comment", 1, nil, nil, false, false))
+
 					postgresMock.ExpectBegin()
 					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditTask)).
-						WithArgs("Test Subtask Name", "Test Subtask Comment", nil, AnyTime{}, false, false, 1151179811697115107).
+						WithArgs("Test Subtask Name", "Test Subtask Comment", nil, AnyTime{}, false, false, "none", 1151179811697115107).
 						WillReturnResult(sqlmock.NewResult(1, 1))
 					postgresMock.ExpectCommit()
 
-					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllRemindersByTaskId)).
 						WithArgs(1151179811697115107).
-						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}).
-							AddRow(1151179811697115107, 0, 11697115107, "Test Subtask Name", "Test Subtask Comment", 1, nil, nil, false, false))
+						WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "user_id", "before", "remind_at", "sent"}))
 
 					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
 						WithArgs(1151179811697115107).
@@ -749,12 +925,185 @@ var _ = Describe("Subtask", func() {
 					Expect(w.Body.String()).To(Equal(`{"message":"Updating the task data was successful."}`))
 				})
 			})
+
+			Context("completing the last subtask with the completion rollup", func() {
+				const requestBody = `{"name": "Test Subtask Name", "comment": "Test Subtask Comment", "end_time": "2077-12-10 13:13", "id": 1151179811697115107, "index": 0, "done": true}`
+
+				BeforeEach(func() {
+					// Query building for the postgres
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectMaxSubtaskIndex)).
+						WithArgs(11697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"index"}))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+						WithArgs(1151179811697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "done"}).
+							AddRow(1151179811697115107, 0, 11697115107, "Test Subtask Name", "Test Subtask Comment", 0, true))
+
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditTask)).
+						WithArgs("Test Subtask Name", "Test Subtask Comment", nil, AnyTime{}, true, false, "none", 1151179811697115107).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					postgresMock.ExpectCommit()
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllRemindersByTaskId)).
+						WithArgs(1151179811697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "user_id", "before", "remind_at", "sent"}))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+						WithArgs(117115101114).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "completion_rollup"}).
+							AddRow(117115101114, true))
+
+					// All subtasks of the parent task are done, so the parent task is completed too
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskIdBySubtaskId)).
+						WithArgs(1151179811697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id"}).
+							AddRow(11697115107))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUndoneSubtasksCount)).
+						WithArgs(11697115107, false).
+						WillReturnRows(sqlmock.NewRows([]string{"count"}).
+							AddRow(0))
+
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlCompleteTask)).
+						WithArgs(true, 11697115107, "").
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					postgresMock.ExpectCommit()
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskIdBySubtaskId)).
+						WithArgs(11697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id"}).
+							AddRow(0))
+
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodPatch, "/todo/subtask/edit", bytes.NewBufferString(requestBody))
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+				})
+
+				It("should return a message about successful update of the task data", func() {
+					Expect(w.Code).To(Equal(http.StatusOK))
+					Expect(w.Body.String()).To(Equal(`{"message":"Updating the task data was successful."}`))
+				})
+
+				It("should complete the parent task", func() {
+					Expect(postgresMock.ExpectationsWereMet()).To(BeNil())
+				})
+			})
+		})
+	})
+
+	Describe("Promote subtask", func() {
+		BeforeEach(func() {
+			r.PUT("/todo/subtask/promote", handler.AuthMiddleware(), handler.PromoteSubtask)
+		})
+
+		Context("this subtask not found", func() {
+			const requestBody = `{"id": 1151179811697115107, "list_id": 108105115116, "index": 0}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskIdBySubtaskId)).
+					WithArgs(1151179811697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"task_id"}))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+					WithArgs(0).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
+					WithArgs(117115101114, 117115101114, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPut, "/todo/subtask/promote", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the subtask was not found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(Equal(`{"error":"This subtask not found."}`))
+			})
+		})
+
+		Context("ok", func() {
+			const requestBody = `{"id": 1151179811697115107, "list_id": 108105115116, "index": 1}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskIdBySubtaskId)).
+					WithArgs(1151179811697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"task_id"}).
+						AddRow(11697115107))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+					WithArgs(11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(11697115107))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
+					WithArgs(117115101114, 117115101114, 11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(108105115116))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListByIdAndUserId)).
+					WithArgs(108105115116, 117115101114, 117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
+						AddRow(108105115116, 117115101114, "Test List Name", "Test List Comment", 0))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101114, "owner", 117115101114, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("owner"))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllTasksByListId)).
+					WithArgs(108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index"}).
+						AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 0))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+					WithArgs(1151179811697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index"}).
+						AddRow(1151179811697115107, 0, 11697115107, "Test Subtask Name", "Test Subtask Comment", 0))
+
+				// The other subtasks and the list tasks are reindexed together with the moving
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlCloseSubtaskGap)).
+					WithArgs(11697115107, 0).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlOpenTaskPlace)).
+					WithArgs(108105115116, 1).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlMoveTask)).
+					WithArgs(1, 108105115116, 0, 1151179811697115107).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteMovedTaskReminders)).
+					WithArgs(1151179811697115107, 1151179811697115107).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteMovedTaskAssignees)).
+					WithArgs(1151179811697115107, 1151179811697115107).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPut, "/todo/subtask/promote", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the subtask has become a task", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The subtask has become a task."}`))
+			})
 		})
 	})
 
 	Describe("Show tasks", func() {
 		BeforeEach(func() {
-			r.GET("/todo/subtask/show", handler.ShowSubtasks)
+			r.GET("/todo/subtask/show", handler.AuthMiddleware(), handler.ShowSubtasks)
 		})
 
 		Context("error when converting task_id", func() {
@@ -771,24 +1120,29 @@ var _ = Describe("Subtask", func() {
 			})
 		})
 
-		Context("inactive user", func() {
+		Context("missing access token", func() {
 			BeforeEach(func() {
 				// Sending a query with data
 				req := httptest.NewRequest(http.MethodGet, `/todo/subtask/show?task_id=11697115107`, nil)
 				r.ServeHTTP(w, req)
 			})
 
-			It("should return an error that the user is inactive", func() {
-				Expect(w.Code).To(Equal(http.StatusNotFound))
-				Expect(w.Body.String()).To(Equal(`{"error":"Inactive user."}`))
+			It("should return an error that the access token is missing", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
 			})
 		})
 
 		Context("this list not found", func() {
 			BeforeEach(func() {
 				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+					WithArgs(11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(11697115107))
+
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
-					WithArgs(117115101114, 11697115107).
+					WithArgs(117115101114, 117115101114, 11697115107).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}))
 
 				// Sending a query with data
@@ -808,10 +1162,21 @@ var _ = Describe("Subtask", func() {
 
 			BeforeEach(func() {
 				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+					WithArgs(11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(11697115107))
+
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
-					WithArgs(117115101114, 11697115107).
+					WithArgs(117115101114, 117115101114, 11697115107).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).
 						AddRow(108105115116))
+
+				// Subtasks without the end time keep the zero time in any timezone
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "timezone"}).
+						AddRow(117115101114, "Asia/Tokyo"))
 			})
 
 			Context("without tasks", func() {
@@ -844,6 +1209,9 @@ var _ = Describe("Subtask", func() {
 						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}).
 							AddRow(1151179811697115107, 0, 11697115107, "Test Subtask Name", "Test Subtask Comment", 0, nil, nil, false, false))
 
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskAssignees)).
+						WillReturnRows(sqlmock.NewRows([]string{"task_id", "id", "username"}))
+
 					// Sending a query with data
 					req := httptest.NewRequest(http.MethodGet, "/todo/subtask/show?task_id=11697115107", nil)
 					req.Header.Set("token", accessJwt)
@@ -858,6 +1226,33 @@ var _ = Describe("Subtask", func() {
 					Expect(subtasks.Subtasks).To(Equal([]models.SubtasksData{{Id: 1151179811697115107, Name: "Test Subtask Name", Comment: "Test Subtask Comment", Index: 0, EndTime: "0001-01-01 00:00"}}))
 				})
 			})
+
+			Context("only special subtasks", func() {
+				BeforeEach(func() {
+					// Query building for the postgres
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectSpecialSubtasks)).
+						WithArgs(11697115107, true).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}).
+							AddRow(1151179811697115107, 0, 11697115107, "Test Subtask Name", "Test Subtask Comment", 0, nil, nil, false, true))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskAssignees)).
+						WillReturnRows(sqlmock.NewRows([]string{"task_id", "id", "username"}))
+
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodGet, "/todo/subtask/show?task_id=11697115107&special=true", nil)
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+
+					// Converting the query body into a model
+					Expect(json.Unmarshal(w.Body.Bytes(), &subtasks)).To(BeNil())
+				})
+
+				It("should return special subtask data", func() {
+					Expect(w.Code).To(Equal(http.StatusOK))
+					Expect(subtasks.Subtasks).To(Equal([]models.SubtasksData{{Id: 1151179811697115107, Name: "Test Subtask Name", Comment: "Test Subtask Comment", Index: 0, EndTime: "0001-01-01 00:00", Special: true}}))
+					Expect(subtasks.NextCursor).To(BeEmpty())
+				})
+			})
 		})
 	})
 })
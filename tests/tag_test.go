@@ -0,0 +1,380 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/NKTKLN/todo-api/models"
+	rd "github.com/NKTKLN/todo-api/pkg/db/redis"
+	"github.com/NKTKLN/todo-api/pkg/handlers"
+)
+
+var _ = Describe("Tags", func() {
+	var (
+		r                       *gin.Engine
+		w                       *httptest.ResponseRecorder
+		accessJwt               string
+		handler                 handlers.Handler
+		postgresMock            sqlmock.Sqlmock
+		redisClientAccessToken  *redis.Client
+		redisClientRefreshToken *redis.Client
+		redisClientSession      *redis.Client
+	)
+
+	tagColumns := []string{"id", "user_id", "name", "color"}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.ReleaseMode)
+
+		r = gin.New()
+		w = httptest.NewRecorder()
+
+		redisClientAccessToken = TestRedisConnection()
+		redisClientRefreshToken = TestRedisConnection()
+		redisClientSession = TestRedisConnection()
+
+		handler.RedisClient = &rd.RedisClients{
+			AccessTokenClient:  redisClientAccessToken,
+			RefreshTokenClient: redisClientRefreshToken,
+			SessionClient:      redisClientSession,
+		}
+
+		handler.PostgresDB, postgresMock = MockPostgresConnection()
+
+		// Creating new session with a jwt token
+		accessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
+	})
+
+	AfterEach(func() {
+		redisClientAccessToken.Close()
+		redisClientRefreshToken.Close()
+		redisClientSession.Close()
+
+		Expect(postgresMock.ExpectationsWereMet()).ShouldNot(HaveOccurred())
+	})
+
+	Describe("Show tags", func() {
+		BeforeEach(func() {
+			r.GET("/todo/tags", handler.AuthMiddleware(), handler.ShowTags)
+		})
+
+		Context("ok", func() {
+			var tags models.ApiShowTags
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTagsByUserId)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows(tagColumns).
+						AddRow(11697103, 117115101114, "Shopping", "#ff8800"))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/tags", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+
+				// Converting the query body into a model
+				Expect(json.Unmarshal(w.Body.Bytes(), &tags)).To(BeNil())
+			})
+
+			It("should return the user tags", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(tags.Tags).To(Equal([]models.TagsData{{Id: 11697103, Name: "Shopping", Color: "#ff8800"}}))
+			})
+		})
+	})
+
+	Describe("Add tag", func() {
+		BeforeEach(func() {
+			r.POST("/todo/tags", handler.AuthMiddleware(), handler.AddTag)
+		})
+
+		Context("incorrect color", func() {
+			const requestBody = `{"name": "Shopping", "color": "orange"}`
+
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/tags", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the color is incorrect", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Incorrect color."}`))
+			})
+		})
+
+		Context("this tag already exists", func() {
+			const requestBody = `{"name": "Shopping", "color": "#FF8800"}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTagByNameAndUserId)).
+					WithArgs("Shopping", 117115101114).
+					WillReturnRows(sqlmock.NewRows(tagColumns).
+						AddRow(11697103, 117115101114, "Shopping", "#ff8800"))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/tags", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the tag already exists", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"This tag already exists."}`))
+			})
+		})
+
+		Context("ok", func() {
+			const requestBody = `{"name": "Shopping"}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTagByNameAndUserId)).
+					WithArgs("Shopping", 117115101114).
+					WillReturnRows(sqlmock.NewRows(tagColumns))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTagsByUserId)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows(tagColumns))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTagById)).
+					WithArgs(AnyInt{}).
+					WillReturnRows(sqlmock.NewRows(tagColumns))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlInsertTagData)).
+					WithArgs(117115101114, "Shopping", models.DEFAULT_TAG_COLOR, AnyInt{}).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/tags", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the tag was added", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"Tag added to db."}`))
+			})
+		})
+	})
+
+	Describe("Edit tag", func() {
+		BeforeEach(func() {
+			r.PATCH("/todo/tags", handler.AuthMiddleware(), handler.EditTag)
+		})
+
+		Context("this tag not found", func() {
+			const requestBody = `{"id": 11697103, "name": "Groceries"}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTagByIdAndUserId)).
+					WithArgs(11697103, 117115101114).
+					WillReturnRows(sqlmock.NewRows(tagColumns))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPatch, "/todo/tags", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the tag was not found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(Equal(`{"error":"This tag not found."}`))
+			})
+		})
+
+		Context("changing only the color", func() {
+			const requestBody = `{"id": 11697103, "color": "#00AA55"}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTagByIdAndUserId)).
+					WithArgs(11697103, 117115101114).
+					WillReturnRows(sqlmock.NewRows(tagColumns).
+						AddRow(11697103, 117115101114, "Shopping", "#ff8800"))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditTag)).
+					WithArgs("Shopping", "#00aa55", 11697103).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPatch, "/todo/tags", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the tag was updated", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"Updating the tag data was successful."}`))
+			})
+		})
+
+		Context("renaming", func() {
+			const requestBody = `{"id": 11697103, "name": "Groceries"}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTagByIdAndUserId)).
+					WithArgs(11697103, 117115101114).
+					WillReturnRows(sqlmock.NewRows(tagColumns).
+						AddRow(11697103, 117115101114, "Shopping", "#ff8800"))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTagByNameAndUserId)).
+					WithArgs("Groceries", 117115101114).
+					WillReturnRows(sqlmock.NewRows(tagColumns))
+
+				// The tag and the task categories are renamed together, the lists where the user is only a viewer are not
+				// touched
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditTag)).
+					WithArgs("Groceries", "#ff8800", 11697103).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlRenameTaskCategory)).
+					WithArgs("Groceries", "Shopping", "Groceries", "Shopping", 117115101114, 117115101114, "editor", "admin", "owner").
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPatch, "/todo/tags", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the tag was updated", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"Updating the tag data was successful."}`))
+			})
+		})
+	})
+
+	Describe("Delete tag", func() {
+		BeforeEach(func() {
+			r.DELETE("/todo/tags", handler.AuthMiddleware(), handler.DeleteTag)
+		})
+
+		Context("error when converting tag_id", func() {
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/todo/tags?tag_id=tag", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error when converting tag_id", func() {
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+				Expect(w.Body.String()).To(Equal(`{"error":"Error when converting tag_id."}`))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTagByIdAndUserId)).
+					WithArgs(11697103, 117115101114).
+					WillReturnRows(sqlmock.NewRows(tagColumns).
+						AddRow(11697103, 117115101114, "Shopping", "#ff8800"))
+
+				// The tag is removed from the task categories together with the deletion, the lists where the user is only
+				// a viewer are not touched
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTag)).
+					WithArgs(11697103).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlRemoveTaskCategory)).
+					WithArgs("Shopping", "Shopping", 117115101114, 117115101114, "editor", "admin", "owner").
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/todo/tags?tag_id=11697103", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the tag was deleted", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The tag has been deleted."}`))
+			})
+		})
+
+		Context("tag used only in a list shared as a viewer", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTagByIdAndUserId)).
+					WithArgs(11697103, 117115101114).
+					WillReturnRows(sqlmock.NewRows(tagColumns).
+						AddRow(11697103, 117115101114, "Shopping", "#ff8800"))
+
+				// Only the lists where the user is an editor or higher are searched, so no task of the viewer list is
+				// updated
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTag)).
+					WithArgs(11697103).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlRemoveTaskCategory)).
+					WithArgs("Shopping", "Shopping", 117115101114, 117115101114, "editor", "admin", "owner").
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/todo/tags?tag_id=11697103", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should delete the tag without changing the tasks of the viewer list", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The tag has been deleted."}`))
+			})
+		})
+	})
+
+	Describe("Autocomplete tags", func() {
+		BeforeEach(func() {
+			r.GET("/todo/tags/autocomplete", handler.AuthMiddleware(), handler.AutocompleteTags)
+		})
+
+		Context("ok", func() {
+			var tags models.ApiTagsAutocomplete
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTagsUsageByUserId)).
+					WithArgs(117115101114, 117115101114, 117115101114, `sh\_%`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "color", "count"}).
+						AddRow(11697103, "sh_op", "#ff8800", 12))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/tags/autocomplete?q=sh_", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+
+				// Converting the query body into a model
+				Expect(json.Unmarshal(w.Body.Bytes(), &tags)).To(BeNil())
+			})
+
+			It("should return the tags with their usage counts", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(tags.Tags).To(Equal([]models.TagUsageData{{Id: 11697103, Name: "sh_op", Color: "#ff8800", Count: 12}}))
+			})
+		})
+	})
+})
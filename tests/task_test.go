@@ -12,9 +12,9 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
+	"github.com/lib/pq"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-	"github.com/spf13/viper"
 
 	"github.com/NKTKLN/todo-api/models"
 	"github.com/NKTKLN/todo-api/pkg/common"
@@ -31,6 +31,8 @@ var _ = Describe("Task", func() {
 		postgresMock            sqlmock.Sqlmock
 		redisClientAccessToken  *redis.Client
 		redisClientRefreshToken *redis.Client
+		redisClientSession      *redis.Client
+		redisClientReminder     *redis.Client
 	)
 
 	BeforeEach(func() {
@@ -41,31 +43,34 @@ var _ = Describe("Task", func() {
 
 		redisClientAccessToken = TestRedisConnection()
 		redisClientRefreshToken = TestRedisConnection()
+		redisClientSession = TestRedisConnection()
+		redisClientReminder = TestRedisConnection()
 
 		handler.RedisClient = &rd.RedisClients{
 			AccessTokenClient:  redisClientAccessToken,
 			RefreshTokenClient: redisClientRefreshToken,
+			SessionClient:      redisClientSession,
+			ReminderClient:     redisClientReminder,
 		}
 
 		handler.PostgresDB, postgresMock = MockPostgresConnection()
 
-		// Generate new jwt token
-		accessJwt, _ = common.NewJWT(117115101114, time.Minute, viper.GetString("api.jwt.access-secret"))
-
-		// Adding data to redis
-		redisClientAccessToken.Set(context.Background(), "117115101114", accessJwt, time.Minute)
+		// Creating new session with a jwt token
+		accessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
 	})
 
 	AfterEach(func() {
 		redisClientAccessToken.Close()
 		redisClientRefreshToken.Close()
+		redisClientSession.Close()
+		redisClientReminder.Close()
 
 		Expect(postgresMock.ExpectationsWereMet()).ShouldNot(HaveOccurred())
 	})
 
 	Describe("Add task", func() {
 		BeforeEach(func() {
-			r.POST("/todo/task/add", handler.AddTask)
+			r.POST("/todo/task/add", handler.AuthMiddleware(), handler.AddTask)
 		})
 
 		Context("data retrieval error", func() {
@@ -82,7 +87,7 @@ var _ = Describe("Task", func() {
 			})
 		})
 
-		Context("inactive user", func() {
+		Context("missing access token", func() {
 			const requestBody = `{"comment": "Test Task Comment", "list_id": 108105115116, "name": "Test Task Name"}`
 
 			BeforeEach(func() {
@@ -91,9 +96,9 @@ var _ = Describe("Task", func() {
 				r.ServeHTTP(w, req)
 			})
 
-			It("should return an error that the user is inactive", func() {
-				Expect(w.Code).To(Equal(http.StatusNotFound))
-				Expect(w.Body.String()).To(Equal(`{"error":"Inactive user."}`))
+			It("should return an error that the access token is missing", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
 			})
 		})
 
@@ -103,7 +108,7 @@ var _ = Describe("Task", func() {
 			BeforeEach(func() {
 				// Query building for the postgres
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListByIdAndUserId)).
-					WithArgs(108105115116, 117115101114).
+					WithArgs(108105115116, 117115101114, 117115101114).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}))
 
 				// Sending a query with data
@@ -122,9 +127,14 @@ var _ = Describe("Task", func() {
 			BeforeEach(func() {
 				// Query building for the postgres
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListByIdAndUserId)).
-					WithArgs(108105115116, 117115101114).
+					WithArgs(108105115116, 117115101114, 117115101114).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
 						AddRow(108105115116, 117115101114, "Test List Name", "Test List Comment", 0))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101114, "owner", 117115101114, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("owner"))
 			})
 
 			Context("empty name", func() {
@@ -166,10 +176,15 @@ var _ = Describe("Task", func() {
 			BeforeEach(func() {
 				// Query building for the postgres
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListByIdAndUserId)).
-					WithArgs(108105115116, 117115101114).
+					WithArgs(108105115116, 117115101114, 117115101114).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
 						AddRow(108105115116, 117115101114, "Test List Name", "Test List Comment", 0))
 
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101114, "owner", 117115101114, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("owner"))
+
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
 					WithArgs(AnyInt{}).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}))
@@ -184,7 +199,7 @@ var _ = Describe("Task", func() {
 
 					postgresMock.ExpectBegin()
 					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlInsertTaskData)).
-						WithArgs(108105115116, 0, "Test Task Name", "Test Task Comment", 0, nil, AnyTime{}, false, false, AnyInt{}).
+						WithArgs(108105115116, 0, "Test Task Name", "Test Task Comment", 0, nil, AnyTime{}, false, false, "none", "", 0, AnyTime{}, AnyInt{}).
 						WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
 					postgresMock.ExpectCommit()
 
@@ -215,7 +230,7 @@ var _ = Describe("Task", func() {
 
 					postgresMock.ExpectBegin()
 					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlInsertTaskData)).
-						WithArgs(108105115116, 0, "Test Task Name", "Test Task Comment", 1, nil, AnyTime{}, false, false, AnyInt{}).
+						WithArgs(108105115116, 0, "Test Task Name", "Test Task Comment", 1, nil, AnyTime{}, false, false, "none", "", 0, AnyTime{}, AnyInt{}).
 						WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
 					postgresMock.ExpectCommit()
 
@@ -235,14 +250,14 @@ var _ = Describe("Task", func() {
 
 	Describe("Delete task", func() {
 		BeforeEach(func() {
-			r.DELETE("/todo/task/delete", handler.DeleteTask)
+			r.DELETE("/todo/task/delete", handler.AuthMiddleware(), handler.DeleteTask)
 		})
 
 		Context("error when converting task_id", func() {
 			BeforeEach(func() {
 				// Query building for the postgres
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
-					WithArgs(117115101114, 0).
+					WithArgs(117115101114, 117115101114, 0).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}))
 
 				// Sending a query with data
@@ -257,21 +272,16 @@ var _ = Describe("Task", func() {
 			})
 		})
 
-		Context("inactive user", func() {
+		Context("missing access token", func() {
 			BeforeEach(func() {
-				// Query building for the postgres
-				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
-					WithArgs(0, 11697115107).
-					WillReturnRows(sqlmock.NewRows([]string{"id"}))
-
 				// Sending a query with data
 				req := httptest.NewRequest(http.MethodDelete, `/todo/task/delete?task_id=11697115107`, nil)
 				r.ServeHTTP(w, req)
 			})
 
-			It("should return an error that the user is inactive", func() {
-				Expect(w.Code).To(Equal(http.StatusNotFound))
-				Expect(w.Body.String()).To(Equal(`{"error":"Inactive user."}`))
+			It("should return an error that the access token is missing", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
 			})
 		})
 
@@ -279,7 +289,7 @@ var _ = Describe("Task", func() {
 			BeforeEach(func() {
 				// Query building for the postgres
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
-					WithArgs(117115101114, 11697115107).
+					WithArgs(117115101114, 117115101114, 11697115107).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}))
 
 				// Sending a query with data
@@ -298,9 +308,14 @@ var _ = Describe("Task", func() {
 			BeforeEach(func() {
 				// Query building for the postgres
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
-					WithArgs(117115101114, 11697115107).
+					WithArgs(117115101114, 117115101114, 11697115107).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).
 						AddRow(108105115116))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101114, "owner", 117115101114, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("owner"))
 			})
 
 			Context("deleting a single task without subtasks", func() {
@@ -315,10 +330,30 @@ var _ = Describe("Task", func() {
 						WithArgs(108105115116, 0).
 						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}))
 
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskTreeReminders)).
+						WithArgs(11697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "user_id", "before", "remind_at", "sent"}))
+
 					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllSubtasksByTaskId)).
 						WithArgs(11697115107).
 						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}))
 
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskReminders)).
+						WithArgs(11697115107).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					postgresMock.ExpectCommit()
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskAssignees)).
+						WithArgs(11697115107).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					postgresMock.ExpectCommit()
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskComments)).
+						WithArgs(11697115107).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					postgresMock.ExpectCommit()
+
 					postgresMock.ExpectBegin()
 					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTask)).
 						WithArgs(11697115107).
@@ -356,10 +391,30 @@ var _ = Describe("Task", func() {
 						WillReturnResult(sqlmock.NewResult(1, 1))
 					postgresMock.ExpectCommit()
 
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskTreeReminders)).
+						WithArgs(11697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "user_id", "before", "remind_at", "sent"}))
+
 					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllSubtasksByTaskId)).
 						WithArgs(11697115107).
 						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}))
 
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskReminders)).
+						WithArgs(11697115107).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					postgresMock.ExpectCommit()
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskAssignees)).
+						WithArgs(11697115107).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					postgresMock.ExpectCommit()
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskComments)).
+						WithArgs(11697115107).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					postgresMock.ExpectCommit()
+
 					postgresMock.ExpectBegin()
 					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTask)).
 						WithArgs(11697115107).
@@ -380,6 +435,8 @@ var _ = Describe("Task", func() {
 
 			Context("deleting a single list with tasks and subtasks", func() {
 				BeforeEach(func() {
+					Expect(handler.RedisClient.ScheduleReminder(context.Background(), 114101109, time.Date(2077, time.December, 10, 13, 13, 0, 0, time.UTC))).To(BeNil())
+
 					// Query building for the postgres
 					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
 						WithArgs(11697115107).
@@ -390,17 +447,47 @@ var _ = Describe("Task", func() {
 						WithArgs(108105115116, 0).
 						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}))
 
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskTreeReminders)).
+						WithArgs(11697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "user_id", "before", "remind_at", "sent"}).
+							AddRow(114101109, 1151179811697115107, 117115101114, nil, time.Date(2077, time.December, 10, 13, 13, 0, 0, time.UTC), false))
+
 					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllSubtasksByTaskId)).
 						WithArgs(11697115107).
 						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}).
 							AddRow(1151179811697115107, 0, 11697115107, "Test Task Name", "Test Task Comment", 0, nil, nil, false, false))
 
 					postgresMock.ExpectBegin()
-					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTask)).
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskTreeReminders)).
+						WithArgs(1151179811697115107).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskTreeAssignees)).
+						WithArgs(1151179811697115107).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskTreeComments)).
+						WithArgs(1151179811697115107).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskTree)).
 						WithArgs(1151179811697115107).
 						WillReturnResult(sqlmock.NewResult(1, 1))
 					postgresMock.ExpectCommit()
 
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskReminders)).
+						WithArgs(11697115107).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					postgresMock.ExpectCommit()
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskAssignees)).
+						WithArgs(11697115107).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					postgresMock.ExpectCommit()
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskComments)).
+						WithArgs(11697115107).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					postgresMock.ExpectCommit()
+
 					postgresMock.ExpectBegin()
 					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTask)).
 						WithArgs(11697115107).
@@ -416,6 +503,7 @@ var _ = Describe("Task", func() {
 				It("should return a message that the task was successfully deleted", func() {
 					Expect(w.Code).To(Equal(http.StatusOK))
 					Expect(w.Body.String()).To(Equal(`{"message":"The task has been deleted."}`))
+					Expect(redisClientReminder.ZCard(context.Background(), "reminders").Val()).To(BeZero())
 				})
 			})
 		})
@@ -423,7 +511,7 @@ var _ = Describe("Task", func() {
 
 	Describe("Edit tasks", func() {
 		BeforeEach(func() {
-			r.PATCH("/todo/task/edit", handler.EditTask)
+			r.PATCH("/todo/task/edit", handler.AuthMiddleware(), handler.EditTask)
 		})
 
 		Context("data retrieval error", func() {
@@ -440,23 +528,18 @@ var _ = Describe("Task", func() {
 			})
 		})
 
-		Context("inactive user", func() {
+		Context("missing access token", func() {
 			const requestBody = `{"end_time": "2077-12-10 13:13", "id": 11697115107}`
 
 			BeforeEach(func() {
-				// Query building for the postgres
-				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
-					WithArgs(0, 11697115107).
-					WillReturnRows(sqlmock.NewRows([]string{"id"}))
-
 				// Sending a query with data
 				req := httptest.NewRequest(http.MethodPatch, "/todo/task/edit", bytes.NewBufferString(requestBody))
 				r.ServeHTTP(w, req)
 			})
 
-			It("should return a message that the user is inactive", func() {
-				Expect(w.Code).To(Equal(http.StatusNotFound))
-				Expect(w.Body.String()).To(Equal(`{"error":"Inactive user."}`))
+			It("should return an error that the access token is missing", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
 			})
 		})
 
@@ -465,8 +548,13 @@ var _ = Describe("Task", func() {
 
 			BeforeEach(func() {
 				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "timezone"}).
+						AddRow(117115101114, ""))
+
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
-					WithArgs(117115101114, 11697115107).
+					WithArgs(117115101114, 117115101114, 11697115107).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}))
 
 				// Sending a query with data
@@ -484,10 +572,20 @@ var _ = Describe("Task", func() {
 		Describe("Icorrect data", func() {
 			BeforeEach(func() {
 				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "timezone"}).
+						AddRow(117115101114, ""))
+
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
-					WithArgs(117115101114, 11697115107).
+					WithArgs(117115101114, 117115101114, 11697115107).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).
 						AddRow(108105115116))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101114, "owner", 117115101114, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("owner"))
 			})
 
 			Describe("Incorrect time", func() {
@@ -558,6 +656,38 @@ var _ = Describe("Task", func() {
 				})
 			})
 
+			Context("incorrect priority", func() {
+				const requestBody = `{"name": "Test Task Name", "end_time": "2077-12-10 13:13", "id": 11697115107, "priority": "critical"}`
+
+				BeforeEach(func() {
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodPatch, `/todo/task/edit`, bytes.NewBufferString(requestBody))
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+				})
+
+				It("should return an error that the priority is incorrect", func() {
+					Expect(w.Code).To(Equal(http.StatusBadRequest))
+					Expect(w.Body.String()).To(Equal(`{"error":"Incorrect priority."}`))
+				})
+			})
+
+			Context("incorrect recurrence rule", func() {
+				const requestBody = `{"name": "Test Task Name", "end_time": "2077-12-10 13:13", "id": 11697115107, "recurrence": "FREQ=YEARLY"}`
+
+				BeforeEach(func() {
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodPatch, `/todo/task/edit`, bytes.NewBufferString(requestBody))
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+				})
+
+				It("should return an error that the recurrence rule is incorrect", func() {
+					Expect(w.Code).To(Equal(http.StatusBadRequest))
+					Expect(w.Body.String()).To(Equal(`{"error":"Incorrect recurrence rule."}`))
+				})
+			})
+
 			Describe("Incorrect index", func() {
 				Context("index is greater than the maximum index", func() {
 					const requestBody = `{"name": "Test Task Name", "end_time": "2077-12-10 13:13", "id": 11697115107, "index": 1}`
@@ -600,12 +730,25 @@ var _ = Describe("Task", func() {
 		})
 
 		Describe("Ok", func() {
+			// The end time is taken in the user timezone
+			tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
 			BeforeEach(func() {
 				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "timezone"}).
+						AddRow(117115101114, "Asia/Tokyo"))
+
 				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
-					WithArgs(117115101114, 11697115107).
+					WithArgs(117115101114, 117115101114, 11697115107).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).
 						AddRow(108105115116))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101114, "owner", 117115101114, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("owner"))
 			})
 
 			Context("update the task without changing the index", func() {
@@ -617,16 +760,20 @@ var _ = Describe("Task", func() {
 						WithArgs(108105115116).
 						WillReturnRows(sqlmock.NewRows([]string{"index"}))
 
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+						WithArgs(11697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}).
+							AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 0, nil, nil, false, false))
+
 					postgresMock.ExpectBegin()
 					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditTask)).
-						WithArgs("Test Task Name", "Test Task Comment", nil, AnyTime{}, false, false, 11697115107).
+						WithArgs("Test Task Name", "Test Task Comment", nil, time.Date(2077, time.December, 10, 13, 13, 0, 0, tokyo), false, false, "none", 11697115107).
 						WillReturnResult(sqlmock.NewResult(1, 1))
 					postgresMock.ExpectCommit()
 
-					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllRemindersByTaskId)).
 						WithArgs(11697115107).
-						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}).
-							AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 0, nil, nil, false, false))
+						WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "user_id", "before", "remind_at", "sent"}))
 
 					// Sending a query with data
 					req := httptest.NewRequest(http.MethodPatch, `/todo/task/edit`, bytes.NewBufferString(requestBody))
@@ -650,16 +797,20 @@ var _ = Describe("Task", func() {
 						WillReturnRows(sqlmock.NewRows([]string{"index"}).
 							AddRow(1))
 
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+						WithArgs(11697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}).
+							AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 0, nil, nil, false, false))
+
 					postgresMock.ExpectBegin()
 					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditTask)).
-						WithArgs("Test Task Name", "Test Task Comment", nil, AnyTime{}, false, false, 11697115107).
+						WithArgs("Test Task Name", "Test Task Comment", nil, AnyTime{}, false, false, "none", 11697115107).
 						WillReturnResult(sqlmock.NewResult(1, 1))
 					postgresMock.ExpectCommit()
 
-					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllRemindersByTaskId)).
 						WithArgs(11697115107).
-						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}).
-							AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 0, nil, nil, false, false))
+						WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "user_id", "before", "remind_at", "sent"}))
 
 					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
 						WithArgs(11697115107).
@@ -712,16 +863,20 @@ var _ = Describe("Task", func() {
 						WillReturnRows(sqlmock.NewRows([]string{"index"}).
 							AddRow(1))
 
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+						WithArgs(11697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}).
+							AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 1, nil, nil, false, false))
+
 					postgresMock.ExpectBegin()
 					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditTask)).
-						WithArgs("Test Task Name", "Test Task Comment", nil, AnyTime{}, false, false, 11697115107).
+						WithArgs("Test Task Name", "Test Task Comment", nil, AnyTime{}, false, false, "none", 11697115107).
 						WillReturnResult(sqlmock.NewResult(1, 1))
 					postgresMock.ExpectCommit()
 
-					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllRemindersByTaskId)).
 						WithArgs(11697115107).
-						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}).
-							AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 1, nil, nil, false, false))
+						WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "user_id", "before", "remind_at", "sent"}))
 
 					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
 						WithArgs(11697115107).
@@ -763,113 +918,964 @@ var _ = Describe("Task", func() {
 					Expect(w.Body.String()).To(Equal(`{"message":"Updating the task data was successful."}`))
 				})
 			})
+
+			Context("complete the recurring task", func() {
+				const requestBody = `{"name": "Test Task Name", "comment": "Test Task Comment", "end_time": "2077-12-10 13:13", "id": 11697115107, "index": 0, "done": true, "recurrence": "FREQ=WEEKLY"}`
+
+				BeforeEach(func() {
+					// Query building for the postgres
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectMaxTaskIndex)).
+						WithArgs(108105115116).
+						WillReturnRows(sqlmock.NewRows([]string{"index"}).
+							AddRow(0))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+						WithArgs(11697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special", "recurrence", "occurrence"}).
+							AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 0, nil, nil, false, false, "FREQ=WEEKLY", 0))
+
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditTask)).
+						WithArgs("Test Task Name", "Test Task Comment", nil, AnyTime{}, true, false, "none", 11697115107).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					postgresMock.ExpectCommit()
+
+					// Creating the next occurrence
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+						WithArgs(AnyInt{}).
+						WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllTasksByListId)).
+						WithArgs(108105115116).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}).
+							AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 0, nil, nil, true, false))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectMaxTaskIndex)).
+						WithArgs(108105115116).
+						WillReturnRows(sqlmock.NewRows([]string{"index"}).
+							AddRow(0))
+
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlInsertTaskData)).
+						WithArgs(108105115116, 0, "Test Task Name", "Test Task Comment", 1, nil, time.Date(2077, time.December, 17, 13, 13, 0, 0, tokyo), false, false, "none", "FREQ=WEEKLY", 1, AnyTime{}, AnyInt{}).
+						WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
+					postgresMock.ExpectCommit()
+
+					// Copying all levels of subtasks
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectSubtreeByTaskId)).
+						WithArgs(11697115107, 11697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special", "priority"}).
+							AddRow(1151179811697115107, 0, 11697115107, "Test Subtask Name", "Test Subtask Comment", 0, nil, nil, true, false, "high").
+							AddRow(1151179811697115108, 0, 1151179811697115107, "Test Nested Subtask Name", "Test Nested Subtask Comment", 0, nil, nil, false, false, "none"))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+						WithArgs(AnyInt{}).
+						WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlInsertTaskData)).
+						WithArgs(0, AnyInt{}, "Test Subtask Name", "Test Subtask Comment", 0, nil, AnyTime{}, false, false, "high", "", 0, AnyTime{}, AnyInt{}).
+						WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
+					postgresMock.ExpectCommit()
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+						WithArgs(AnyInt{}).
+						WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlInsertTaskData)).
+						WithArgs(0, AnyInt{}, "Test Nested Subtask Name", "Test Nested Subtask Comment", 0, nil, AnyTime{}, false, false, "none", "", 0, AnyTime{}, AnyInt{}).
+						WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
+					postgresMock.ExpectCommit()
+
+					// The completed occurrence leaves the series
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditTaskRecurrence)).
+						WithArgs(AnyTime{}, "", 0, 11697115107).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					postgresMock.ExpectCommit()
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllRemindersByTaskId)).
+						WithArgs(11697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "user_id", "before", "remind_at", "sent"}))
+
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodPatch, `/todo/task/edit`, bytes.NewBufferString(requestBody))
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+				})
+
+				It("should return a message about successful update of the task data", func() {
+					Expect(w.Code).To(Equal(http.StatusOK))
+					Expect(w.Body.String()).To(Equal(`{"message":"Updating the task data was successful."}`))
+				})
+			})
 		})
 	})
 
-	Describe("Show tasks", func() {
+	Describe("Skip task occurrence", func() {
 		BeforeEach(func() {
-			r.GET("/todo/task/show", handler.ShowTasks)
+			r.PUT("/todo/task/skip", handler.AuthMiddleware(), handler.SkipTaskOccurrence)
 		})
 
-		Context("error when converting list_id", func() {
+		Context("error when converting task_id", func() {
 			BeforeEach(func() {
 				// Sending a query with data
-				req := httptest.NewRequest(http.MethodGet, `/todo/task/show?list_id="108105115116"`, nil)
+				req := httptest.NewRequest(http.MethodPut, `/todo/task/skip?task_id="11697115107"`, nil)
 				req.Header.Set("token", accessJwt)
 				r.ServeHTTP(w, req)
 			})
 
-			It("should return an error message when converting the list_id", func() {
+			It("should return an error message when converting the task_id", func() {
 				Expect(w.Code).To(Equal(http.StatusInternalServerError))
-				Expect(w.Body.String()).To(Equal(`{"error":"Error when converting list_id."}`))
-			})
-		})
-
-		Context("inactive user", func() {
-			BeforeEach(func() {
-				// Sending a query with data
-				req := httptest.NewRequest(http.MethodGet, `/todo/task/show?list_id=108105115116`, nil)
-				r.ServeHTTP(w, req)
-			})
-
-			It("should return an error that the user is inactive", func() {
-				Expect(w.Code).To(Equal(http.StatusNotFound))
-				Expect(w.Body.String()).To(Equal(`{"error":"Inactive user."}`))
+				Expect(w.Body.String()).To(Equal(`{"error":"Error when converting task_id."}`))
 			})
 		})
 
-		Context("this list not found", func() {
+		Context("this task not found", func() {
 			BeforeEach(func() {
 				// Query building for the postgres
-				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListByIdAndUserId)).
-					WithArgs(108105115116, 117115101114).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}))
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
+					WithArgs(117115101114, 117115101114, 11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
 
 				// Sending a query with data
-				req := httptest.NewRequest(http.MethodGet, "/todo/task/show?list_id=108105115116", nil)
+				req := httptest.NewRequest(http.MethodPut, "/todo/task/skip?task_id=11697115107", nil)
 				req.Header.Set("token", accessJwt)
 				r.ServeHTTP(w, req)
 			})
 
-			It("should return a message that the list is not found", func() {
+			It("should return a message that the task is not found", func() {
 				Expect(w.Code).To(Equal(http.StatusNotFound))
-				Expect(w.Body.String()).To(Equal(`{"error":"This list not found."}`))
+				Expect(w.Body.String()).To(Equal(`{"error":"This task not found."}`))
 			})
 		})
 
-		Describe("Ok", func() {
-			var tasks models.ApiShowTasks
-
+		Describe("Task found", func() {
 			BeforeEach(func() {
 				// Query building for the postgres
-				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListByIdAndUserId)).
-					WithArgs(108105115116, 117115101114).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
-						AddRow(108105115116, 117115101114, "Test List Name", "Test List Comment", 0))
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
+					WithArgs(117115101114, 117115101114, 11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(108105115116))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101114, "owner", 117115101114, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("owner"))
 			})
 
-			Context("without tasks", func() {
+			Context("task is not recurring", func() {
 				BeforeEach(func() {
 					// Query building for the postgres
-					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllTasksByListId)).
-						WithArgs(108105115116).
-						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}))
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+						WithArgs(11697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special", "recurrence", "occurrence"}).
+							AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 0, nil, nil, false, false, "", 0))
 
 					// Sending a query with data
-					req := httptest.NewRequest(http.MethodGet, "/todo/task/show?list_id=108105115116", nil)
+					req := httptest.NewRequest(http.MethodPut, "/todo/task/skip?task_id=11697115107", nil)
 					req.Header.Set("token", accessJwt)
 					r.ServeHTTP(w, req)
-
-					// Converting the query body into a model
-					Expect(json.Unmarshal(w.Body.Bytes(), &tasks)).To(BeNil())
 				})
 
-				It("should return empty task data", func() {
-					Expect(w.Code).To(Equal(http.StatusOK))
-					Expect(tasks.Tasks).To(BeNil())
+				It("should return an error that the task is not recurring", func() {
+					Expect(w.Code).To(Equal(http.StatusBadRequest))
+					Expect(w.Body.String()).To(Equal(`{"error":"This task is not recurring."}`))
 				})
 			})
 
-			Context("with tasks", func() {
+			Context("last occurrence", func() {
 				BeforeEach(func() {
 					// Query building for the postgres
-					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllTasksByListId)).
-						WithArgs(108105115116).
-						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}).
-							AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 0, nil, nil, false, false))
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+						WithArgs(11697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special", "recurrence", "occurrence"}).
+							AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 0, nil, nil, false, false, "FREQ=DAILY;COUNT=2", 1))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+						WithArgs(117115101114).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "timezone"}).
+							AddRow(117115101114, "UTC"))
 
 					// Sending a query with data
-					req := httptest.NewRequest(http.MethodGet, "/todo/task/show?list_id=108105115116", nil)
+					req := httptest.NewRequest(http.MethodPut, "/todo/task/skip?task_id=11697115107", nil)
 					req.Header.Set("token", accessJwt)
 					r.ServeHTTP(w, req)
+				})
 
-					// Converting the query body into a model
-					Expect(json.Unmarshal(w.Body.Bytes(), &tasks)).To(BeNil())
+				It("should return an error that this is the last occurrence", func() {
+					Expect(w.Code).To(Equal(http.StatusBadRequest))
+					Expect(w.Body.String()).To(Equal(`{"error":"This is the last occurrence of the task."}`))
 				})
+			})
 
-				It("should return task data", func() {
-					Expect(w.Code).To(Equal(http.StatusOK))
-					Expect(tasks.Tasks).To(Equal([]models.TasksData{{Id: 11697115107, Name: "Test Task Name", Comment: "Test Task Comment", Index: 0, EndTime: "0001-01-01 00:00"}}))
+			Context("ok", func() {
+				BeforeEach(func() {
+					// Query building for the postgres
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+						WithArgs(11697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special", "recurrence", "occurrence"}).
+							AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 0, nil, time.Date(2077, time.December, 6, 13, 13, 0, 0, time.UTC), false, false, "FREQ=WEEKLY;BYDAY=MO,TH", 0))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+						WithArgs(117115101114).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "timezone"}).
+							AddRow(117115101114, "UTC"))
+
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditTaskRecurrence)).
+						WithArgs(time.Date(2077, time.December, 9, 13, 13, 0, 0, time.UTC), "FREQ=WEEKLY;BYDAY=MO,TH", 1, 11697115107).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					postgresMock.ExpectCommit()
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllRemindersByTaskId)).
+						WithArgs(11697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "user_id", "before", "remind_at", "sent"}))
+
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodPut, "/todo/task/skip?task_id=11697115107", nil)
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+				})
+
+				It("should return a message that the occurrence has been skipped", func() {
+					Expect(w.Code).To(Equal(http.StatusOK))
+					Expect(w.Body.String()).To(Equal(`{"message":"The occurrence has been skipped."}`))
+				})
+			})
+
+			Context("user in another timezone", func() {
+				// The due time is on wednesday in UTC but already on thursday in the user timezone
+				tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+				BeforeEach(func() {
+					// Query building for the postgres
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+						WithArgs(11697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special", "recurrence", "occurrence"}).
+							AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 0, nil, time.Date(2077, time.December, 8, 16, 0, 0, 0, time.UTC), false, false, "FREQ=WEEKLY;BYDAY=MO,TH", 0))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+						WithArgs(117115101114).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "timezone"}).
+							AddRow(117115101114, "Asia/Tokyo"))
+
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditTaskRecurrence)).
+						WithArgs(time.Date(2077, time.December, 13, 1, 0, 0, 0, tokyo), "FREQ=WEEKLY;BYDAY=MO,TH", 1, 11697115107).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					postgresMock.ExpectCommit()
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllRemindersByTaskId)).
+						WithArgs(11697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "user_id", "before", "remind_at", "sent"}))
+
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodPut, "/todo/task/skip?task_id=11697115107", nil)
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+				})
+
+				It("should move the task to the next day of the rule in the user timezone", func() {
+					Expect(w.Code).To(Equal(http.StatusOK))
+					Expect(w.Body.String()).To(Equal(`{"message":"The occurrence has been skipped."}`))
+				})
+			})
+		})
+	})
+
+	Describe("End task series", func() {
+		BeforeEach(func() {
+			r.PUT("/todo/task/end-series", handler.AuthMiddleware(), handler.EndTaskSeries)
+
+			// Query building for the postgres
+			postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
+				WithArgs(117115101114, 117115101114, 11697115107).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).
+					AddRow(108105115116))
+
+			postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+				WithArgs(117115101114, "owner", 117115101114, 108105115116).
+				WillReturnRows(sqlmock.NewRows([]string{"role"}).
+					AddRow("owner"))
+		})
+
+		Context("task is not recurring", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+					WithArgs(11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special", "recurrence", "occurrence"}).
+						AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 0, nil, nil, false, false, "", 0))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPut, "/todo/task/end-series?task_id=11697115107", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the task is not recurring", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"This task is not recurring."}`))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+					WithArgs(11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special", "recurrence", "occurrence"}).
+						AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 0, nil, nil, false, false, "FREQ=DAILY", 3))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditTaskRecurrence)).
+					WithArgs(AnyTime{}, "", 3, 11697115107).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPut, "/todo/task/end-series?task_id=11697115107", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the series has been ended", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The series has been ended."}`))
+			})
+		})
+	})
+
+	Describe("Move task", func() {
+		BeforeEach(func() {
+			r.PUT("/todo/task/move", handler.AuthMiddleware(), handler.MoveTask)
+
+			// Query building for the postgres
+			postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
+				WithArgs(117115101114, 117115101114, 11697115107).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).
+					AddRow(108105115116))
+		})
+
+		Context("this list not found", func() {
+			const requestBody = `{"id": 11697115107, "list_id": 108105115117, "index": 0}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListByIdAndUserId)).
+					WithArgs(108105115117, 117115101114, 117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPut, "/todo/task/move", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the list was not found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(Equal(`{"error":"This list not found."}`))
+			})
+		})
+
+		Context("viewer of the target list", func() {
+			const requestBody = `{"id": 11697115107, "list_id": 108105115117, "index": 0}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListByIdAndUserId)).
+					WithArgs(108105115117, 117115101114, 117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
+						AddRow(108105115117, 97108105115101, "Shared List Name", "Shared List Comment", 0))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101114, "owner", 117115101114, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("owner"))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101114, "owner", 117115101114, 108105115117).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("viewer"))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPut, "/todo/task/move", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the user has not enough rights", func() {
+				Expect(w.Code).To(Equal(http.StatusForbidden))
+				Expect(w.Body.String()).To(Equal(`{"error":"Not enough rights."}`))
+			})
+		})
+
+		Describe("List found", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListByIdAndUserId)).
+					WithArgs(108105115117, 117115101114, 117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
+						AddRow(108105115117, 117115101114, "Test List Name", "Test List Comment", 1))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101114, "owner", 117115101114, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("owner"))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101114, "owner", 117115101114, 108105115117).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("owner"))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllTasksByListId)).
+					WithArgs(108105115117).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index"}).
+						AddRow(11697115108, 108105115117, 0, "Test Task Name", "Test Task Comment", 0))
+			})
+
+			Context("incorrect index", func() {
+				const requestBody = `{"id": 11697115107, "list_id": 108105115117, "index": 2}`
+
+				BeforeEach(func() {
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodPut, "/todo/task/move", bytes.NewBufferString(requestBody))
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+				})
+
+				It("should return an error that the index is incorrect", func() {
+					Expect(w.Code).To(Equal(http.StatusBadRequest))
+					Expect(w.Body.String()).To(Equal(`{"error":"Incorrect index."}`))
+				})
+			})
+
+			Context("ok", func() {
+				const requestBody = `{"id": 11697115107, "list_id": 108105115117, "index": 1}`
+
+				BeforeEach(func() {
+					// Query building for the postgres
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+						WithArgs(11697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index"}).
+							AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 0))
+
+					// Both lists are reindexed together with the moving, users without access to the new list lose their reminders and assignments
+					postgresMock.ExpectBegin()
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlCloseTaskGap)).
+						WithArgs(108105115116, 0).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlOpenTaskPlace)).
+						WithArgs(108105115117, 1).
+						WillReturnResult(sqlmock.NewResult(1, 0))
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlMoveTask)).
+						WithArgs(1, 108105115117, 0, 11697115107).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteMovedTaskReminders)).
+						WithArgs(11697115107, 11697115107).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteMovedTaskAssignees)).
+						WithArgs(11697115107, 11697115107).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					postgresMock.ExpectCommit()
+
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodPut, "/todo/task/move", bytes.NewBufferString(requestBody))
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+				})
+
+				It("should return a message that the task has been moved", func() {
+					Expect(w.Code).To(Equal(http.StatusOK))
+					Expect(w.Body.String()).To(Equal(`{"message":"The task has been moved."}`))
+				})
+			})
+		})
+	})
+
+	Describe("Demote task", func() {
+		BeforeEach(func() {
+			r.PUT("/todo/task/demote", handler.AuthMiddleware(), handler.DemoteTask)
+		})
+
+		Context("task is a subtask of its own subtask", func() {
+			const requestBody = `{"id": 11697115107, "task_id": 1151179811697115107, "index": 0}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+					WithArgs(1151179811697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(11697115107))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
+					WithArgs(117115101114, 117115101114, 11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(108105115116))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
+					WithArgs(117115101114, 117115101114, 11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(108105115116))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101114, "owner", 117115101114, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("owner"))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPut, "/todo/task/demote", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the task can't be a subtask of itself", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"A task can't be a subtask of itself or its subtasks."}`))
+			})
+		})
+
+		Context("ok", func() {
+			const requestBody = `{"id": 11697115107, "task_id": 1151179811697115108, "index": 0}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectRootTaskId)).
+					WithArgs(1151179811697115108).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(11697115108))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
+					WithArgs(117115101114, 117115101114, 11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(108105115116))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListIdWhereTask)).
+					WithArgs(117115101114, 117115101114, 11697115108).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).
+						AddRow(108105115116))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListRole)).
+					WithArgs(117115101114, "owner", 117115101114, 108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).
+						AddRow("editor"))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllSubtasksByTaskId)).
+					WithArgs(1151179811697115108).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index"}))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskById)).
+					WithArgs(11697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "recurrence"}).
+						AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 2, "FREQ=DAILY"))
+
+				// The task becomes a subtask of the subtask, its recurrence ends because subtasks don't recur
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlCloseTaskGap)).
+					WithArgs(108105115116, 2).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlOpenSubtaskPlace)).
+					WithArgs(1151179811697115108, 0).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlMoveTaskToSubtasks)).
+					WithArgs(0, 0, 0, "", 1151179811697115108, 11697115107).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteMovedTaskReminders)).
+					WithArgs(11697115107, 11697115107).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteMovedTaskAssignees)).
+					WithArgs(11697115107, 11697115107).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPut, "/todo/task/demote", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the task has become a subtask", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The task has become a subtask."}`))
+			})
+		})
+	})
+
+	Describe("Show task tree", func() {
+		BeforeEach(func() {
+			r.GET("/todo/task/tree", handler.AuthMiddleware(), handler.ShowTaskTree)
+		})
+
+		Context("this list not found", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListByIdAndUserId)).
+					WithArgs(108105115116, 117115101114, 117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/task/tree?list_id=108105115116", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the list was not found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(Equal(`{"error":"This list not found."}`))
+			})
+		})
+
+		Context("ok", func() {
+			var tree models.ApiShowTaskTree
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListByIdAndUserId)).
+					WithArgs(108105115116, 117115101114, 117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
+						AddRow(108105115116, 117115101114, "Test List Name", "Test List Comment", 0))
+
+				// Tasks of all levels come sorted by the index
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskTree)).
+					WithArgs(108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "end_time"}).
+						AddRow(11697115107, 108105115116, 0, "Task", "", 0, time.Time{}).
+						AddRow(1151179811697115108, 0, 11697115107, "Subtask 2", "", 0, time.Time{}).
+						AddRow(1151179811697115107, 0, 1151179811697115108, "Nested subtask", "", 0, time.Date(2077, time.December, 10, 13, 13, 0, 0, time.UTC)).
+						AddRow(11697115108, 108105115116, 0, "Task 2", "", 1, time.Time{}).
+						AddRow(1151179811697115109, 0, 11697115107, "Subtask 1", "", 1, time.Time{}))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskAssignees)).
+					WillReturnRows(sqlmock.NewRows([]string{"task_id", "id", "username"}))
+
+				// The end times of all levels are shown in the user timezone
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "timezone"}).
+						AddRow(117115101114, "Asia/Tokyo"))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/task/tree?list_id=108105115116", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+
+				// Converting the query body into a model
+				Expect(json.Unmarshal(w.Body.Bytes(), &tree)).To(BeNil())
+			})
+
+			It("should return the nested tasks", func() {
+				endTime := "0001-01-01 00:00"
+
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(tree.Tasks).To(Equal([]models.TaskTreeData{
+					{
+						TasksData: models.TasksData{Id: 11697115107, Name: "Task", EndTime: endTime},
+						Subtasks: []models.TaskTreeData{
+							{
+								TasksData: models.TasksData{Id: 1151179811697115108, Name: "Subtask 2", EndTime: endTime},
+								Subtasks:  []models.TaskTreeData{{TasksData: models.TasksData{Id: 1151179811697115107, Name: "Nested subtask", EndTime: "2077-12-10 22:13"}}},
+							},
+							{TasksData: models.TasksData{Id: 1151179811697115109, Name: "Subtask 1", Index: 1, EndTime: endTime}},
+						},
+					},
+					{TasksData: models.TasksData{Id: 11697115108, Name: "Task 2", Index: 1, EndTime: endTime}},
+				}))
+			})
+		})
+	})
+
+	Describe("Show tasks", func() {
+		BeforeEach(func() {
+			r.GET("/todo/task/show", handler.AuthMiddleware(), handler.ShowTasks)
+		})
+
+		Context("error when converting list_id", func() {
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, `/todo/task/show?list_id="108105115116"`, nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error message when converting the list_id", func() {
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+				Expect(w.Body.String()).To(Equal(`{"error":"Error when converting list_id."}`))
+			})
+		})
+
+		Context("missing access token", func() {
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, `/todo/task/show?list_id=108105115116`, nil)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the access token is missing", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
+			})
+		})
+
+		Context("this list not found", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListByIdAndUserId)).
+					WithArgs(108105115116, 117115101114, 117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/task/show?list_id=108105115116", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the list is not found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(Equal(`{"error":"This list not found."}`))
+			})
+		})
+
+		Context("incorrect sort", func() {
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/task/show?list_id=108105115116&sort=random", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the sort is incorrect", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Incorrect sort."}`))
+			})
+		})
+
+		Context("incorrect order", func() {
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/task/show?list_id=108105115116&sort=name&order=up", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the order is incorrect", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Incorrect order."}`))
+			})
+		})
+
+		Describe("Ok", func() {
+			var tasks models.ApiShowTasks
+
+			// The end times are taken and shown in the user timezone
+			tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+			BeforeEach(func() {
+				tasks = models.ApiShowTasks{}
+
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListByIdAndUserId)).
+					WithArgs(108105115116, 117115101114, 117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
+						AddRow(108105115116, 117115101114, "Test List Name", "Test List Comment", 0))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "timezone"}).
+						AddRow(117115101114, "Asia/Tokyo"))
+			})
+
+			Context("without tasks", func() {
+				BeforeEach(func() {
+					// Query building for the postgres
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllTasksByListId)).
+						WithArgs(108105115116).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}))
+
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodGet, "/todo/task/show?list_id=108105115116", nil)
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+
+					// Converting the query body into a model
+					Expect(json.Unmarshal(w.Body.Bytes(), &tasks)).To(BeNil())
+				})
+
+				It("should return empty task data", func() {
+					Expect(w.Code).To(Equal(http.StatusOK))
+					Expect(tasks.Tasks).To(BeNil())
+				})
+			})
+
+			Context("with tasks", func() {
+				BeforeEach(func() {
+					// Query building for the postgres
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllTasksByListId)).
+						WithArgs(108105115116).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}).
+							AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 0, nil, nil, false, false))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskAssignees)).
+						WithArgs(11697115107).
+						WillReturnRows(sqlmock.NewRows([]string{"task_id", "id", "username"}).
+							AddRow(11697115107, 117115101115, "test"))
+
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodGet, "/todo/task/show?list_id=108105115116", nil)
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+
+					// Converting the query body into a model
+					Expect(json.Unmarshal(w.Body.Bytes(), &tasks)).To(BeNil())
+				})
+
+				It("should return task data", func() {
+					Expect(w.Code).To(Equal(http.StatusOK))
+					Expect(tasks.Tasks).To(Equal([]models.TasksData{{Id: 11697115107, Name: "Test Task Name", Comment: "Test Task Comment", Index: 0, EndTime: "0001-01-01 00:00", Assignees: []models.AssigneeData{{Id: 117115101115, Username: "test"}}}}))
+				})
+			})
+
+			Context("sorted by priority", func() {
+				BeforeEach(func() {
+					// Query building for the postgres
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllTasksByPriority)).
+						WithArgs(108105115116).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special", "priority"}).
+							AddRow(11697115108, 108105115116, 0, "Urgent Task Name", "Test Task Comment", 1, nil, nil, false, false, "urgent").
+							AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 0, nil, nil, false, false, "low"))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskAssignees)).
+						WillReturnRows(sqlmock.NewRows([]string{"task_id", "id", "username"}))
+
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodGet, "/todo/task/show?list_id=108105115116&sort=priority", nil)
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+
+					// Converting the query body into a model
+					Expect(json.Unmarshal(w.Body.Bytes(), &tasks)).To(BeNil())
+				})
+
+				It("should return task data sorted by priority", func() {
+					Expect(w.Code).To(Equal(http.StatusOK))
+					Expect(tasks.Tasks).To(Equal([]models.TasksData{
+						{Id: 11697115108, Name: "Urgent Task Name", Comment: "Test Task Comment", Index: 1, EndTime: "0001-01-01 00:00", Priority: "urgent"},
+						{Id: 11697115107, Name: "Test Task Name", Comment: "Test Task Comment", Index: 0, EndTime: "0001-01-01 00:00", Priority: "low"},
+					}))
+				})
+			})
+
+			Context("sorted by due time", func() {
+				BeforeEach(func() {
+					// Query building for the postgres
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllTasksByDueTime)).
+						WithArgs(108105115116).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}))
+
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodGet, "/todo/task/show?list_id=108105115116&sort=due&order=desc", nil)
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+				})
+
+				It("should return task data sorted by due time", func() {
+					Expect(w.Code).To(Equal(http.StatusOK))
+					Expect(postgresMock.ExpectationsWereMet()).To(BeNil())
+				})
+			})
+
+			Context("filtered with the next page", func() {
+				BeforeEach(func() {
+					// Query building for the postgres
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectFilteredTasks)).
+						WithArgs(108105115116, true, "Party", "%drinks%", "%drinks%").
+						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special", "page_key"}).
+							AddRow(11697115107, 108105115116, 0, "Buy drinks", "Test Task Comment", 0, pq.StringArray{"Party"}, nil, true, false, "[0, 11697115107]").
+							AddRow(11697115108, 108105115116, 0, "Buy more drinks", "Test Task Comment", 1, pq.StringArray{"Party"}, nil, true, false, "[1, 11697115108]"))
+
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskAssignees)).
+						WillReturnRows(sqlmock.NewRows([]string{"task_id", "id", "username"}))
+
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodGet, "/todo/task/show?list_id=108105115116&done=true&category=Party&q=drinks&limit=1", nil)
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+
+					// Converting the query body into a model
+					Expect(json.Unmarshal(w.Body.Bytes(), &tasks)).To(BeNil())
+				})
+
+				It("should return the first page of the filtered task data", func() {
+					Expect(w.Code).To(Equal(http.StatusOK))
+					Expect(tasks.Tasks).To(Equal([]models.TasksData{{Id: 11697115107, Name: "Buy drinks", Comment: "Test Task Comment", Index: 0, Categories: pq.StringArray{"Party"}, EndTime: "0001-01-01 00:00", Done: true}}))
+					Expect(tasks.NextCursor).To(Equal(common.EncodeCursor([]interface{}{0, 11697115107})))
+				})
+			})
+
+			Context("due in the range on the next page", func() {
+				BeforeEach(func() {
+					// Query building for the postgres
+					postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTasksByDueRange)).
+						WithArgs(108105115116, time.Date(2077, time.December, 11, 0, 0, 0, 0, tokyo), time.Time{}, time.Date(2077, time.December, 10, 0, 0, 0, 0, tokyo), "0", "11697115107").
+						WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}))
+
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodGet, "/todo/task/show?list_id=108105115116&due_after=2077-12-10+00:00&due_before=2077-12-11+00:00&cursor="+common.EncodeCursor([]interface{}{0, 11697115107}), nil)
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+
+					// Converting the query body into a model
+					Expect(json.Unmarshal(w.Body.Bytes(), &tasks)).To(BeNil())
+				})
+
+				It("should return the last page of the task data", func() {
+					Expect(w.Code).To(Equal(http.StatusOK))
+					Expect(tasks.Tasks).To(BeNil())
+					Expect(tasks.NextCursor).To(BeEmpty())
+				})
+			})
+		})
+
+		Describe("Incorrect filter", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectListByIdAndUserId)).
+					WithArgs(108105115116, 117115101114, 117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "comment", "index"}).
+						AddRow(108105115116, 117115101114, "Test List Name", "Test List Comment", 0))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "timezone"}).
+						AddRow(117115101114, ""))
+			})
+
+			Context("incorrect done", func() {
+				BeforeEach(func() {
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodGet, "/todo/task/show?list_id=108105115116&done=maybe", nil)
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+				})
+
+				It("should return an error that the done filter is incorrect", func() {
+					Expect(w.Code).To(Equal(http.StatusBadRequest))
+					Expect(w.Body.String()).To(Equal(`{"error":"Incorrect done."}`))
+				})
+			})
+
+			Context("incorrect due time format", func() {
+				BeforeEach(func() {
+					// Sending a query with data
+					req := httptest.NewRequest(http.MethodGet, "/todo/task/show?list_id=108105115116&due_before=tomorrow", nil)
+					req.Header.Set("token", accessJwt)
+					r.ServeHTTP(w, req)
+				})
+
+				It("should return a message that the time format is incorrect", func() {
+					Expect(w.Code).To(Equal(http.StatusBadRequest))
+					Expect(w.Body.String()).To(Equal(`{"error":"Incorrect time format."}`))
 				})
 			})
 		})
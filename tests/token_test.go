@@ -0,0 +1,152 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/NKTKLN/todo-api/models"
+	rd "github.com/NKTKLN/todo-api/pkg/db/redis"
+	"github.com/NKTKLN/todo-api/pkg/handlers"
+)
+
+var _ = Describe("Tokens", func() {
+	var (
+		r                       *gin.Engine
+		handler                 handlers.Handler
+		accessJwt               string
+		refreshJwt              string
+		redisClientAccessToken  *redis.Client
+		redisClientRefreshToken *redis.Client
+		redisClientSession      *redis.Client
+	)
+
+	refreshTokens := func(refreshToken string) (*httptest.ResponseRecorder, models.UserTokens) {
+		var tokens models.UserTokens
+		w := httptest.NewRecorder()
+
+		// Sending a query with data
+		req := httptest.NewRequest(http.MethodPut, "/user/settings/update/token", nil)
+		req.Header.Set("refresh_token", refreshToken)
+		r.ServeHTTP(w, req)
+
+		// Converting the query body into a model
+		json.Unmarshal(w.Body.Bytes(), &tokens)
+		return w, tokens
+	}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.ReleaseMode)
+
+		r = gin.New()
+
+		redisClientAccessToken = TestRedisConnection()
+		redisClientRefreshToken = TestRedisConnection()
+		redisClientSession = TestRedisConnection()
+
+		handler.RedisClient = &rd.RedisClients{
+			AccessTokenClient:  redisClientAccessToken,
+			RefreshTokenClient: redisClientRefreshToken,
+			SessionClient:      redisClientSession,
+		}
+
+		// Creating new session with a pair of jwt tokens
+		accessJwt, refreshJwt, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
+
+		r.PUT("/user/settings/update/token", handler.UpdateUserToken)
+	})
+
+	AfterEach(func() {
+		redisClientAccessToken.Close()
+		redisClientRefreshToken.Close()
+		redisClientSession.Close()
+	})
+
+	Describe("Refresh token rotation", func() {
+		Context("refreshing with the current refresh token", func() {
+			var (
+				w      *httptest.ResponseRecorder
+				tokens models.UserTokens
+			)
+
+			BeforeEach(func() {
+				// Making the user sessions index close to expiring
+				redisClientSession.Expire(context.Background(), "user:117115101114", time.Minute)
+
+				w, tokens = refreshTokens(refreshJwt)
+			})
+
+			It("should return a new pair of tokens", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(tokens.AccessToken).NotTo(Equal(accessJwt))
+				Expect(tokens.RefreshToken).NotTo(Equal(refreshJwt))
+				Expect(handler.RedisClient.VerifyToken(context.Background(), tokens.AccessToken)).To(Equal(117115101114))
+			})
+
+			It("should invalidate the old access token", func() {
+				Expect(handler.RedisClient.VerifyToken(context.Background(), accessJwt)).To(Equal(0))
+			})
+
+			It("should renew the life of the user sessions index", func() {
+				Expect(redisClientSession.TTL(context.Background(), "user:117115101114").Val()).To(BeNumerically(">", time.Minute))
+			})
+
+			It("should allow refreshing with the new refresh token", func() {
+				w, newTokens := refreshTokens(tokens.RefreshToken)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(handler.RedisClient.VerifyToken(context.Background(), newTokens.AccessToken)).To(Equal(117115101114))
+			})
+		})
+
+		Context("reusing an already rotated refresh token", func() {
+			var (
+				w      *httptest.ResponseRecorder
+				tokens models.UserTokens
+			)
+
+			BeforeEach(func() {
+				_, tokens = refreshTokens(refreshJwt)
+				w, _ = refreshTokens(refreshJwt)
+			})
+
+			It("should return an error that the user is inactive", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(Equal(`{"error":"Inactive user."}`))
+			})
+
+			It("should revoke the whole token family", func() {
+				Expect(handler.RedisClient.VerifyToken(context.Background(), tokens.AccessToken)).To(Equal(0))
+
+				w, _ := refreshTokens(tokens.RefreshToken)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+
+				sessions, err := handler.RedisClient.GetUserSessions(context.Background(), 117115101114)
+				Expect(err).To(BeNil())
+				Expect(sessions).To(BeEmpty())
+			})
+		})
+
+		Context("reusing a refresh token of another session", func() {
+			var otherAccessJwt string
+
+			BeforeEach(func() {
+				// Creating a session on another device
+				otherAccessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
+
+				refreshTokens(refreshJwt)
+				refreshTokens(refreshJwt)
+			})
+
+			It("should keep other sessions of the user active", func() {
+				Expect(handler.RedisClient.VerifyToken(context.Background(), otherAccessJwt)).To(Equal(117115101114))
+			})
+		})
+	})
+})
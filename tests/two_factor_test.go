@@ -0,0 +1,480 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/NKTKLN/todo-api/models"
+	"github.com/NKTKLN/todo-api/pkg/common"
+	rd "github.com/NKTKLN/todo-api/pkg/db/redis"
+	"github.com/NKTKLN/todo-api/pkg/handlers"
+)
+
+var _ = Describe("Two-factor authentication", func() {
+	const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	var (
+		r                       *gin.Engine
+		w                       *httptest.ResponseRecorder
+		accessJwt               string
+		hashedPassword          []byte
+		handler                 handlers.Handler
+		postgresMock            sqlmock.Sqlmock
+		redisClientAccessToken  *redis.Client
+		redisClientRefreshToken *redis.Client
+		redisClientSession      *redis.Client
+		redisClientLimit        *redis.Client
+	)
+
+	userColumns := []string{"id", "email", "name", "username", "password", "icon", "totp_secret", "totp_enabled", "recovery_codes"}
+
+	currentCode := func() string {
+		code, err := common.TOTPCode(secret, time.Now())
+		Expect(err).To(BeNil())
+		return code
+	}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.ReleaseMode)
+
+		r = gin.New()
+		w = httptest.NewRecorder()
+
+		redisClientAccessToken = TestRedisConnection()
+		redisClientRefreshToken = TestRedisConnection()
+		redisClientSession = TestRedisConnection()
+		redisClientLimit = TestRedisConnection()
+
+		handler.RedisClient = &rd.RedisClients{
+			AccessTokenClient:  redisClientAccessToken,
+			RefreshTokenClient: redisClientRefreshToken,
+			SessionClient:      redisClientSession,
+			LimitClient:        redisClientLimit,
+		}
+
+		handler.PostgresDB, postgresMock = MockPostgresConnection()
+
+		// Creating new session with a pair of jwt tokens
+		accessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
+
+		// Password hash generation
+		var err error
+		hashedPassword, err = bcrypt.GenerateFromPassword([]byte("StRon9Pa$$w0rd"), bcrypt.MinCost)
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		redisClientAccessToken.Close()
+		redisClientRefreshToken.Close()
+		redisClientSession.Close()
+		redisClientLimit.Close()
+
+		Expect(postgresMock.ExpectationsWereMet()).ShouldNot(HaveOccurred())
+	})
+
+	Describe("TOTP codes", func() {
+		It("should match the RFC 6238 test vectors", func() {
+			for unixTime, code := range map[int64]string{
+				59:         "287082",
+				1111111109: "081804",
+				1234567890: "005924",
+				2000000000: "279037",
+			} {
+				Expect(common.TOTPCode(secret, time.Unix(unixTime, 0))).To(Equal(code))
+			}
+		})
+
+		It("should accept only the codes of the current time window", func() {
+			oldCode, err := common.TOTPCode(secret, time.Now().Add(-5*time.Minute))
+			Expect(err).To(BeNil())
+
+			_, ok := common.VerifyTOTP(secret, currentCode(), 0)
+			Expect(ok).To(BeTrue())
+			_, ok = common.VerifyTOTP(secret, oldCode, 0)
+			Expect(ok).To(BeFalse())
+			_, ok = common.VerifyTOTP(secret, "", 0)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should not accept the code of the last accepted time step again", func() {
+			step, ok := common.VerifyTOTP(secret, currentCode(), 0)
+			Expect(ok).To(BeTrue())
+			Expect(step).To(BeNumerically("~", time.Now().Unix()/30, 1))
+
+			_, ok = common.VerifyTOTP(secret, currentCode(), step)
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("Enable two-factor authentication", func() {
+		BeforeEach(func() {
+			r.POST("/user/2fa/enable", handler.AuthMiddleware(), handler.EnableTwoFactor)
+		})
+
+		Context("already enabled", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows(userColumns).
+						AddRow(117115101114, "email@example.com", "", "", "", "", secret, true, "{}"))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/user/2fa/enable", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that two-factor authentication is already enabled", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Two-factor authentication is already enabled."}`))
+			})
+		})
+
+		Context("ok", func() {
+			var setup models.ApiTwoFactorSetup
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows(userColumns).
+						AddRow(117115101114, "email@example.com", "", "", "", "", "", false, "{}"))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditUserTOTPSecret)).
+					WithArgs(AnyString{}, 117115101114).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/user/2fa/enable", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+
+				// Converting the query body into a model
+				Expect(json.Unmarshal(w.Body.Bytes(), &setup)).To(BeNil())
+			})
+
+			It("should return a secret and an otpauth uri", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(setup.Secret).To(HaveLen(32))
+				Expect(setup.URI).To(HavePrefix("otpauth://totp/ToDo%20API:email@example.com?"))
+				Expect(setup.URI).To(ContainSubstring("secret=" + setup.Secret))
+			})
+		})
+	})
+
+	Describe("Confirm two-factor authentication", func() {
+		BeforeEach(func() {
+			r.POST("/user/2fa/confirm", handler.AuthMiddleware(), handler.ConfirmTwoFactor)
+
+			// Query building for the postgres
+			postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+				WithArgs(117115101114).
+				WillReturnRows(sqlmock.NewRows(userColumns).
+					AddRow(117115101114, "email@example.com", "", "", "", "", secret, false, "{}"))
+		})
+
+		Context("wrong code", func() {
+			const requestBody = `{"code": "000000"}`
+
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/user/2fa/confirm", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the code is wrong", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Wrong two-factor authentication code."}`))
+			})
+		})
+
+		Context("ok", func() {
+			var recoveryCodes models.ApiRecoveryCodes
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlUseTOTPStep)).
+					WithArgs(AnyInt{}, 117115101114, AnyInt{}).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEnableUserTOTP)).
+					WithArgs(AnyString{}, true, 117115101114).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/user/2fa/confirm", bytes.NewBufferString(fmt.Sprintf(`{"code": "%s"}`, currentCode())))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+
+				// Converting the query body into a model
+				Expect(json.Unmarshal(w.Body.Bytes(), &recoveryCodes)).To(BeNil())
+			})
+
+			It("should return recovery codes", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(recoveryCodes.RecoveryCodes).To(HaveLen(models.RECOVERY_CODES_COUNT))
+			})
+		})
+	})
+
+	Describe("Disable two-factor authentication", func() {
+		BeforeEach(func() {
+			r.POST("/user/2fa/disable", handler.AuthMiddleware(), handler.DisableTwoFactor)
+
+			// Query building for the postgres
+			postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+				WithArgs(117115101114).
+				WillReturnRows(sqlmock.NewRows(userColumns).
+					AddRow(117115101114, "email@example.com", "", "", hashedPassword, "", secret, true, "{}"))
+		})
+
+		Context("incorrect password", func() {
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/user/2fa/disable", bytes.NewBufferString(fmt.Sprintf(`{"code": "%s", "password": "password"}`, currentCode())))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the password is incorrect", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Incorrect password."}`))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlUseTOTPStep)).
+					WithArgs(AnyInt{}, 117115101114, AnyInt{}).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDisableUserTOTP)).
+					WithArgs(AnyString{}, false, "", 117115101114).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/user/2fa/disable", bytes.NewBufferString(fmt.Sprintf(`{"code": "%s", "password": "StRon9Pa$$w0rd"}`, currentCode())))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that two-factor authentication has been disabled", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"Two-factor authentication has been disabled."}`))
+			})
+		})
+	})
+
+	Describe("Sign in with two-factor authentication", func() {
+		const recoveryCode = "3f9a1-0c7b2"
+
+		var challenge models.ApiTwoFactorChallenge
+
+		expectUser := func() {
+			postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+				WithArgs(117115101114).
+				WillReturnRows(sqlmock.NewRows(userColumns).
+					AddRow(117115101114, "email@example.com", "", "", hashedPassword, "", secret, true, "{"+common.HashRecoveryCode(recoveryCode)+"}"))
+		}
+
+		BeforeEach(func() {
+			r.POST("/auth/sign-in", handler.SignIn)
+			r.POST("/auth/sign-in/2fa", handler.SignInTwoFactor)
+
+			// Query building for the postgres
+			postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserByEmail)).
+				WithArgs("email@example.com").
+				WillReturnRows(sqlmock.NewRows(userColumns).
+					AddRow(117115101114, "email@example.com", "", "", hashedPassword, "", secret, true, "{}"))
+
+			// Sending a query with data
+			req := httptest.NewRequest(http.MethodPost, "/auth/sign-in", bytes.NewBufferString(`{"email": "email@example.com", "password": "StRon9Pa$$w0rd", "device": "Test Device"}`))
+			r.ServeHTTP(w, req)
+
+			// Converting the query body into a model
+			Expect(json.Unmarshal(w.Body.Bytes(), &challenge)).To(BeNil())
+		})
+
+		It("should return a challenge instead of tokens", func() {
+			Expect(w.Code).To(Equal(http.StatusAccepted))
+			Expect(challenge.Challenge).NotTo(BeEmpty())
+			Expect(w.Body.String()).NotTo(ContainSubstring("access_token"))
+		})
+
+		Context("challenge is not valid", func() {
+			BeforeEach(func() {
+				w = httptest.NewRecorder()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/auth/sign-in/2fa", bytes.NewBufferString(fmt.Sprintf(`{"challenge": "challenge", "code": "%s"}`, currentCode())))
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the challenge is not valid", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Time has expired, your challenge is not valid."}`))
+			})
+		})
+
+		Context("wrong code", func() {
+			BeforeEach(func() {
+				w = httptest.NewRecorder()
+
+				// Query building for the postgres
+				expectUser()
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlUseRecoveryCode)).
+					WithArgs(common.HashRecoveryCode("000000"), 117115101114, common.HashRecoveryCode("000000")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/auth/sign-in/2fa", bytes.NewBufferString(fmt.Sprintf(`{"challenge": "%s", "code": "000000"}`, challenge.Challenge)))
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the code is wrong", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Wrong two-factor authentication code."}`))
+			})
+		})
+
+		Context("too many attempts", func() {
+			BeforeEach(func() {
+				// Using up all attempts of the challenge
+				for i := 0; i < models.MAX_CHALLENGE_ATTEMPTS; i++ {
+					_, err := handler.RedisClient.GetChallenge(context.Background(), challenge.Challenge)
+					Expect(err).To(BeNil())
+				}
+
+				w = httptest.NewRecorder()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/auth/sign-in/2fa", bytes.NewBufferString(fmt.Sprintf(`{"challenge": "%s", "code": "%s"}`, challenge.Challenge, currentCode())))
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error and revoke the challenge", func() {
+				Expect(w.Code).To(Equal(http.StatusTooManyRequests))
+				Expect(w.Body.String()).To(Equal(`{"error":"Too many attempts, sign in again."}`))
+
+				_, err := handler.RedisClient.GetChallenge(context.Background(), challenge.Challenge)
+				Expect(err).NotTo(BeNil())
+			})
+		})
+
+		Context("totp code already used", func() {
+			BeforeEach(func() {
+				w = httptest.NewRecorder()
+
+				// Query building for the postgres, the time step of the code is already saved by another sign in
+				expectUser()
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlUseTOTPStep)).
+					WithArgs(AnyInt{}, 117115101114, AnyInt{}).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				postgresMock.ExpectCommit()
+
+				code := currentCode()
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlUseRecoveryCode)).
+					WithArgs(common.HashRecoveryCode(code), 117115101114, common.HashRecoveryCode(code)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/auth/sign-in/2fa", bytes.NewBufferString(fmt.Sprintf(`{"challenge": "%s", "code": "%s"}`, challenge.Challenge, code)))
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the code is wrong", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Wrong two-factor authentication code."}`))
+			})
+		})
+
+		Context("ok with a totp code", func() {
+			var tokens models.UserTokens
+
+			BeforeEach(func() {
+				w = httptest.NewRecorder()
+
+				// Query building for the postgres
+				expectUser()
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlUseTOTPStep)).
+					WithArgs(AnyInt{}, 117115101114, AnyInt{}).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/auth/sign-in/2fa", bytes.NewBufferString(fmt.Sprintf(`{"challenge": "%s", "code": "%s"}`, challenge.Challenge, currentCode())))
+				r.ServeHTTP(w, req)
+
+				// Converting the query body into a model
+				Expect(json.Unmarshal(w.Body.Bytes(), &tokens)).To(BeNil())
+			})
+
+			It("should return a couple of new tokens", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				session := handler.RedisClient.VerifySession(context.Background(), tokens.AccessToken)
+				Expect(session.UserId).To(Equal(117115101114))
+				Expect(session.Device).To(Equal("Test Device"))
+			})
+
+			It("should not allow the challenge to be used again", func() {
+				_, err := handler.RedisClient.GetChallenge(context.Background(), challenge.Challenge)
+				Expect(err).NotTo(BeNil())
+			})
+		})
+
+		Context("ok with a recovery code", func() {
+			BeforeEach(func() {
+				w = httptest.NewRecorder()
+
+				// Query building for the postgres
+				expectUser()
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlUseRecoveryCode)).
+					WithArgs(common.HashRecoveryCode(recoveryCode), 117115101114, common.HashRecoveryCode(recoveryCode)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/auth/sign-in/2fa", bytes.NewBufferString(fmt.Sprintf(`{"challenge": "%s", "code": "%s"}`, challenge.Challenge, strings.ToUpper(recoveryCode))))
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a couple of new tokens", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(ContainSubstring("access_token"))
+			})
+		})
+	})
+})
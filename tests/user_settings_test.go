@@ -64,6 +64,8 @@ var _ = Describe("User settings", func() {
 		redisClientEmail        *redis.Client
 		redisClientAccessToken  *redis.Client
 		redisClientRefreshToken *redis.Client
+		redisClientSession      *redis.Client
+		redisClientLimit        *redis.Client
 	)
 
 	BeforeEach(func() {
@@ -75,6 +77,8 @@ var _ = Describe("User settings", func() {
 		redisClientEmail = TestRedisConnection()
 		redisClientAccessToken = TestRedisConnection()
 		redisClientRefreshToken = TestRedisConnection()
+		redisClientSession = TestRedisConnection()
+		redisClientLimit = TestRedisConnection()
 
 		handler.EmailAuthData = NewFakeEmailProvider("email@example.com", "StRon9Pa$$w0rd", "smtp.example.com", 0)
 
@@ -82,6 +86,8 @@ var _ = Describe("User settings", func() {
 			EmailClient:        redisClientEmail,
 			AccessTokenClient:  redisClientAccessToken,
 			RefreshTokenClient: redisClientRefreshToken,
+			SessionClient:      redisClientSession,
+			LimitClient:        redisClientLimit,
 		}
 
 		handler.PostgresDB, postgresMock = MockPostgresConnection()
@@ -96,24 +102,23 @@ var _ = Describe("User settings", func() {
 			logrus.Fatalf("error when connecting to the MinIO database: %s", err.Error())
 		}
 
-		// Generate new jwt token
-		accessJwt, _ = common.NewJWT(117115101114, time.Minute, viper.GetString("api.jwt.access-secret"))
-
-		// Adding data to redis
-		redisClientAccessToken.Set(context.Background(), "117115101114", accessJwt, time.Minute)
+		// Creating new session with a jwt token
+		accessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
 	})
 
 	AfterEach(func() {
 		redisClientEmail.Close()
 		redisClientAccessToken.Close()
 		redisClientRefreshToken.Close()
+		redisClientSession.Close()
+		redisClientLimit.Close()
 
 		Expect(postgresMock.ExpectationsWereMet()).ShouldNot(HaveOccurred())
 	})
 
 	Describe("Edit user name", func() {
 		BeforeEach(func() {
-			r.PATCH("/user/settings/update/name", handler.EditUserName)
+			r.PATCH("/user/settings/update/name", handler.AuthMiddleware(), handler.EditUserName)
 		})
 
 		Context("data retrieval error", func() {
@@ -130,7 +135,7 @@ var _ = Describe("User settings", func() {
 			})
 		})
 
-		Context("inactive user", func() {
+		Context("missing access token", func() {
 			const requestBody = `{"name": "Test User Name"}`
 
 			BeforeEach(func() {
@@ -139,9 +144,9 @@ var _ = Describe("User settings", func() {
 				r.ServeHTTP(w, req)
 			})
 
-			It("should return an error that the user is inactive", func() {
-				Expect(w.Code).To(Equal(http.StatusNotFound))
-				Expect(w.Body.String()).To(Equal(`{"error":"Inactive user."}`))
+			It("should return an error that the access token is missing", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
 			})
 		})
 
@@ -185,9 +190,83 @@ var _ = Describe("User settings", func() {
 		})
 	})
 
+	Describe("Edit user timezone", func() {
+		BeforeEach(func() {
+			r.PATCH("/user/settings/update/timezone", handler.AuthMiddleware(), handler.EditUserTimezone)
+		})
+
+		Context("incorrect timezone", func() {
+			const requestBody = `{"timezone": "Mars/Olympus"}`
+
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPatch, "/user/settings/update/timezone", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the timezone is incorrect", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Incorrect timezone."}`))
+			})
+		})
+
+		Context("ok", func() {
+			const requestBody = `{"timezone": "Europe/Moscow"}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditUserTimezone)).
+					WithArgs("Europe/Moscow", 117115101114).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPatch, "/user/settings/update/timezone", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the user timezone was updated successfully", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"Timezone updated successfully."}`))
+			})
+		})
+	})
+
+	Describe("Edit user completion rollup", func() {
+		BeforeEach(func() {
+			r.PATCH("/user/settings/update/rollup", handler.AuthMiddleware(), handler.EditUserCompletionRollup)
+		})
+
+		Context("ok", func() {
+			const requestBody = `{"rollup": true}`
+
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditUserCompletionRollup)).
+					WithArgs(true, 117115101114).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPatch, "/user/settings/update/rollup", bytes.NewBufferString(requestBody))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the completion rollup was updated successfully", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"Completion rollup updated successfully."}`))
+			})
+		})
+	})
+
 	Describe("Edit user username", func() {
 		BeforeEach(func() {
-			r.PATCH("/user/settings/update/username", handler.EditUserUsername)
+			r.PATCH("/user/settings/update/username", handler.AuthMiddleware(), handler.EditUserUsername)
 		})
 
 		Context("data retrieval error", func() {
@@ -204,7 +283,7 @@ var _ = Describe("User settings", func() {
 			})
 		})
 
-		Context("inactive user", func() {
+		Context("missing access token", func() {
 			const requestBody = `{"username": "test_username"}`
 
 			BeforeEach(func() {
@@ -213,9 +292,9 @@ var _ = Describe("User settings", func() {
 				r.ServeHTTP(w, req)
 			})
 
-			It("should return an error that the user is inactive", func() {
-				Expect(w.Code).To(Equal(http.StatusNotFound))
-				Expect(w.Body.String()).To(Equal(`{"error":"Inactive user."}`))
+			It("should return an error that the access token is missing", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
 			})
 		})
 
@@ -292,7 +371,7 @@ var _ = Describe("User settings", func() {
 
 	Describe("Reset user email", func() {
 		BeforeEach(func() {
-			r.POST("/user/settings/reset/email", handler.ResetUserEmail)
+			r.POST("/user/settings/reset/email", handler.AuthMiddleware(), handlers.SessionMiddleware(), handler.ResetUserEmail)
 		})
 
 		Context("data retrieval error", func() {
@@ -309,7 +388,7 @@ var _ = Describe("User settings", func() {
 			})
 		})
 
-		Context("inactive user", func() {
+		Context("missing access token", func() {
 			const requestBody = `{"email": "email@example.com"}`
 
 			BeforeEach(func() {
@@ -318,9 +397,9 @@ var _ = Describe("User settings", func() {
 				r.ServeHTTP(w, req)
 			})
 
-			It("should return an error that the user is inactive", func() {
-				Expect(w.Code).To(Equal(http.StatusNotFound))
-				Expect(w.Body.String()).To(Equal(`{"error":"Inactive user."}`))
+			It("should return an error that the access token is missing", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
 			})
 		})
 
@@ -386,9 +465,58 @@ var _ = Describe("User settings", func() {
 			})
 		})
 
+		Context("key of another flow", func() {
+			BeforeEach(func() {
+				// Convert data to json
+				jsonData, err := json.Marshal(models.Users{Id: 117115101114, Email: "email@example.com"})
+				Expect(err).To(BeNil())
+
+				// Adding data to redis
+				redisClientEmail.Set(context.Background(), models.VERIFICATION_FLOW+":key", jsonData, time.Minute)
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPatch, "/user/settings/update/email?key=key", nil)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the key is invalid", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Time has expired, your key is not valid."}`))
+			})
+		})
+
+		Context("mail is already in use", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllUsersByEmail)).
+					WithArgs("email@example.com").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "username", "password", "icon"}).
+						AddRow(117, "email@example.com", "", "", "", ""))
+
+				// Convert data to json
+				jsonData, err := json.Marshal(models.Users{Id: 117115101114, Email: "email@example.com"})
+				Expect(err).To(BeNil())
+
+				// Adding data to redis
+				redisClientEmail.Set(context.Background(), models.EMAIL_CHANGE_FLOW+":key", jsonData, time.Minute)
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPatch, "/user/settings/update/email?key=key", nil)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the mail is already in use", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Mail is already in use."}`))
+			})
+		})
+
 		Context("ok", func() {
 			BeforeEach(func() {
 				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllUsersByEmail)).
+					WithArgs("email@example.com").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "username", "password", "icon"}))
 				postgresMock.ExpectBegin()
 				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditUserEmail)).
 					WithArgs("email@example.com", 117115101114).
@@ -400,7 +528,7 @@ var _ = Describe("User settings", func() {
 				Expect(err).To(BeNil())
 
 				// Adding data to redis
-				redisClientEmail.Set(context.Background(), "key", jsonData, time.Minute)
+				redisClientEmail.Set(context.Background(), models.EMAIL_CHANGE_FLOW+":key", jsonData, time.Minute)
 
 				// Sending a query with data
 				req := httptest.NewRequest(http.MethodPatch, "/user/settings/update/email?key=key", nil)
@@ -411,6 +539,25 @@ var _ = Describe("User settings", func() {
 				Expect(w.Code).To(Equal(http.StatusOK))
 				Expect(w.Body.String()).To(Equal(`{"message":"Email successfully updated."}`))
 			})
+
+			It("should revoke all user sessions", func() {
+				Expect(handler.RedisClient.VerifyToken(context.Background(), accessJwt)).To(Equal(0))
+			})
+
+			It("should delete the personal access tokens", func() {
+				Expect(postgresMock.ExpectationsWereMet()).To(BeNil())
+			})
+
+			It("should not accept the key a second time", func() {
+				w = httptest.NewRecorder()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPatch, "/user/settings/update/email?key=key", nil)
+				r.ServeHTTP(w, req)
+
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Time has expired, your key is not valid."}`))
+			})
 		})
 	})
 
@@ -532,9 +679,18 @@ var _ = Describe("User settings", func() {
 					WithArgs(AnyString{}, "email@example.com").
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				postgresMock.ExpectCommit()
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserByEmail)).
+					WithArgs("email@example.com").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "username", "password", "icon"}).
+						AddRow(117115101114, "email@example.com", "", "", "", ""))
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteAllUserPersonalTokens)).
+					WithArgs(117115101114).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
 
 				// Adding data to redis
-				redisClientEmail.Set(context.Background(), "key", "email@example.com", time.Minute)
+				redisClientEmail.Set(context.Background(), models.PASSWORD_RESET_FLOW+":key", "email@example.com", time.Minute)
 
 				// Sending a query with data
 				req := httptest.NewRequest(http.MethodPatch, "/user/settings/update/password?key=key", bytes.NewBufferString(requestBody))
@@ -546,6 +702,25 @@ var _ = Describe("User settings", func() {
 				Expect(w.Code).To(Equal(http.StatusOK))
 				Expect(w.Body.String()).To(Equal(`{"message":"Password successfully updated."}`))
 			})
+
+			It("should revoke all user sessions", func() {
+				Expect(handler.RedisClient.VerifyToken(context.Background(), accessJwt)).To(Equal(0))
+			})
+
+			It("should delete the personal access tokens", func() {
+				Expect(postgresMock.ExpectationsWereMet()).To(BeNil())
+			})
+
+			It("should not accept the key a second time", func() {
+				w = httptest.NewRecorder()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPatch, "/user/settings/update/password?key=key", bytes.NewBufferString(requestBody))
+				r.ServeHTTP(w, req)
+
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Time has expired, your key is not valid."}`))
+			})
 		})
 	})
 
@@ -572,10 +747,15 @@ var _ = Describe("User settings", func() {
 
 			BeforeEach(func() {
 				// Generate new jwt token
-				refreshJwt, _ := common.NewJWT(117115101114, time.Minute, viper.GetString("api.jwt.refresh-secret"))
+				refreshJwt, _ := common.NewJWT(117115101114, "session", time.Minute, viper.GetString("api.jwt.refresh-secret"))
+
+				// Convert data to json
+				jsonData, err := json.Marshal(models.Sessions{Id: "session", UserId: 117115101114})
+				Expect(err).To(BeNil())
 
 				// Adding data to redis
-				redisClientRefreshToken.Set(context.Background(), "117115101114", refreshJwt, time.Minute)
+				redisClientRefreshToken.Set(context.Background(), "session", refreshJwt, time.Minute)
+				redisClientSession.Set(context.Background(), "session", jsonData, time.Minute)
 
 				// Sending a query with data
 				req := httptest.NewRequest(http.MethodPut, "/user/settings/update/token", nil)
@@ -595,7 +775,7 @@ var _ = Describe("User settings", func() {
 
 	Describe("Update user icon", func() {
 		BeforeEach(func() {
-			r.PUT("/user/settings/update/icon", handler.UpdateUserIcon)
+			r.PUT("/user/settings/update/icon", handler.AuthMiddleware(), handler.UpdateUserIcon)
 		})
 
 		Context("no such file", func() {
@@ -637,7 +817,7 @@ var _ = Describe("User settings", func() {
 			})
 		})
 
-		Context("inactive user", func() {
+		Context("missing access token", func() {
 			BeforeEach(func() {
 				// Preparing an icon for upload
 				body, writer, err := filePreparation("./static/test_icon.png")
@@ -649,9 +829,9 @@ var _ = Describe("User settings", func() {
 				r.ServeHTTP(w, req)
 			})
 
-			It("should return an error that the user is inactive", func() {
-				Expect(w.Code).To(Equal(http.StatusNotFound))
-				Expect(w.Body.String()).To(Equal(`{"error":"Inactive user."}`))
+			It("should return an error that the access token is missing", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
 			})
 		})
 
@@ -703,24 +883,19 @@ var _ = Describe("User settings", func() {
 
 	Describe("Delete user icon", func() {
 		BeforeEach(func() {
-			r.DELETE("/user/delete/icon", handler.DeleteUserIcon)
+			r.DELETE("/user/delete/icon", handler.AuthMiddleware(), handler.DeleteUserIcon)
 		})
 
-		Context("inactive user", func() {
+		Context("missing access token", func() {
 			BeforeEach(func() {
-				// Query building for the postgres
-				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
-					WithArgs(0).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "username", "password", "icon"}))
-
 				// Sending a query with data
 				req := httptest.NewRequest(http.MethodDelete, "/user/delete/icon", nil)
 				r.ServeHTTP(w, req)
 			})
 
-			It("should return an error that the user is inactive", func() {
-				Expect(w.Code).To(Equal(http.StatusNotFound))
-				Expect(w.Body.String()).To(Equal(`{"error":"Inactive user."}`))
+			It("should return an error that the access token is missing", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
 			})
 		})
 
@@ -789,24 +964,19 @@ var _ = Describe("User settings", func() {
 
 	Describe("Delete user", func() {
 		BeforeEach(func() {
-			r.DELETE("/user/delete/account", handler.DeleteUser)
+			r.DELETE("/user/delete/account", handler.AuthMiddleware(), handler.DeleteUser)
 		})
 
-		Context("inactive user", func() {
+		Context("missing access token", func() {
 			BeforeEach(func() {
-				// Query building for the postgres
-				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
-					WithArgs(0).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "username", "password", "icon"}))
-
 				// Sending a query with data
 				req := httptest.NewRequest(http.MethodDelete, `/user/delete/account?password=StRon9Pa$$w0rd`, nil)
 				r.ServeHTTP(w, req)
 			})
 
-			It("should return an error that the user is inactive", func() {
-				Expect(w.Code).To(Equal(http.StatusNotFound))
-				Expect(w.Body.String()).To(Equal(`{"error":"Inactive user."}`))
+			It("should return an error that the access token is missing", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
 			})
 		})
 
@@ -862,23 +1032,93 @@ var _ = Describe("User settings", func() {
 						AddRow(1151179811697115107, 0, 11697115107, "Test Task Name", "Test Task Comment", 0, nil, nil, false, false))
 
 				postgresMock.ExpectBegin()
-				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTask)).
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskTreeReminders)).
+					WithArgs(1151179811697115107).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskTreeAssignees)).
+					WithArgs(1151179811697115107).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskTreeComments)).
+					WithArgs(1151179811697115107).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskTree)).
 					WithArgs(1151179811697115107).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				postgresMock.ExpectCommit()
 
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskReminders)).
+					WithArgs(11697115107).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				postgresMock.ExpectCommit()
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskAssignees)).
+					WithArgs(11697115107).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				postgresMock.ExpectCommit()
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTaskComments)).
+					WithArgs(11697115107).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				postgresMock.ExpectCommit()
+
 				postgresMock.ExpectBegin()
 				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteTask)).
 					WithArgs(11697115107).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				postgresMock.ExpectCommit()
 
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteListMembers)).
+					WithArgs(108105115116).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				postgresMock.ExpectCommit()
+
 				postgresMock.ExpectBegin()
 				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteList)).
 					WithArgs(108105115116).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				postgresMock.ExpectCommit()
 
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteAllUserSavedFilters)).
+					WithArgs(117115101114).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteAllUserTags)).
+					WithArgs(117115101114).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteAllUserReminders)).
+					WithArgs(117115101114).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteAllUserAssignees)).
+					WithArgs(117115101114).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteAllUserListMembers)).
+					WithArgs(117115101114).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				postgresMock.ExpectCommit()
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserAdminWorkspaceIds)).
+					WithArgs(117115101114, "admin").
+					WillReturnRows(sqlmock.NewRows([]string{"workspace_id"}))
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteAllUserWorkspaceMembers)).
+					WithArgs(117115101114).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				postgresMock.ExpectCommit()
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteAllUserPersonalTokens)).
+					WithArgs(117115101114).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
 				postgresMock.ExpectBegin()
 				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteUser)).
 					WithArgs(117115101114).
@@ -9,17 +9,14 @@ import (
 	"net/http/httptest"
 	"os"
 	"regexp"
-	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-	"github.com/spf13/viper"
 
 	"github.com/NKTKLN/todo-api/models"
-	"github.com/NKTKLN/todo-api/pkg/common"
 	"github.com/NKTKLN/todo-api/pkg/db/minio"
 	rd "github.com/NKTKLN/todo-api/pkg/db/redis"
 	"github.com/NKTKLN/todo-api/pkg/handlers"
@@ -225,28 +222,33 @@ var _ = Describe("User", func() {
 
 	Describe("Show user data by token", func() {
 		var (
-			accessJwt              string
-			redisClientAccessToken *redis.Client
+			accessJwt               string
+			redisClientAccessToken  *redis.Client
+			redisClientRefreshToken *redis.Client
+			redisClientSession      *redis.Client
 		)
 
 		BeforeEach(func() {
 			redisClientAccessToken = TestRedisConnection()
+			redisClientRefreshToken = TestRedisConnection()
+			redisClientSession = TestRedisConnection()
 
 			handler.RedisClient = &rd.RedisClients{
-				AccessTokenClient: redisClientAccessToken,
+				AccessTokenClient:  redisClientAccessToken,
+				RefreshTokenClient: redisClientRefreshToken,
+				SessionClient:      redisClientSession,
 			}
 
-			// Generate new jwt token
-			accessJwt, _ = common.NewJWT(117115101114, time.Minute, viper.GetString("api.jwt.access-secret"))
+			// Creating new session with a jwt token
+			accessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
 
-			// Adding data to redis
-			redisClientAccessToken.Set(context.Background(), "117115101114", accessJwt, time.Minute)
-
-			r.GET("/user/show/data-by-token", handler.GetUserDataByToken)
+			r.GET("/user/show/data-by-token", handler.AuthMiddleware(), handler.GetUserDataByToken)
 		})
 
 		AfterEach(func() {
 			redisClientAccessToken.Close()
+			redisClientRefreshToken.Close()
+			redisClientSession.Close()
 		})
 
 		Context("user not found", func() {
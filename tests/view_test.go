@@ -0,0 +1,239 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/NKTKLN/todo-api/models"
+	rd "github.com/NKTKLN/todo-api/pkg/db/redis"
+	"github.com/NKTKLN/todo-api/pkg/handlers"
+)
+
+var _ = Describe("Views", func() {
+	var (
+		r                       *gin.Engine
+		w                       *httptest.ResponseRecorder
+		accessJwt               string
+		handler                 handlers.Handler
+		postgresMock            sqlmock.Sqlmock
+		redisClientAccessToken  *redis.Client
+		redisClientRefreshToken *redis.Client
+		redisClientSession      *redis.Client
+		tasks                   models.ApiShowViewTasks
+	)
+
+	taskColumns := []string{"id", "list_id", "task_id", "name", "comment", "index", "categories", "end_time", "done", "special"}
+	listColumns := []string{"id", "user_id", "name", "comment", "index"}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.ReleaseMode)
+
+		r = gin.New()
+		w = httptest.NewRecorder()
+		tasks = models.ApiShowViewTasks{}
+
+		redisClientAccessToken = TestRedisConnection()
+		redisClientRefreshToken = TestRedisConnection()
+		redisClientSession = TestRedisConnection()
+
+		handler.RedisClient = &rd.RedisClients{
+			AccessTokenClient:  redisClientAccessToken,
+			RefreshTokenClient: redisClientRefreshToken,
+			SessionClient:      redisClientSession,
+		}
+
+		handler.PostgresDB, postgresMock = MockPostgresConnection()
+
+		// Creating new session with a jwt token
+		accessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
+	})
+
+	AfterEach(func() {
+		redisClientAccessToken.Close()
+		redisClientRefreshToken.Close()
+		redisClientSession.Close()
+
+		Expect(postgresMock.ExpectationsWereMet()).ShouldNot(HaveOccurred())
+	})
+
+	Describe("Today", func() {
+		BeforeEach(func() {
+			r.GET("/todo/views/today", handler.AuthMiddleware(), handler.ShowTodayTasks)
+		})
+
+		Context("missing access token", func() {
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/views/today", nil)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the access token is missing", func() {
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				Expect(w.Body.String()).To(Equal(`{"error":"Missing access token."}`))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				// The day starts at the midnight of the user timezone
+				location, _ := time.LoadLocation("Asia/Tokyo")
+				now := time.Now().In(location)
+				today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "timezone"}).
+						AddRow(117115101114, "Asia/Tokyo"))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTodayUserTasks)).
+					WithArgs(117115101114, 117115101114, false, today.AddDate(0, 0, 1), time.Time{}, today).
+					WillReturnRows(sqlmock.NewRows(taskColumns).
+						AddRow(11697115107, 108105115116, 0, "Test Task Name", "Test Task Comment", 0, nil, time.Date(2077, time.December, 10, 13, 13, 0, 0, time.UTC), false, false))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserLists)).
+					WithArgs("owner", 117115101114, 117115101114).
+					WillReturnRows(sqlmock.NewRows(listColumns).
+						AddRow(108105115116, 117115101114, "Test List Name", "Test List Comment", 0))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskAssignees)).
+					WillReturnRows(sqlmock.NewRows([]string{"task_id", "id", "username"}))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/views/today", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+
+				// Converting the query body into a model
+				Expect(json.Unmarshal(w.Body.Bytes(), &tasks)).To(BeNil())
+			})
+
+			It("should return tasks due today with their lists and the end time in the user timezone", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(tasks.Tasks).To(Equal([]models.ViewTasksData{{
+					ListId:    108105115116,
+					ListName:  "Test List Name",
+					TasksData: models.TasksData{Id: 11697115107, Name: "Test Task Name", Comment: "Test Task Comment", EndTime: "2077-12-10 22:13"},
+				}}))
+			})
+		})
+	})
+
+	Describe("Starred", func() {
+		BeforeEach(func() {
+			r.GET("/todo/views/starred", handler.AuthMiddleware(), handler.ShowStarredTasks)
+		})
+
+		Context("without tasks", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "timezone"}).
+						AddRow(117115101114, "Asia/Tokyo"))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectStarredUserTasks)).
+					WithArgs(117115101114, 117115101114, false, true).
+					WillReturnRows(sqlmock.NewRows(taskColumns))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/views/starred", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+
+				// Converting the query body into a model
+				Expect(json.Unmarshal(w.Body.Bytes(), &tasks)).To(BeNil())
+			})
+
+			It("should return empty task data", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(tasks.Tasks).To(BeNil())
+			})
+		})
+
+		Context("incorrect limit", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "timezone"}).
+						AddRow(117115101114, "Asia/Tokyo"))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/views/starred?limit=0", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the limit is incorrect", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Incorrect limit."}`))
+			})
+		})
+	})
+
+	Describe("Assigned to me", func() {
+		BeforeEach(func() {
+			r.GET("/todo/views/assigned-to-me", handler.AuthMiddleware(), handler.ShowAssignedTasks)
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAssignedTasks)).
+					WithArgs(117115101114, 117115101114, 117115101114, false).
+					WillReturnRows(sqlmock.NewRows(append(taskColumns, "root_list_id")).
+						AddRow(1151179811697115107, 0, 11697115107, "Test Subtask Name", "Test Subtask Comment", 0, nil, nil, false, false, 108105115116))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserLists)).
+					WithArgs("owner", 117115101114, 117115101114).
+					WillReturnRows(sqlmock.NewRows(listColumns).
+						AddRow(108105115116, 117115101115, "Shared List Name", "Test List Comment", 0))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectTaskAssignees)).
+					WithArgs(1151179811697115107).
+					WillReturnRows(sqlmock.NewRows([]string{"task_id", "id", "username"}).
+						AddRow(1151179811697115107, 117115101114, "nktkln"))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserById)).
+					WithArgs(117115101114).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "timezone"}).
+						AddRow(117115101114, "Asia/Tokyo"))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/views/assigned-to-me", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+
+				// Converting the query body into a model
+				Expect(json.Unmarshal(w.Body.Bytes(), &tasks)).To(BeNil())
+			})
+
+			It("should return the assigned subtask in the list of its task", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(tasks.Tasks).To(Equal([]models.ViewTasksData{{
+					ListId:   108105115116,
+					ListName: "Shared List Name",
+					TasksData: models.TasksData{
+						Id:        1151179811697115107,
+						Name:      "Test Subtask Name",
+						Comment:   "Test Subtask Comment",
+						EndTime:   "0001-01-01 00:00",
+						Assignees: []models.AssigneeData{{Id: 117115101114, Username: "nktkln"}},
+					},
+				}}))
+			})
+		})
+	})
+})
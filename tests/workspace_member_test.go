@@ -0,0 +1,343 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/NKTKLN/todo-api/models"
+	rd "github.com/NKTKLN/todo-api/pkg/db/redis"
+	"github.com/NKTKLN/todo-api/pkg/handlers"
+)
+
+var _ = Describe("Workspace members", func() {
+	var (
+		r                       *gin.Engine
+		w                       *httptest.ResponseRecorder
+		accessJwt               string
+		handler                 handlers.Handler
+		postgresMock            sqlmock.Sqlmock
+		redisClientAccessToken  *redis.Client
+		redisClientRefreshToken *redis.Client
+		redisClientSession      *redis.Client
+	)
+
+	userColumns := []string{"id", "email", "name", "username", "password", "icon", "role", "disabled"}
+	memberColumns := []string{"id", "username", "name", "role"}
+
+	expectWorkspaceRole := func(userId int, role string) {
+		rows := sqlmock.NewRows([]string{"role"})
+		if role != "" {
+			rows.AddRow(role)
+		}
+		postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectWorkspaceRole)).
+			WithArgs(119111114107, userId).
+			WillReturnRows(rows)
+	}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.ReleaseMode)
+
+		r = gin.New()
+		w = httptest.NewRecorder()
+
+		redisClientAccessToken = TestRedisConnection()
+		redisClientRefreshToken = TestRedisConnection()
+		redisClientSession = TestRedisConnection()
+
+		handler.RedisClient = &rd.RedisClients{
+			AccessTokenClient:  redisClientAccessToken,
+			RefreshTokenClient: redisClientRefreshToken,
+			SessionClient:      redisClientSession,
+		}
+
+		handler.PostgresDB, postgresMock = MockPostgresConnection()
+
+		// Creating new session with a jwt token
+		accessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
+	})
+
+	AfterEach(func() {
+		redisClientAccessToken.Close()
+		redisClientRefreshToken.Close()
+		redisClientSession.Close()
+
+		Expect(postgresMock.ExpectationsWereMet()).ShouldNot(HaveOccurred())
+	})
+
+	Describe("Show workspace members", func() {
+		var members models.ApiShowWorkspaceMembers
+
+		BeforeEach(func() {
+			r.GET("/todo/workspace/members", handler.AuthMiddleware(), handler.ShowWorkspaceMembers)
+		})
+
+		Context("this workspace not found", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectWorkspaceRole(117115101114, "")
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/workspace/members?workspace_id=119111114107", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the workspace not found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(Equal(`{"error":"This workspace not found."}`))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectWorkspaceRole(117115101114, "viewer")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectWorkspaceMembers)).
+					WithArgs(119111114107).
+					WillReturnRows(sqlmock.NewRows(memberColumns).
+						AddRow(117115101114, "nktkln", "Test Name", "viewer").
+						AddRow(117115101115, "test", "Test", "admin"))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodGet, "/todo/workspace/members?workspace_id=119111114107", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return the members of the workspace", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(json.Unmarshal(w.Body.Bytes(), &members)).ShouldNot(HaveOccurred())
+				Expect(members.Members).To(Equal([]models.WorkspaceMemberData{
+					{Id: 117115101114, Username: "nktkln", Name: "Test Name", Role: "viewer"},
+					{Id: 117115101115, Username: "test", Name: "Test", Role: "admin"},
+				}))
+			})
+		})
+	})
+
+	Describe("Add workspace member", func() {
+		BeforeEach(func() {
+			r.POST("/todo/workspace/members", handler.AuthMiddleware(), handler.AddWorkspaceMember)
+		})
+
+		Context("not enough rights", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectWorkspaceRole(117115101114, "editor")
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/workspace/members", bytes.NewBufferString(`{"workspace_id": 119111114107, "user": "test", "role": "editor"}`))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that there are not enough rights", func() {
+				Expect(w.Code).To(Equal(http.StatusForbidden))
+				Expect(w.Body.String()).To(Equal(`{"error":"Not enough rights."}`))
+			})
+		})
+
+		Context("this user is already a member", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectWorkspaceRole(117115101114, "admin")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserByUsername)).
+					WithArgs("test").
+					WillReturnRows(sqlmock.NewRows(userColumns).
+						AddRow(117115101115, "test@example.com", "Test", "test", "", "", "user", false))
+
+				expectWorkspaceRole(117115101115, "viewer")
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/workspace/members", bytes.NewBufferString(`{"workspace_id": 119111114107, "user": "test", "role": "editor"}`))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the user is already a member", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"This user is already a member of the workspace."}`))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectWorkspaceRole(117115101114, "admin")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserByEmail)).
+					WithArgs("test@example.com").
+					WillReturnRows(sqlmock.NewRows(userColumns).
+						AddRow(117115101115, "test@example.com", "Test", "test", "", "", "user", false))
+
+				expectWorkspaceRole(117115101115, "")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectWorkspaceMembers)).
+					WithArgs(119111114107).
+					WillReturnRows(sqlmock.NewRows(memberColumns).
+						AddRow(117115101114, "nktkln", "Test Name", "admin"))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlInsertWorkspaceMember)).
+					WithArgs(119111114107, 117115101115, "editor").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteWorkspaceListMembers)).
+					WithArgs(117115101115, 119111114107).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/workspace/members", bytes.NewBufferString(`{"workspace_id": 119111114107, "user": "test@example.com", "role": "editor"}`))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the member has been added", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The member has been added to the workspace."}`))
+			})
+		})
+	})
+
+	Describe("Edit workspace member", func() {
+		BeforeEach(func() {
+			r.PATCH("/todo/workspace/members", handler.AuthMiddleware(), handler.EditWorkspaceMember)
+		})
+
+		Context("the last admin", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectWorkspaceRole(117115101114, "admin")
+				expectWorkspaceRole(117115101114, "admin")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectWorkspaceMembers)).
+					WithArgs(119111114107).
+					WillReturnRows(sqlmock.NewRows(memberColumns).
+						AddRow(117115101114, "nktkln", "Test Name", "admin").
+						AddRow(117115101115, "test", "Test", "editor"))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPatch, "/todo/workspace/members", bytes.NewBufferString(`{"workspace_id": 119111114107, "user_id": 117115101114, "role": "viewer"}`))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the workspace must have an admin", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"The workspace must have an admin."}`))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectWorkspaceRole(117115101114, "admin")
+				expectWorkspaceRole(117115101115, "editor")
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditWorkspaceMemberRole)).
+					WithArgs("admin", 119111114107, 117115101115).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPatch, "/todo/workspace/members", bytes.NewBufferString(`{"workspace_id": 119111114107, "user_id": 117115101115, "role": "admin"}`))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the member role has been changed", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The member role has been changed."}`))
+			})
+		})
+	})
+
+	Describe("Delete workspace member", func() {
+		BeforeEach(func() {
+			r.DELETE("/todo/workspace/members", handler.AuthMiddleware(), handler.DeleteWorkspaceMember)
+		})
+
+		Context("not enough rights to remove another member", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectWorkspaceRole(117115101114, "editor")
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/todo/workspace/members?workspace_id=119111114107&user_id=117115101115", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that there are not enough rights", func() {
+				Expect(w.Code).To(Equal(http.StatusForbidden))
+				Expect(w.Body.String()).To(Equal(`{"error":"Not enough rights."}`))
+			})
+		})
+
+		Context("the last admin leaves", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectWorkspaceRole(117115101114, "admin")
+				expectWorkspaceRole(117115101114, "admin")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectWorkspaceMembers)).
+					WithArgs(119111114107).
+					WillReturnRows(sqlmock.NewRows(memberColumns).
+						AddRow(117115101114, "nktkln", "Test Name", "admin"))
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/todo/workspace/members?workspace_id=119111114107&user_id=117115101114", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the workspace must have an admin", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"The workspace must have an admin."}`))
+			})
+		})
+
+		Context("the viewer leaves", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectWorkspaceRole(117115101114, "viewer")
+				expectWorkspaceRole(117115101114, "viewer")
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteWorkspaceMemberReminders)).
+					WithArgs(117115101114, 119111114107).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteWorkspaceMemberAssignees)).
+					WithArgs(117115101114, 119111114107).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteWorkspaceMember)).
+					WithArgs(119111114107, 117115101114).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/todo/workspace/members?workspace_id=119111114107&user_id=117115101114", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the member has been removed", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The member has been removed from the workspace."}`))
+			})
+		})
+	})
+})
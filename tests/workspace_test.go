@@ -0,0 +1,265 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/NKTKLN/todo-api/models"
+	rd "github.com/NKTKLN/todo-api/pkg/db/redis"
+	"github.com/NKTKLN/todo-api/pkg/handlers"
+)
+
+var _ = Describe("Workspaces", func() {
+	var (
+		r                       *gin.Engine
+		w                       *httptest.ResponseRecorder
+		accessJwt               string
+		handler                 handlers.Handler
+		postgresMock            sqlmock.Sqlmock
+		redisClientAccessToken  *redis.Client
+		redisClientRefreshToken *redis.Client
+		redisClientSession      *redis.Client
+	)
+
+	expectWorkspaceRole := func(role string) {
+		rows := sqlmock.NewRows([]string{"role"})
+		if role != "" {
+			rows.AddRow(role)
+		}
+		postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectWorkspaceRole)).
+			WithArgs(119111114107, 117115101114).
+			WillReturnRows(rows)
+	}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.ReleaseMode)
+
+		r = gin.New()
+		w = httptest.NewRecorder()
+
+		redisClientAccessToken = TestRedisConnection()
+		redisClientRefreshToken = TestRedisConnection()
+		redisClientSession = TestRedisConnection()
+
+		handler.RedisClient = &rd.RedisClients{
+			AccessTokenClient:  redisClientAccessToken,
+			RefreshTokenClient: redisClientRefreshToken,
+			SessionClient:      redisClientSession,
+		}
+
+		handler.PostgresDB, postgresMock = MockPostgresConnection()
+
+		// Creating new session with a jwt token
+		accessJwt, _, _ = handler.RedisClient.CreateSession(context.Background(), models.Sessions{UserId: 117115101114})
+	})
+
+	AfterEach(func() {
+		redisClientAccessToken.Close()
+		redisClientRefreshToken.Close()
+		redisClientSession.Close()
+
+		Expect(postgresMock.ExpectationsWereMet()).ShouldNot(HaveOccurred())
+	})
+
+	Describe("Add workspace", func() {
+		BeforeEach(func() {
+			r.POST("/todo/workspace/add", handler.AuthMiddleware(), handler.AddWorkspace)
+		})
+
+		Context("empty name", func() {
+			BeforeEach(func() {
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/workspace/add", bytes.NewBufferString(`{"name": ""}`))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the name is empty", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"error":"Empty name."}`))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectWorkspaceById)).
+					WithArgs(AnyInt{}).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlInsertWorkspaceData)).
+					WithArgs("Test Workspace Name", AnyInt{}).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlInsertWorkspaceMember)).
+					WithArgs(AnyInt{}, 117115101114, "admin").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPost, "/todo/workspace/add", bytes.NewBufferString(`{"name": "Test Workspace Name"}`))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the workspace has been created", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The workspace has been created."}`))
+			})
+		})
+	})
+
+	Describe("Delete workspace", func() {
+		BeforeEach(func() {
+			r.DELETE("/todo/workspace/delete", handler.AuthMiddleware(), handler.DeleteWorkspace)
+		})
+
+		Context("this workspace not found", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectWorkspaceRole("")
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/todo/workspace/delete?workspace_id=119111114107", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that the workspace not found", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(Equal(`{"error":"This workspace not found."}`))
+			})
+		})
+
+		Context("not enough rights", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectWorkspaceRole("editor")
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/todo/workspace/delete?workspace_id=119111114107", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return an error that there are not enough rights", func() {
+				Expect(w.Code).To(Equal(http.StatusForbidden))
+				Expect(w.Body.String()).To(Equal(`{"error":"Not enough rights."}`))
+			})
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectWorkspaceRole("admin")
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectWorkspaceListsForDelete)).
+					WithArgs(119111114107).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "workspace_id", "name", "comment", "index"}).
+						AddRow(108105115116, 0, 119111114107, "Test List Name", "Test List Comment", 0))
+
+				postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectAllTasksByListId)).
+					WithArgs(108105115116).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteListMembers)).
+					WithArgs(108105115116).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				postgresMock.ExpectCommit()
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteList)).
+					WithArgs(108105115116).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteWorkspaceMembers)).
+					WithArgs(119111114107).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlDeleteWorkspace)).
+					WithArgs(119111114107).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodDelete, "/todo/workspace/delete?workspace_id=119111114107", nil)
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the workspace has been deleted", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"The workspace has been deleted."}`))
+			})
+		})
+	})
+
+	Describe("Edit workspace", func() {
+		BeforeEach(func() {
+			r.PUT("/todo/workspace/edit", handler.AuthMiddleware(), handler.EditWorkspace)
+		})
+
+		Context("ok", func() {
+			BeforeEach(func() {
+				// Query building for the postgres
+				expectWorkspaceRole("admin")
+
+				postgresMock.ExpectBegin()
+				postgresMock.ExpectExec(regexp.QuoteMeta(models.SqlEditWorkspace)).
+					WithArgs("New Workspace Name", 119111114107).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				postgresMock.ExpectCommit()
+
+				// Sending a query with data
+				req := httptest.NewRequest(http.MethodPut, "/todo/workspace/edit", bytes.NewBufferString(`{"id": 119111114107, "name": "New Workspace Name"}`))
+				req.Header.Set("token", accessJwt)
+				r.ServeHTTP(w, req)
+			})
+
+			It("should return a message that the workspace has been updated", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal(`{"message":"Updating the workspace data was successful."}`))
+			})
+		})
+	})
+
+	Describe("Show workspaces", func() {
+		var workspaces models.ApiShowWorkspaces
+
+		BeforeEach(func() {
+			r.GET("/todo/workspace/show", handler.AuthMiddleware(), handler.ShowWorkspaces)
+
+			// Query building for the postgres
+			postgresMock.ExpectQuery(regexp.QuoteMeta(models.SqlSelectUserWorkspaces)).
+				WithArgs(117115101114).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}).
+					AddRow(119111114107, "Test Workspace Name", "editor"))
+
+			// Sending a query with data
+			req := httptest.NewRequest(http.MethodGet, "/todo/workspace/show", nil)
+			req.Header.Set("token", accessJwt)
+			r.ServeHTTP(w, req)
+		})
+
+		It("should return the workspaces of the user", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(json.Unmarshal(w.Body.Bytes(), &workspaces)).ShouldNot(HaveOccurred())
+			Expect(workspaces.Workspaces).To(Equal([]models.WorkspacesData{{Id: 119111114107, Name: "Test Workspace Name", Role: "editor"}}))
+		})
+	})
+})